@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -11,12 +10,12 @@ import (
 	"os"
 
 	driver "github.com/tjmaynes/shopping-cart-service-go/internal/driver"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/config"
 	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
 )
 
 // SeedData ..
-func SeedData(jsonSource string, dbConn *sql.DB) []uuid.UUID {
-	cartRepository := item.NewRepository(dbConn)
+func SeedData(jsonSource string, cartRepository item.Repository) []uuid.UUID {
 	ctx := context.Background()
 	cartService := item.NewService(cartRepository)
 
@@ -55,12 +54,36 @@ func main() {
 
 	flag.Parse()
 
-	dbConn, err := driver.ConnectDB(*dbSource)
+	cartRepository, err := openRepository(*dbSource)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(-1)
 	}
 
-	ids := SeedData(*seedDataSource, dbConn)
+	ids := SeedData(*seedDataSource, cartRepository)
 	fmt.Printf("Added %d entries!", len(ids))
 }
+
+// openRepository opens the Postgres or SQLite database dbSource names. Seeding memory: would be
+// lost on exit, so it's refused.
+func openRepository(dbSource string) (item.Repository, error) {
+	switch driver.SchemeOf(dbSource) {
+	case driver.SchemeMemory:
+		return nil, fmt.Errorf("unable to seed %s, items in memory are lost on exit", dbSource)
+	case driver.SchemeSQLite:
+		dbConn, err := driver.ConnectSQLite(dbSource)
+		if err != nil {
+			return nil, err
+		}
+		return item.NewSQLiteRepository(dbConn), nil
+	default:
+		cfg := config.Default().Database
+		cfg.URL = dbSource
+
+		dbConn, err := driver.ConnectDB(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return item.NewRepository(dbConn), nil
+	}
+}
@@ -2,20 +2,52 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"log/slog"
 	"os"
 
 	api "github.com/tjmaynes/shopping-cart-service-go/internal/pkg/api"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/config"
 )
 
 func main() {
-	var (
-		dbSource   = flag.String("DATABASE_URL", os.Getenv("DATABASE_URL"), "Database source such as ./db/my.db.")
-		serverPort = flag.String("PORT", os.Getenv("PORT"), "Port to run server from.")
-	)
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	printConfig := flags.Bool("print-config", false, "Print the configuration, with secrets redacted, and exit.")
 
-	flag.Parse()
+	cfg, err := config.Load(flags, os.Args[1:], os.LookupEnv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(2)
+	}
 
-	api.
-		NewAPI(*dbSource).
-		Run(*serverPort)
+	switch args := flags.Args(); {
+	case len(args) > 0 && args[0] == "migrate":
+		if err := runMigrate(cfg, args[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	case len(args) > 0:
+		fmt.Fprintf(os.Stderr, "unknown command %q, the only command is migrate\n", args[0])
+		os.Exit(2)
+	}
+
+	if *printConfig {
+		if err := config.Print(os.Stdout, cfg); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	a, err := api.NewAPI(cfg)
+	if err != nil {
+		slog.Error("unable to start", "error", err)
+		os.Exit(1)
+	}
+
+	if err := a.Run(); err != nil {
+		slog.Error("stopped", "error", err)
+		os.Exit(1)
+	}
 }
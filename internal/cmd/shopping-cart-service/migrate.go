@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/db/migrations"
+	driver "github.com/tjmaynes/shopping-cart-service-go/internal/driver"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/config"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/logging"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/migrate"
+)
+
+var errMigrateUsage = errors.New("usage: shopping-cart-service [flags] migrate up|down|status|redo|dump")
+
+// runMigrate runs the migrate subcommand named by args against the configured database, writing
+// what it did to w.
+func runMigrate(cfg config.Config, args []string, w io.Writer) error {
+	if len(args) != 1 {
+		return errMigrateUsage
+	}
+	switch args[0] {
+	case "up", "down", "status", "redo", "dump":
+	default:
+		return errMigrateUsage
+	}
+
+	all, err := migrate.Load(migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	if scheme := driver.SchemeOf(cfg.Database.URL); scheme != driver.SchemePostgres {
+		return fmt.Errorf("migrations only apply to Postgres, not %s", scheme)
+	}
+
+	logger, err := logging.New(os.Stderr, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+
+	dbConn, err := driver.ConnectDB(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer dbConn.Close()
+
+	ctx := context.Background()
+	migrator := migrate.NewMigrator(dbConn, all)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Fprintf(w, "Applied: %s\n", migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Fprintln(w, "Already up to date")
+		}
+		return err
+	case "down":
+		migration, err := migrator.Down(ctx)
+		if err == nil {
+			fmt.Fprintf(w, "Rolled back: %s\n", migration.Name)
+		}
+		return err
+	case "redo":
+		migration, err := migrator.Redo(ctx)
+		if err == nil {
+			fmt.Fprintf(w, "Redone: %s\n", migration.Name)
+		}
+		return err
+	case "dump":
+		schema, err := migrate.Dump(ctx, dbConn)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, schema)
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		pending := 0
+		for _, status := range statuses {
+			mark := "X"
+			if !status.Applied {
+				mark = " "
+				pending++
+			}
+			fmt.Fprintf(w, "[%s] %s\n", mark, status.Name)
+		}
+		fmt.Fprintf(w, "\nApplied: %d\nPending: %d\n", len(statuses)-pending, pending)
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+-- migrate:up
+CREATE TABLE price_history (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  item_id uuid NOT NULL REFERENCES item (id) ON DELETE CASCADE,
+  price BIGINT NOT NULL,
+  effective_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX price_history_item_id_effective_at_idx ON price_history (item_id, effective_at DESC);
+
+INSERT INTO price_history (item_id, price) SELECT id, price FROM item;
+
+CREATE TABLE scheduled_price (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  item_id uuid NOT NULL REFERENCES item (id) ON DELETE CASCADE,
+  price BIGINT NOT NULL,
+  effective_at TIMESTAMPTZ NOT NULL,
+  applied_at TIMESTAMPTZ
+);
+
+CREATE INDEX scheduled_price_pending_idx ON scheduled_price (effective_at) WHERE applied_at IS NULL;
+
+-- migrate:down
+DROP TABLE IF EXISTS scheduled_price;
+DROP TABLE IF EXISTS price_history;
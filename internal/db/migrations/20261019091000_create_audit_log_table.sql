@@ -0,0 +1,31 @@
+-- migrate:up
+CREATE TABLE audit_log (
+  id BIGSERIAL PRIMARY KEY,
+  actor VARCHAR (255) NOT NULL,
+  request_id VARCHAR (255) NOT NULL DEFAULT '',
+  operation VARCHAR (32) NOT NULL,
+  entity_type VARCHAR (64) NOT NULL,
+  entity_id uuid NOT NULL,
+  before JSONB,
+  after JSONB,
+  diff JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX audit_log_entity_idx ON audit_log (entity_type, entity_id, id DESC);
+CREATE INDEX audit_log_created_at_idx ON audit_log (created_at);
+CREATE INDEX audit_log_actor_idx ON audit_log (actor, created_at);
+
+CREATE FUNCTION audit_log_prevent_mutation() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+  BEFORE UPDATE OR DELETE ON audit_log
+  FOR EACH ROW EXECUTE FUNCTION audit_log_prevent_mutation();
+
+-- migrate:down
+DROP TABLE IF EXISTS audit_log;
+DROP FUNCTION IF EXISTS audit_log_prevent_mutation();
@@ -0,0 +1,37 @@
+-- migrate:up
+CREATE TABLE item_event (
+  id BIGSERIAL PRIMARY KEY,
+  type VARCHAR (32) NOT NULL,
+  item_id uuid NOT NULL,
+  data JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE FUNCTION item_event_notify() RETURNS trigger AS $$
+DECLARE
+  event item_event;
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    INSERT INTO item_event (type, item_id, data) VALUES ('item.deleted', OLD.id, to_jsonb(OLD)) RETURNING * INTO event;
+  ELSIF TG_OP = 'UPDATE' THEN
+    IF OLD IS NOT DISTINCT FROM NEW THEN
+      RETURN NULL;
+    END IF;
+    INSERT INTO item_event (type, item_id, data) VALUES ('item.updated', NEW.id, to_jsonb(NEW)) RETURNING * INTO event;
+  ELSE
+    INSERT INTO item_event (type, item_id, data) VALUES ('item.created', NEW.id, to_jsonb(NEW)) RETURNING * INTO event;
+  END IF;
+
+  PERFORM pg_notify('item_events', to_jsonb(event)::text);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER item_event_notify
+  AFTER INSERT OR UPDATE OR DELETE ON item
+  FOR EACH ROW EXECUTE FUNCTION item_event_notify();
+
+-- migrate:down
+DROP TRIGGER IF EXISTS item_event_notify ON item;
+DROP FUNCTION IF EXISTS item_event_notify();
+DROP TABLE IF EXISTS item_event;
@@ -0,0 +1,31 @@
+-- migrate:up
+CREATE TABLE webhook_subscription (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  url TEXT NOT NULL,
+  event_types TEXT[] NOT NULL,
+  secret VARCHAR (255) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE webhook_delivery (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  subscription_id uuid NOT NULL REFERENCES webhook_subscription (id) ON DELETE CASCADE,
+  event_id BIGINT NOT NULL,
+  event_type VARCHAR (64) NOT NULL,
+  payload JSONB NOT NULL,
+  status VARCHAR (16) NOT NULL DEFAULT 'pending',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_error TEXT NOT NULL DEFAULT '',
+  last_response_code INTEGER,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  delivered_at TIMESTAMPTZ,
+  UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX webhook_delivery_due_idx ON webhook_delivery (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_delivery_subscription_idx ON webhook_delivery (subscription_id, created_at DESC);
+
+-- migrate:down
+DROP TABLE IF EXISTS webhook_delivery;
+DROP TABLE IF EXISTS webhook_subscription;
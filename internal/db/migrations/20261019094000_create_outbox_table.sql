@@ -0,0 +1,11 @@
+-- migrate:up
+CREATE TABLE outbox (
+  id BIGSERIAL PRIMARY KEY,
+  topic VARCHAR (255) NOT NULL,
+  key VARCHAR (255) NOT NULL,
+  payload JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS outbox;
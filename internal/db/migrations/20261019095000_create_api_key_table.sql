@@ -0,0 +1,14 @@
+-- migrate:up
+CREATE TABLE api_key (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  name VARCHAR (255) NOT NULL,
+  prefix VARCHAR (16) NOT NULL,
+  key_hash CHAR (64) NOT NULL UNIQUE,
+  scopes TEXT[] NOT NULL,
+  expires_at TIMESTAMPTZ,
+  last_used_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS api_key;
@@ -0,0 +1,8 @@
+-- migrate:up
+CREATE TABLE rate_limit_bucket (
+  key TEXT PRIMARY KEY,
+  tat TIMESTAMPTZ NOT NULL
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS rate_limit_bucket;
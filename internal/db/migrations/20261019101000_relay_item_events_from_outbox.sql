@@ -0,0 +1,40 @@
+-- migrate:up
+-- Item events are recorded by the outbox relay, under the id of the outbox message they come
+-- from, rather than by a trigger.
+DROP TRIGGER IF EXISTS item_event_notify ON item;
+DROP FUNCTION IF EXISTS item_event_notify();
+
+ALTER TABLE item_event ALTER COLUMN id DROP DEFAULT;
+DROP SEQUENCE IF EXISTS item_event_id_seq;
+
+-- Outbox ids carry on past the events and webhook deliveries recorded so far.
+SELECT setval('outbox_id_seq', GREATEST((SELECT COALESCE(max(id), 0) FROM item_event), (SELECT COALESCE(max(event_id), 0) FROM webhook_delivery), (SELECT last_value FROM outbox_id_seq)));
+
+-- migrate:down
+CREATE SEQUENCE item_event_id_seq OWNED BY item_event.id;
+SELECT setval('item_event_id_seq', (SELECT COALESCE(max(id), 0) + 1 FROM item_event), false);
+ALTER TABLE item_event ALTER COLUMN id SET DEFAULT nextval('item_event_id_seq');
+
+CREATE FUNCTION item_event_notify() RETURNS trigger AS $$
+DECLARE
+  event item_event;
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    INSERT INTO item_event (type, item_id, data) VALUES ('item.deleted', OLD.id, to_jsonb(OLD)) RETURNING * INTO event;
+  ELSIF TG_OP = 'UPDATE' THEN
+    IF OLD IS NOT DISTINCT FROM NEW THEN
+      RETURN NULL;
+    END IF;
+    INSERT INTO item_event (type, item_id, data) VALUES ('item.updated', NEW.id, to_jsonb(NEW)) RETURNING * INTO event;
+  ELSE
+    INSERT INTO item_event (type, item_id, data) VALUES ('item.created', NEW.id, to_jsonb(NEW)) RETURNING * INTO event;
+  END IF;
+
+  PERFORM pg_notify('item_events', to_jsonb(event)::text);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER item_event_notify
+  AFTER INSERT OR UPDATE OR DELETE ON item
+  FOR EACH ROW EXECUTE FUNCTION item_event_notify();
@@ -0,0 +1,10 @@
+-- migrate:up
+-- Listeners catch up from when events were recorded rather than from their ids, which are assigned
+-- before the events commit and so may commit out of order. Events older than the retention period
+-- are pruned by recorded_at too.
+ALTER TABLE item_event ADD COLUMN recorded_at TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp();
+CREATE INDEX item_event_recorded_at_idx ON item_event (recorded_at, id);
+
+-- migrate:down
+DROP INDEX item_event_recorded_at_idx;
+ALTER TABLE item_event DROP COLUMN recorded_at;
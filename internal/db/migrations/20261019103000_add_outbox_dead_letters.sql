@@ -0,0 +1,13 @@
+-- migrate:up
+-- Messages that keep failing to be published are set aside as dead letters once they have been
+-- attempted too many times, so that the messages behind them are still relayed.
+ALTER TABLE outbox ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE outbox ADD COLUMN last_error TEXT NOT NULL DEFAULT '';
+ALTER TABLE outbox ADD COLUMN dead_at TIMESTAMPTZ;
+CREATE INDEX outbox_pending_idx ON outbox (id) WHERE dead_at IS NULL;
+
+-- migrate:down
+DROP INDEX outbox_pending_idx;
+ALTER TABLE outbox DROP COLUMN dead_at;
+ALTER TABLE outbox DROP COLUMN last_error;
+ALTER TABLE outbox DROP COLUMN attempts;
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// FS holds the dbmate migrations, named <version>_<description>.sql.
+//
+//go:embed *.sql
+var FS embed.FS
+
+// Versions returns the versions of the migrations in FS, oldest first.
+func Versions() ([]string, error) {
+	names, err := fs.Glob(FS, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0, len(names))
+	for _, name := range names {
+		version, _, _ := strings.Cut(name, "_")
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	return versions, nil
+}
@@ -0,0 +1,110 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	neturl "net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	driver "github.com/tjmaynes/shopping-cart-service-go/internal/driver"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/config"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/migrate"
+)
+
+func Test_Versions_ShouldListEmbeddedMigrationsOldestFirst(t *testing.T) {
+	versions, err := Versions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(versions) == 0 || versions[0] != "20190626153002" {
+		t.Fatalf("expected the item table's migration first, got %v", versions)
+	}
+	for i := 1; i < len(versions); i++ {
+		if versions[i-1] >= versions[i] {
+			t.Errorf("expected versions to be unique and ascending, got %v", versions)
+		}
+	}
+}
+
+func Test_SchemaDump_ShouldMatchMigratedDatabase(t *testing.T) {
+	url := os.Getenv("DATABASE_URL")
+	if url == "" || driver.SchemeOf(url) != driver.SchemePostgres {
+		t.Skip("DATABASE_URL isn't set, skipping the schema dump")
+	}
+
+	dump, err := os.ReadFile("../schema.sql")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	all, err := migrate.Load(FS)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	dbConn := scratchDatabase(t, url)
+
+	if _, err := migrate.NewMigrator(dbConn, all).Up(ctx); err != nil {
+		t.Fatalf("unexpected error migrating a new database: %v", err)
+	}
+
+	schema, err := migrate.Dump(ctx, dbConn)
+	if err != nil {
+		t.Fatalf("unexpected error dumping the schema: %v", err)
+	}
+
+	if string(dump) != schema {
+		t.Errorf("internal/db/schema.sql is out of date with the migrations, regenerate it with `make dump_schema`. Expected:\n%s", schema)
+	}
+}
+
+// scratchDatabase creates an empty database alongside the one url names, dropping it once the test
+// is done, and connects to it.
+func scratchDatabase(t *testing.T, url string) *sql.DB {
+	cfg := config.Default().Database
+	cfg.URL = url
+	adminConn, err := driver.ConnectDB(cfg)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when connecting to the database", err)
+	}
+	t.Cleanup(func() { adminConn.Close() })
+
+	name := fmt.Sprintf("schema_dump_%d", time.Now().UnixNano())
+	if _, err := adminConn.Exec("CREATE DATABASE " + name); err != nil {
+		t.Fatalf("an error '%s' was not expected when creating a scratch database", err)
+	}
+	t.Cleanup(func() { adminConn.Exec("DROP DATABASE IF EXISTS " + name + " WITH (FORCE)") })
+
+	cfg.URL, err = withDatabaseName(url, name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dbConn, err := driver.ConnectDB(cfg)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when connecting to the scratch database", err)
+	}
+	t.Cleanup(func() { dbConn.Close() })
+
+	return dbConn
+}
+
+// withDatabaseName returns dsn, either a URL or key=value pairs, naming the database name instead.
+func withDatabaseName(dsn string, name string) (string, error) {
+	if !strings.HasPrefix(dsn, "postgres://") && !strings.HasPrefix(dsn, "postgresql://") {
+		return dsn + " dbname=" + name, nil
+	}
+
+	u, err := neturl.Parse(dsn)
+	if err != nil {
+		return "", err
+	}
+	u.Path = "/" + name
+
+	return u.String(), nil
+}
@@ -1,21 +1,181 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/XSAM/otelsql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/config"
 )
 
-// ConnectDB ..
-func ConnectDB(dbConnectionString string) (*sql.DB, error) {
-	db, error := sql.Open("postgres", dbConnectionString)
-	if error != nil {
-		return nil, error
+const (
+	minRetryInterval = 100 * time.Millisecond
+	maxRetryInterval = 5 * time.Second
+
+	// unlimited stands in for no limit on how long pgxpool keeps a connection, short of
+	// overflowing the times it's added to.
+	unlimited = 100 * 365 * 24 * time.Hour
+)
+
+// ConnectDB opens a connection pool to the Postgres database as configured by cfg, tracing each
+// SQL statement run within a span as a child of it. Until the database accepts connections it
+// retries with exponential backoff, giving up after cfg.ConnectTimeout.
+func ConnectDB(cfg config.Database) (*sql.DB, error) {
+	connector, err := newConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db := otelsql.OpenDB(connector,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			DisableErrSkip:       true,
+			OmitConnResetSession: true,
+			OmitRows:             true,
+			SpanFilter:           withParentSpan,
+		}),
+	)
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	if strings.EqualFold(cfg.Driver, "pgxpool") {
+		// Idle connections are kept by the pgx pool, which would otherwise be drained into this one.
+		db.SetMaxIdleConns(0)
+	} else {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+		db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 	}
 
-	if err := db.Ping(); err != nil {
+	if err := ping(db, cfg.ConnectTimeout); err != nil {
+		db.Close()
 		return nil, err
 	}
 
 	return db, nil
 }
+
+// newConnector returns a connector for cfg.Driver: lib/pq, pgx, or pgx with its own pool.
+func newConnector(cfg config.Database) (driver.Connector, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case "", "pq":
+		dsn, err := withStatementTimeout(cfg.URL, cfg.StatementTimeout)
+		if err != nil {
+			return nil, err
+		}
+		return pq.NewConnector(dsn)
+	case "pgx":
+		connConfig, err := pgx.ParseConfig(cfg.URL)
+		if err != nil {
+			return nil, err
+		}
+		setStatementTimeout(connConfig, cfg.StatementTimeout)
+		return stdlib.GetConnector(*connConfig), nil
+	case "pgxpool":
+		poolConfig, err := pgxpool.ParseConfig(cfg.URL)
+		if err != nil {
+			return nil, err
+		}
+		setStatementTimeout(poolConfig.ConnConfig, cfg.StatementTimeout)
+		poolConfig.MaxConns = int32(cfg.MaxOpenConns)
+		poolConfig.MaxConnLifetime = unlimitedWhenZero(cfg.ConnMaxLifetime)
+		poolConfig.MaxConnIdleTime = unlimitedWhenZero(cfg.ConnMaxIdleTime)
+
+		pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+		if err != nil {
+			return nil, err
+		}
+		return poolConnector{Connector: stdlib.GetPoolConnector(pool), pool: pool}, nil
+	default:
+		return nil, fmt.Errorf("invalid database driver %q, must be pq, pgx or pgxpool", cfg.Driver)
+	}
+}
+
+// poolConnector closes its pgx pool when the sql.DB using it is closed.
+type poolConnector struct {
+	driver.Connector
+	pool *pgxpool.Pool
+}
+
+func (c poolConnector) Close() error {
+	c.pool.Close()
+	return nil
+}
+
+// ping waits for db to accept a connection, retrying with exponential backoff for up to timeout.
+func ping(db *sql.DB, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	interval := minRetryInterval
+	for attempt := 1; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+
+		slog.Warn("database unavailable", "attempt", attempt, "retry_in", interval, "error", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("database unavailable after %d attempts in %s: %w", attempt, timeout, err)
+		case <-time.After(interval):
+		}
+
+		interval = min(interval*2, maxRetryInterval)
+	}
+}
+
+// withStatementTimeout adds Postgres's statement_timeout to dsn, either a URL or key=value pairs,
+// which lib/pq passes on as a run-time parameter of each connection.
+func withStatementTimeout(dsn string, timeout time.Duration) (string, error) {
+	if timeout <= 0 {
+		return dsn, nil
+	}
+	milliseconds := strconv.FormatInt(timeout.Milliseconds(), 10)
+
+	if !strings.HasPrefix(dsn, "postgres://") && !strings.HasPrefix(dsn, "postgresql://") {
+		return dsn + " statement_timeout=" + milliseconds, nil
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("statement_timeout", milliseconds)
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+func setStatementTimeout(connConfig *pgx.ConnConfig, timeout time.Duration) {
+	if timeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(timeout.Milliseconds(), 10)
+	}
+}
+
+// unlimitedWhenZero maps database/sql's 0 for no limit onto pgxpool, which takes 0 literally.
+func unlimitedWhenZero(d time.Duration) time.Duration {
+	if d == 0 {
+		return unlimited
+	}
+	return d
+}
+
+// withParentSpan skips tracing the statements that background workers, such as the outbox relay,
+// run outside of any trace, so that polling doesn't flood the exporter with root spans.
+func withParentSpan(ctx context.Context, _ otelsql.Method, _ string, _ []driver.NamedValue) bool {
+	return trace.SpanContextFromContext(ctx).IsValid()
+}
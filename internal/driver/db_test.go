@@ -0,0 +1,63 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/config"
+)
+
+func Test_ConnectDB_WhenDatabaseIsUnreachable_ShouldRetryUntilConnectTimeout(t *testing.T) {
+	for _, driver := range []string{"pq", "pgx", "pgxpool"} {
+		t.Run(driver, func(t *testing.T) {
+			cfg := config.Default().Database
+			cfg.URL = "postgres://postgres@127.0.0.1:1/shopping-cart?sslmode=disable"
+			cfg.Driver = driver
+			cfg.ConnectTimeout = 500 * time.Millisecond
+
+			start := time.Now()
+			db, err := ConnectDB(cfg)
+
+			if err == nil || db != nil {
+				t.Fatalf("Expected an error. Got %v", err)
+			}
+			if !strings.Contains(err.Error(), "attempts") {
+				t.Errorf("Expected the attempts to be reported. Got %v", err)
+			}
+			if elapsed := time.Since(start); elapsed < cfg.ConnectTimeout {
+				t.Errorf("Expected retries for %s. Gave up after %s", cfg.ConnectTimeout, elapsed)
+			}
+		})
+	}
+}
+
+func Test_ConnectDB_WhenDriverIsUnknown_ShouldReturnError(t *testing.T) {
+	cfg := config.Default().Database
+	cfg.URL = "postgres://postgres@127.0.0.1:1/shopping-cart"
+	cfg.Driver = "mysql"
+
+	if _, err := ConnectDB(cfg); err == nil || !strings.Contains(err.Error(), "mysql") {
+		t.Errorf("Expected the driver to be rejected. Got %v", err)
+	}
+}
+
+func Test_withStatementTimeout_ShouldAddItToEitherFormOfConnectionString(t *testing.T) {
+	for _, tc := range []struct {
+		dsn      string
+		timeout  time.Duration
+		expected string
+	}{
+		{"postgres://localhost/shopping-cart?sslmode=disable", 5 * time.Second, "postgres://localhost/shopping-cart?sslmode=disable&statement_timeout=5000"},
+		{"host=localhost dbname=shopping-cart", 1500 * time.Millisecond, "host=localhost dbname=shopping-cart statement_timeout=1500"},
+		{"postgres://localhost/shopping-cart", 0, "postgres://localhost/shopping-cart"},
+	} {
+		actual, err := withStatementTimeout(tc.dsn, tc.timeout)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if actual != tc.expected {
+			t.Errorf("Expected %q. Got %q", tc.expected, actual)
+		}
+	}
+}
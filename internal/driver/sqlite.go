@@ -0,0 +1,73 @@
+package db
+
+import (
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/XSAM/otelsql"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	_ "modernc.org/sqlite"
+)
+
+// The schemes of DATABASE_URL, which selects where items are stored.
+const (
+	SchemePostgres = "postgres"
+	SchemeSQLite   = "sqlite"
+	SchemeMemory   = "memory"
+)
+
+//go:embed sqlite.sql
+var sqliteSchema string
+
+// SchemeOf returns which backend dsn names: memory for memory: (the items are kept in memory),
+// sqlite for sqlite:path, and otherwise postgres.
+func SchemeOf(dsn string) string {
+	scheme, _, _ := strings.Cut(dsn, ":")
+	switch strings.ToLower(scheme) {
+	case SchemeMemory:
+		return SchemeMemory
+	case SchemeSQLite:
+		return SchemeSQLite
+	default:
+		return SchemePostgres
+	}
+}
+
+// ConnectSQLite opens the SQLite database that dsn names, such as sqlite:///var/lib/cart.db,
+// sqlite://cart.db relative to the working directory, or sqlite::memory:, and creates the item
+// tables if they don't exist. SQLite allows one writer at a time, so the pool has one connection;
+// that also keeps an in-memory database alive for as long as the pool.
+func ConnectSQLite(dsn string) (*sql.DB, error) {
+	if SchemeOf(dsn) != SchemeSQLite {
+		return nil, fmt.Errorf("invalid SQLite database URL %q, must start with sqlite:", dsn)
+	}
+	_, name, _ := strings.Cut(dsn, ":")
+	path, params, _ := strings.Cut(strings.TrimPrefix(name, "//"), "?")
+	if params != "" {
+		params += "&"
+	}
+	params += "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+
+	db, err := otelsql.Open("sqlite", path+"?"+params,
+		otelsql.WithAttributes(semconv.DBSystemSqlite),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			DisableErrSkip:       true,
+			OmitConnResetSession: true,
+			OmitRows:             true,
+			SpanFilter:           withParentSpan,
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
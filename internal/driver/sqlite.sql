@@ -0,0 +1,28 @@
+-- The item tables for SQLite, created by ConnectSQLite. Times are nanoseconds since the Unix
+-- epoch and ids are uuids in text form.
+
+CREATE TABLE IF NOT EXISTS item (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL,
+  price INTEGER NOT NULL,
+  manufacturer TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS price_history (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  item_id TEXT NOT NULL REFERENCES item (id) ON DELETE CASCADE,
+  price INTEGER NOT NULL,
+  effective_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS price_history_item_id_effective_at_idx ON price_history (item_id, effective_at DESC);
+
+CREATE TABLE IF NOT EXISTS scheduled_price (
+  id TEXT PRIMARY KEY,
+  item_id TEXT NOT NULL REFERENCES item (id) ON DELETE CASCADE,
+  price INTEGER NOT NULL,
+  effective_at INTEGER NOT NULL,
+  applied_at INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS scheduled_price_pending_idx ON scheduled_price (effective_at) WHERE applied_at IS NULL;
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func Test_SchemeOf_ShouldSelectBackendByScheme(t *testing.T) {
+	for dsn, expected := range map[string]string{
+		"memory:":                             SchemeMemory,
+		"memory://":                           SchemeMemory,
+		"sqlite::memory:":                     SchemeSQLite,
+		"SQLite:///var/lib/shopping-cart.db":  SchemeSQLite,
+		"postgres://localhost/shopping-cart":  SchemePostgres,
+		"host=localhost dbname=shopping-cart": SchemePostgres,
+	} {
+		if actual := SchemeOf(dsn); actual != expected {
+			t.Errorf("Expected %s for %q. Got %s", expected, dsn, actual)
+		}
+	}
+}
+
+func Test_ConnectSQLite_ShouldCreateTablesOnce(t *testing.T) {
+	dsn := "sqlite://" + filepath.Join(t.TempDir(), "shopping-cart.db")
+
+	for i := 0; i < 2; i++ {
+		db, err := ConnectSQLite(dsn)
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a SQLite database", err)
+		}
+
+		var foreignKeys int
+		if err := db.QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys); err != nil || foreignKeys != 1 {
+			t.Errorf("Expected foreign keys to be enforced. Got %d, %v", foreignKeys, err)
+		}
+		if _, err := db.Exec("SELECT id, name, price, manufacturer FROM item"); err != nil {
+			t.Errorf("Expected the item table to exist. Got %v", err)
+		}
+		db.Close()
+	}
+}
+
+func Test_ConnectSQLite_WhenURLIsNotSQLite_ShouldReturnError(t *testing.T) {
+	if _, err := ConnectSQLite("postgres://localhost/shopping-cart"); err == nil {
+		t.Error("Expected an error")
+	}
+}
@@ -0,0 +1,385 @@
+package content
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const (
+	// JSON ..
+	JSON = "application/json"
+
+	// Form ..
+	Form = "application/x-www-form-urlencoded"
+
+	// MsgPack ..
+	MsgPack = "application/msgpack"
+
+	// CSV is only offered for list responses.
+	CSV = "text/csv"
+)
+
+var (
+	// ErrUnsupportedMediaType ..
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+	// ErrNotAcceptable ..
+	ErrNotAcceptable = errors.New("not acceptable")
+)
+
+// msgPackAliases are the other names clients commonly send MessagePack under.
+var msgPackAliases = []string{"application/x-msgpack", "application/vnd.msgpack"}
+
+// Decode reads the request body into v according to its Content-Type. Bodies without a
+// Content-Type are read as JSON.
+func Decode(r *http.Request, v interface{}) error {
+	mediaType := JSON
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		parsed, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return ErrUnsupportedMediaType
+		}
+		mediaType = parsed
+	}
+
+	switch Canonical(mediaType) {
+	case JSON:
+		return json.NewDecoder(r.Body).Decode(v)
+	case Form:
+		return decodeForm(r, v)
+	case MsgPack:
+		var generic interface{}
+		if err := msgpack.NewDecoder(r.Body).Decode(&generic); err != nil {
+			return err
+		}
+		return convert(generic, v)
+	default:
+		return ErrUnsupportedMediaType
+	}
+}
+
+// CreateResponse writes payload in the representation the Accept header asks for, falling back
+// to JSON when the client accepts anything. Payloads shaped like {"data": [...]} can also be
+// written as CSV.
+func CreateResponse(w http.ResponseWriter, r *http.Request, code int, payload interface{}) {
+	mediaType, err := Negotiate(r.Header.Get("Accept"), Offers(payload))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+		return
+	}
+
+	var response []byte
+	switch mediaType {
+	case MsgPack:
+		response, err = encodeMsgPack(payload)
+	case CSV:
+		response, err = encodeCSV(payload)
+		mediaType = CSV + "; charset=utf-8"
+	default:
+		response, err = json.Marshal(payload)
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "unable to encode response", "media_type", mediaType, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+// Offers lists the media types payload can be written as, most preferred first.
+func Offers(payload interface{}) []string {
+	if _, ok := listData(payload); ok {
+		return []string{JSON, MsgPack, CSV}
+	}
+	return []string{JSON, MsgPack}
+}
+
+// Negotiate picks the offer that best matches accept, honoring q-values and wildcards. An empty
+// Accept header accepts the first offer.
+func Negotiate(accept string, offers []string) (string, error) {
+	if strings.TrimSpace(accept) == "" {
+		return offers[0], nil
+	}
+
+	type acceptRange struct {
+		mediaType string
+		quality   float64
+	}
+
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+		ranges = append(ranges, acceptRange{mediaType: Canonical(mediaType), quality: quality})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	for _, acceptable := range ranges {
+		if acceptable.quality <= 0 {
+			continue
+		}
+		for _, offer := range offers {
+			if matches(acceptable.mediaType, offer) {
+				return offer, nil
+			}
+		}
+	}
+
+	return "", ErrNotAcceptable
+}
+
+func matches(acceptable string, offer string) bool {
+	if acceptable == "*/*" || acceptable == offer {
+		return true
+	}
+
+	if strings.HasSuffix(acceptable, "/*") {
+		return strings.HasPrefix(offer, strings.TrimSuffix(acceptable, "*"))
+	}
+
+	return false
+}
+
+// Canonical returns the name this package uses for mediaType, mapping MessagePack aliases onto
+// MsgPack.
+func Canonical(mediaType string) string {
+	for _, alias := range msgPackAliases {
+		if mediaType == alias {
+			return MsgPack
+		}
+	}
+	return mediaType
+}
+
+// decodeForm maps form values onto the JSON fields of v, parsing numbers and collecting repeated
+// keys for slice fields, so forms and JSON bodies decode into the same structs.
+func decodeForm(r *http.Request, v interface{}) error {
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+
+	modelType := reflect.TypeOf(v)
+	for modelType.Kind() == reflect.Pointer {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return errors.New("form bodies can only be decoded into structs")
+	}
+
+	fields := make(map[string]interface{})
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		values, ok := r.PostForm[name]
+		if !ok || len(values) == 0 {
+			continue
+		}
+
+		switch field.Type.Kind() {
+		case reflect.Slice:
+			fields[name] = values
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			number, err := strconv.ParseInt(values[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			fields[name] = number
+		case reflect.Float32, reflect.Float64:
+			number, err := strconv.ParseFloat(values[0], 64)
+			if err != nil {
+				return err
+			}
+			fields[name] = number
+		case reflect.Bool:
+			boolean, err := strconv.ParseBool(values[0])
+			if err != nil {
+				return err
+			}
+			fields[name] = boolean
+		default:
+			fields[name] = values[0]
+		}
+	}
+
+	return convert(fields, v)
+}
+
+// convert round-trips value through JSON so every media type shares the JSON field names and
+// formats.
+func convert(value interface{}, v interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// toGeneric returns payload as the maps, slices and scalars its JSON encoding is made of.
+func toGeneric(payload interface{}) (interface{}, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	return withNumbers(generic), nil
+}
+
+func withNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = withNumbers(child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = withNumbers(child)
+		}
+		return v
+	case json.Number:
+		if integer, err := v.Int64(); err == nil {
+			return integer
+		}
+		float, _ := v.Float64()
+		return float
+	default:
+		return v
+	}
+}
+
+func encodeMsgPack(payload interface{}) ([]byte, error) {
+	generic, err := toGeneric(payload)
+	if err != nil {
+		return nil, err
+	}
+	return msgpack.Marshal(generic)
+}
+
+// listData returns the slice held under "data" when payload is a list response.
+func listData(payload interface{}) (reflect.Value, bool) {
+	value := reflect.ValueOf(payload)
+	if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+		return reflect.Value{}, false
+	}
+
+	data := value.MapIndex(reflect.ValueOf("data"))
+	if !data.IsValid() {
+		return reflect.Value{}, false
+	}
+	for data.Kind() == reflect.Interface {
+		data = data.Elem()
+	}
+	if data.Kind() != reflect.Slice || data.Type().Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	return data, true
+}
+
+// encodeCSV writes one row per element with a header of JSON field names. Scalars are written
+// as-is, except for text a spreadsheet would run as a formula, which is quoted with a leading
+// apostrophe; nested values are written as JSON.
+func encodeCSV(payload interface{}) ([]byte, error) {
+	data, ok := listData(payload)
+	if !ok {
+		return nil, ErrNotAcceptable
+	}
+
+	elemType := data.Type().Elem()
+	var header []string
+	var indexes []int
+	for i := 0; i < elemType.NumField(); i++ {
+		name := strings.Split(elemType.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || !elemType.Field(i).IsExported() {
+			continue
+		}
+		header = append(header, name)
+		indexes = append(indexes, i)
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for row := 0; row < data.Len(); row++ {
+		record := make([]string, 0, len(indexes))
+		for _, index := range indexes {
+			cell, err := csvCell(data.Index(row).Field(index).Interface())
+			if err != nil {
+				return nil, err
+			}
+			record = append(record, cell)
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+// csvFormulaPrefixes are the characters that make a spreadsheet treat a cell as a formula.
+const csvFormulaPrefixes = "=+-@\t\r"
+
+func csvCell(value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	if string(encoded) == "null" {
+		return "", nil
+	}
+
+	var text string
+	if err := json.Unmarshal(encoded, &text); err == nil {
+		if text != "" && strings.ContainsRune(csvFormulaPrefixes, rune(text[0])) {
+			return "'" + text, nil
+		}
+		return text, nil
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,220 @@
+package content
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type testItem struct {
+	Name   string   `json:"name"`
+	Price  int64    `json:"price"`
+	Tags   []string `json:"tags"`
+	Hidden string   `json:"-"`
+}
+
+type testRow struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Tags      []string  `json:"tags"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func Test_Negotiate_WhenAcceptIsEmpty_ShouldReturnFirstOffer(t *testing.T) {
+	result, err := Negotiate("", []string{JSON, MsgPack})
+
+	if err != nil || result != JSON {
+		t.Errorf("expected %s, got %s (%v)", JSON, result, err)
+	}
+}
+
+func Test_Negotiate_WhenAcceptHasQualities_ShouldReturnPreferredOffer(t *testing.T) {
+	result, err := Negotiate("application/json;q=0.5, text/csv", []string{JSON, MsgPack, CSV})
+
+	if err != nil || result != CSV {
+		t.Errorf("expected %s, got %s (%v)", CSV, result, err)
+	}
+}
+
+func Test_Negotiate_WhenAcceptIsWildcard_ShouldReturnFirstMatchingOffer(t *testing.T) {
+	result, err := Negotiate("text/*", []string{JSON, MsgPack, CSV})
+
+	if err != nil || result != CSV {
+		t.Errorf("expected %s, got %s (%v)", CSV, result, err)
+	}
+}
+
+func Test_Negotiate_WhenAcceptIsMsgPackAlias_ShouldReturnMsgPack(t *testing.T) {
+	result, err := Negotiate("application/x-msgpack", []string{JSON, MsgPack})
+
+	if err != nil || result != MsgPack {
+		t.Errorf("expected %s, got %s (%v)", MsgPack, result, err)
+	}
+}
+
+func Test_Negotiate_WhenNothingMatches_ShouldReturnNotAcceptable(t *testing.T) {
+	_, err := Negotiate("application/xml, text/csv;q=0", []string{JSON, CSV})
+
+	if !errors.Is(err, ErrNotAcceptable) {
+		t.Errorf("expected ErrNotAcceptable, got %v", err)
+	}
+}
+
+func Test_Decode_WhenBodyIsJSON_ShouldDecode(t *testing.T) {
+	request := httptest.NewRequest("POST", "/", strings.NewReader(`{"name": "Apple", "price": 199}`))
+	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	var result testItem
+	err := Decode(request, &result)
+
+	if err != nil || result.Name != "Apple" || result.Price != 199 {
+		t.Errorf("unexpected result %+v (%v)", result, err)
+	}
+}
+
+func Test_Decode_WhenContentTypeIsMissing_ShouldDecodeJSON(t *testing.T) {
+	request := httptest.NewRequest("POST", "/", strings.NewReader(`{"name": "Apple"}`))
+
+	var result testItem
+	err := Decode(request, &result)
+
+	if err != nil || result.Name != "Apple" {
+		t.Errorf("unexpected result %+v (%v)", result, err)
+	}
+}
+
+func Test_Decode_WhenBodyIsForm_ShouldDecode(t *testing.T) {
+	request := httptest.NewRequest("POST", "/", strings.NewReader("name=Apple&price=199&tags=a&tags=b&Hidden=x"))
+	request.Header.Set("Content-Type", Form)
+
+	var result testItem
+	err := Decode(request, &result)
+
+	if err != nil || result.Name != "Apple" || result.Price != 199 || len(result.Tags) != 2 || result.Hidden != "" {
+		t.Errorf("unexpected result %+v (%v)", result, err)
+	}
+}
+
+func Test_Decode_WhenFormNumberIsInvalid_ShouldReturnError(t *testing.T) {
+	request := httptest.NewRequest("POST", "/", strings.NewReader("name=Apple&price=cheap"))
+	request.Header.Set("Content-Type", Form)
+
+	var result testItem
+	err := Decode(request, &result)
+
+	if err == nil || errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("expected a decoding error, got %v", err)
+	}
+}
+
+func Test_Decode_WhenBodyIsMsgPack_ShouldDecode(t *testing.T) {
+	body, _ := msgpack.Marshal(map[string]interface{}{"name": "Apple", "price": 199})
+	request := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+	request.Header.Set("Content-Type", "application/vnd.msgpack")
+
+	var result testItem
+	err := Decode(request, &result)
+
+	if err != nil || result.Name != "Apple" || result.Price != 199 {
+		t.Errorf("unexpected result %+v (%v)", result, err)
+	}
+}
+
+func Test_Decode_WhenContentTypeIsUnsupported_ShouldReturnUnsupportedMediaType(t *testing.T) {
+	request := httptest.NewRequest("POST", "/", strings.NewReader("name: Apple"))
+	request.Header.Set("Content-Type", "application/yaml")
+
+	var result testItem
+	err := Decode(request, &result)
+
+	if !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("expected ErrUnsupportedMediaType, got %v", err)
+	}
+}
+
+func Test_CreateResponse_WhenAcceptIsCSV_ShouldWriteRows(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	request := httptest.NewRequest("GET", "/", nil)
+	request.Header.Set("Accept", "text/csv")
+	recorder := httptest.NewRecorder()
+
+	CreateResponse(recorder, request, http.StatusOK, map[string][]testRow{
+		"data": {{ID: 1, Name: "Apple, Red", Tags: []string{"fruit"}, UpdatedAt: updatedAt}},
+	})
+
+	expected := "id,name,tags,updated_at\n1,\"Apple, Red\",\"[\"\"fruit\"\"]\",2024-01-02T03:04:05Z\n"
+	if body := recorder.Body.String(); body != expected {
+		t.Errorf("expected %q, got %q", expected, body)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, CSV) {
+		t.Errorf("unexpected Content-Type %s", contentType)
+	}
+}
+
+func Test_CreateResponse_WhenAcceptIsCSV_ShouldQuoteCellsThatWouldRunAsFormulas(t *testing.T) {
+	request := httptest.NewRequest("GET", "/", nil)
+	request.Header.Set("Accept", "text/csv")
+	recorder := httptest.NewRecorder()
+
+	CreateResponse(recorder, request, http.StatusOK, map[string][]testRow{
+		"data": {
+			{ID: -1, Name: "=HYPERLINK(\"http://example.com\")"},
+			{ID: 2, Name: "+1"},
+			{ID: 3, Name: "-1"},
+			{ID: 4, Name: "@SUM(A1)"},
+			{ID: 5, Name: "\tTab"},
+			{ID: 6, Name: "\rReturn"},
+			{ID: 7, Name: "Apple = Red"},
+		},
+	})
+
+	expected := "id,name,tags,updated_at\n" +
+		"-1,\"'=HYPERLINK(\"\"http://example.com\"\")\",,0001-01-01T00:00:00Z\n" +
+		"2,'+1,,0001-01-01T00:00:00Z\n" +
+		"3,'-1,,0001-01-01T00:00:00Z\n" +
+		"4,'@SUM(A1),,0001-01-01T00:00:00Z\n" +
+		"5,'\tTab,,0001-01-01T00:00:00Z\n" +
+		"6,\"'\rReturn\",,0001-01-01T00:00:00Z\n" +
+		"7,Apple = Red,,0001-01-01T00:00:00Z\n"
+	if body := recorder.Body.String(); body != expected {
+		t.Errorf("expected %q, got %q", expected, body)
+	}
+}
+
+func Test_CreateResponse_WhenPayloadIsNotAList_ShouldNotOfferCSV(t *testing.T) {
+	request := httptest.NewRequest("GET", "/", nil)
+	request.Header.Set("Accept", "text/csv")
+	recorder := httptest.NewRecorder()
+
+	CreateResponse(recorder, request, http.StatusOK, map[string]testRow{"data": {ID: 1}})
+
+	if recorder.Code != http.StatusNotAcceptable {
+		t.Errorf("expected %d, got %d", http.StatusNotAcceptable, recorder.Code)
+	}
+}
+
+func Test_CreateResponse_WhenAcceptIsMsgPack_ShouldWriteMsgPack(t *testing.T) {
+	request := httptest.NewRequest("GET", "/", nil)
+	request.Header.Set("Accept", "application/msgpack")
+	recorder := httptest.NewRecorder()
+
+	CreateResponse(recorder, request, http.StatusCreated, map[string]testRow{"data": {ID: 1, Name: "Apple"}})
+
+	var result map[string]map[string]interface{}
+	if err := msgpack.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if recorder.Code != http.StatusCreated || fmt.Sprint(result["data"]["id"]) != "1" || result["data"]["name"] != "Apple" {
+		t.Errorf("unexpected response %d %+v", recorder.Code, result)
+	}
+	if vary := recorder.Header().Get("Vary"); vary != "Accept" {
+		t.Errorf("expected Vary: Accept, got %s", vary)
+	}
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+
+	jsonHandler "github.com/tjmaynes/shopping-cart-service-go/internal/handler/json"
+	cart "github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+// NewGraphQLHandler ..
+func NewGraphQLHandler(service cart.Service) (*GraphQLHandler, error) {
+	schema, err := NewSchema(service)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GraphQLHandler{Service: service, Schema: schema}, nil
+}
+
+// GraphQLHandler ..
+type GraphQLHandler struct {
+	Service cart.Service
+	Schema  graphql.Schema
+}
+
+// Request ..
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Query serves POST requests with a JSON body and GET requests with query parameters. Mutations
+// are only accepted over POST.
+func (g *GraphQLHandler) Query(w http.ResponseWriter, r *http.Request) {
+	var request Request
+
+	switch r.Method {
+	case "GET":
+		request.Query = r.URL.Query().Get("query")
+		request.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+		}
+	case "POST":
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if request.Query == "" {
+		http.Error(w, "query: cannot be blank.", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == "GET" && isMutation(request) {
+		http.Error(w, "mutations must be sent with POST.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result := g.Execute(r.Context(), request)
+	jsonHandler.CreateResponse(w, http.StatusOK, result)
+}
+
+// Execute runs request against the schema with a fresh set of loaders.
+func (g *GraphQLHandler) Execute(ctx context.Context, request Request) *graphql.Result {
+	return graphql.Do(graphql.Params{
+		Schema:         g.Schema,
+		RequestString:  request.Query,
+		OperationName:  request.OperationName,
+		VariableValues: request.Variables,
+		Context:        withLoaders(ctx, newLoaders(g.Service)),
+	})
+}
+
+func isMutation(request Request) bool {
+	document, err := parser.Parse(parser.ParseParams{Source: request.Query})
+	if err != nil {
+		return false
+	}
+
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if request.OperationName != "" && (operation.Name == nil || operation.Name.Value != request.OperationName) {
+			continue
+		}
+		if operation.Operation == ast.OperationTypeMutation {
+			return true
+		}
+	}
+
+	return false
+}
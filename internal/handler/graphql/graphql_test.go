@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	cart "github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+func newTestHandler(t *testing.T, service cart.Service) *GraphQLHandler {
+	sut, err := NewGraphQLHandler(service)
+	if err != nil {
+		t.Fatalf("failed to build schema: %v", err)
+	}
+	return sut
+}
+
+func Test_GraphQLHandler_Execute_WhenQueryingItemsWithPrices_ShouldBatchPriceLookups(t *testing.T) {
+	items := []cart.Item{
+		{ID: uuid.New(), Name: "Apple", Price: 199, Manufacturer: "Orchard"},
+		{ID: uuid.New(), Name: "Pear", Price: 249, Manufacturer: "Orchard"},
+		{ID: uuid.New(), Name: "Plum", Price: 299, Manufacturer: "Orchard"},
+	}
+
+	var filterCalled cart.Filter
+	mockService := &cart.ServiceMock{
+		FindItemsFunc: func(ctx context.Context, filter cart.Filter, page int64, pageSize int64) ([]cart.Item, error) {
+			filterCalled = filter
+			return items, nil
+		},
+		GetPriceHistoryByItemIDsFunc: func(ctx context.Context, ids []uuid.UUID) ([]cart.PriceChange, error) {
+			return []cart.PriceChange{
+				{ItemID: items[0].ID, Price: 199, EffectiveAt: time.Now()},
+				{ItemID: items[2].ID, Price: 299, EffectiveAt: time.Now()},
+			}, nil
+		},
+		GetScheduledPricesByItemIDsFunc: func(ctx context.Context, ids []uuid.UUID) ([]cart.ScheduledPrice, error) {
+			return []cart.ScheduledPrice{}, nil
+		},
+	}
+
+	sut := newTestHandler(t, mockService)
+	result := sut.Execute(context.Background(), Request{
+		Query: `{ items(filter: {manufacturer: "Orchard", minPrice: 100}) { id name priceHistory { price } scheduledPrices { id } } }`,
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	if calls := len(mockService.GetPriceHistoryByItemIDsCalls()); calls != 1 {
+		t.Errorf("expected price history to be fetched once, got %d", calls)
+	}
+	if ids := mockService.GetPriceHistoryByItemIDsCalls()[0].Ids; len(ids) != len(items) {
+		t.Errorf("expected %d ids in the batch, got %d", len(items), len(ids))
+	}
+	if calls := len(mockService.GetScheduledPricesByItemIDsCalls()); calls != 1 {
+		t.Errorf("expected scheduled prices to be fetched once, got %d", calls)
+	}
+
+	if filterCalled.Manufacturer != "Orchard" || filterCalled.MinPrice != 100 {
+		t.Errorf("unexpected filter: %+v", filterCalled)
+	}
+
+	data := result.Data.(map[string]interface{})["items"].([]interface{})
+	if history := data[1].(map[string]interface{})["priceHistory"].([]interface{}); len(history) != 0 {
+		t.Errorf("expected an empty price history, got %v", history)
+	}
+}
+
+func Test_GraphQLHandler_Execute_WhenQueryingManyItemsByID_ShouldBatchItemLookups(t *testing.T) {
+	first := cart.Item{ID: uuid.New(), Name: "Apple", Price: 199, Manufacturer: "Orchard"}
+	mockService := &cart.ServiceMock{
+		GetItemsByIDsFunc: func(ctx context.Context, ids []uuid.UUID) ([]cart.Item, error) {
+			return []cart.Item{first}, nil
+		},
+	}
+
+	sut := newTestHandler(t, mockService)
+	result := sut.Execute(context.Background(), Request{
+		Query:     `query($a: ID!, $b: ID!) { a: item(id: $a) { name } b: item(id: $b) { name } }`,
+		Variables: map[string]interface{}{"a": first.ID.String(), "b": uuid.New().String()},
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	if calls := len(mockService.GetItemsByIDsCalls()); calls != 1 {
+		t.Errorf("expected items to be fetched once, got %d", calls)
+	}
+
+	data := result.Data.(map[string]interface{})
+	if data["a"].(map[string]interface{})["name"] != "Apple" {
+		t.Errorf("unexpected item: %v", data["a"])
+	}
+	if data["b"] != nil {
+		t.Errorf("expected a missing item to be null, got %v", data["b"])
+	}
+}
+
+func Test_GraphQLHandler_Execute_WhenCreatingInvalidItem_ShouldReturnError(t *testing.T) {
+	sut := newTestHandler(t, cart.NewService(&cart.RepositoryMock{}))
+
+	result := sut.Execute(context.Background(), Request{
+		Query: `mutation { createItem(input: {name: "Apple", price: 1, manufacturer: "Orchard"}) { id } }`,
+	})
+	if !result.HasErrors() {
+		t.Fatalf("expected a validation error")
+	}
+}
+
+func Test_GraphQLHandler_Execute_WhenUpdatingMissingItem_ShouldReturnError(t *testing.T) {
+	mockService := &cart.ServiceMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (cart.Item, error) {
+			return cart.Item{}, sql.ErrNoRows
+		},
+	}
+
+	sut := newTestHandler(t, mockService)
+	result := sut.Execute(context.Background(), Request{
+		Query: `mutation { updateItem(id: "` + uuid.New().String() + `", input: {name: "Apple", price: 199, manufacturer: "Orchard"}) { id } }`,
+	})
+	if !result.HasErrors() {
+		t.Fatalf("expected a not found error")
+	}
+	if len(mockService.UpdateItemCalls()) != 0 {
+		t.Errorf("UpdateItem should not have been called")
+	}
+}
+
+func Test_GraphQLHandler_Execute_WhenDeletingItem_ShouldReturnID(t *testing.T) {
+	id := uuid.New()
+	mockService := &cart.ServiceMock{
+		RemoveItemFunc: func(ctx context.Context, id uuid.UUID) (uuid.UUID, cart.ServiceError) {
+			return id, nil
+		},
+	}
+
+	sut := newTestHandler(t, mockService)
+	result := sut.Execute(context.Background(), Request{
+		Query: `mutation { deleteItem(id: "` + id.String() + `") }`,
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	if result.Data.(map[string]interface{})["deleteItem"] != id.String() {
+		t.Errorf("unexpected result: %v", result.Data)
+	}
+}
+
+func Test_GraphQLHandler_Query_WhenMutationIsSentWithGet_ShouldReturnMethodNotAllowed(t *testing.T) {
+	sut := newTestHandler(t, &cart.ServiceMock{})
+
+	query := url.QueryEscape(`mutation { deleteItem(id: "` + uuid.New().String() + `") }`)
+	request := httptest.NewRequest("GET", "/graphql?query="+query, nil)
+	recorder := httptest.NewRecorder()
+
+	sut.Query(recorder, request)
+
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected %d, got %d", http.StatusMethodNotAllowed, recorder.Code)
+	}
+}
+
+func Test_GraphQLHandler_Query_WhenPostingQuery_ShouldReturnData(t *testing.T) {
+	mockService := &cart.ServiceMock{
+		FindItemsFunc: func(ctx context.Context, filter cart.Filter, page int64, pageSize int64) ([]cart.Item, error) {
+			return []cart.Item{{ID: uuid.New(), Name: "Apple", Price: 199, Manufacturer: "Orchard"}}, nil
+		},
+	}
+
+	sut := newTestHandler(t, mockService)
+	request := httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"query": "{ items { name price } }"}`))
+	recorder := httptest.NewRecorder()
+
+	sut.Query(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d", http.StatusOK, recorder.Code)
+	}
+	if !strings.Contains(recorder.Body.String(), `"price":199`) {
+		t.Errorf("unexpected body: %s", recorder.Body.String())
+	}
+}
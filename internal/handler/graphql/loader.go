@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchFunc fetches the values for keys in one call. Keys missing from the returned map are
+// treated as not found.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// NewLoader ..
+func NewLoader[K comparable, V any](fetch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:   fetch,
+		results: make(map[K]V),
+		fetched: make(map[K]error),
+	}
+}
+
+// Loader collects the keys requested while one level of a query is resolved and fetches them
+// together the first time any of the returned thunks is called. Results are cached for the
+// lifetime of the loader, which is a single request.
+type Loader[K comparable, V any] struct {
+	fetch   BatchFunc[K, V]
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]struct{}
+	results map[K]V
+	fetched map[K]error
+}
+
+// Load queues key and returns a thunk the graphql executor resolves after the current level.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, ok := l.fetched[key]; !ok {
+		if l.queued == nil {
+			l.queued = make(map[K]struct{})
+		}
+		if _, ok := l.queued[key]; !ok {
+			l.queued[key] = struct{}{}
+			l.pending = append(l.pending, key)
+		}
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		value, found, err := l.resolve(ctx, key)
+		if err != nil || !found {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+func (l *Loader[K, V]) resolve(ctx context.Context, key K) (V, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.pending) > 0 {
+		keys := l.pending
+		l.pending = nil
+		l.queued = nil
+
+		values, err := l.fetch(ctx, keys)
+		for _, k := range keys {
+			l.fetched[k] = err
+			if value, ok := values[k]; ok && err == nil {
+				l.results[k] = value
+			}
+		}
+	}
+
+	var zero V
+	if err := l.fetched[key]; err != nil {
+		return zero, false, err
+	}
+
+	value, found := l.results[key]
+	return value, found, nil
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func Test_Loader_Load_WhenManyKeysAreQueued_ShouldFetchOnce(t *testing.T) {
+	var calls [][]int
+	sut := NewLoader(func(ctx context.Context, keys []int) (map[int]string, error) {
+		calls = append(calls, keys)
+		return map[int]string{1: "one", 2: "two"}, nil
+	})
+
+	ctx := context.Background()
+	first := sut.Load(ctx, 1)
+	second := sut.Load(ctx, 2)
+	duplicate := sut.Load(ctx, 1)
+	missing := sut.Load(ctx, 3)
+
+	for _, thunk := range []func() (interface{}, error){first, second, duplicate} {
+		if _, err := thunk(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if value, _ := first(); value != "one" {
+		t.Errorf("expected 'one', got %v", value)
+	}
+	if value, _ := missing(); value != nil {
+		t.Errorf("expected nil for a missing key, got %v", value)
+	}
+
+	if len(calls) != 1 || len(calls[0]) != 3 {
+		t.Errorf("expected a single fetch of 3 keys, got %v", calls)
+	}
+}
+
+func Test_Loader_Load_WhenKeyWasFetched_ShouldUseCache(t *testing.T) {
+	var calls int
+	sut := NewLoader(func(ctx context.Context, keys []int) (map[int]string, error) {
+		calls++
+		return map[int]string{1: "one"}, nil
+	})
+
+	ctx := context.Background()
+	sut.Load(ctx, 1)()
+	sut.Load(ctx, 1)()
+
+	if calls != 1 {
+		t.Errorf("expected 1 fetch, got %d", calls)
+	}
+}
+
+func Test_Loader_Load_WhenFetchFails_ShouldReturnErrorForEveryKey(t *testing.T) {
+	sut := NewLoader(func(ctx context.Context, keys []int) (map[int]string, error) {
+		return nil, errors.New("boom")
+	})
+
+	ctx := context.Background()
+	first := sut.Load(ctx, 1)
+	second := sut.Load(ctx, 2)
+
+	if _, err := first(); err == nil {
+		t.Errorf("expected an error for the first key")
+	}
+	if _, err := second(); err == nil {
+		t.Errorf("expected an error for the second key")
+	}
+}
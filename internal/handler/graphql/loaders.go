@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	cart "github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+type loadersContextKey struct{}
+
+// loaders holds the per-request loaders used by the item resolvers.
+type loaders struct {
+	Items           *Loader[uuid.UUID, cart.Item]
+	PriceHistory    *Loader[uuid.UUID, []cart.PriceChange]
+	ScheduledPrices *Loader[uuid.UUID, []cart.ScheduledPrice]
+}
+
+func newLoaders(service cart.Service) *loaders {
+	return &loaders{
+		Items: NewLoader(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]cart.Item, error) {
+			items, err := service.GetItemsByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make(map[uuid.UUID]cart.Item, len(items))
+			for _, item := range items {
+				result[item.ID] = item
+			}
+			return result, nil
+		}),
+		PriceHistory: NewLoader(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]cart.PriceChange, error) {
+			changes, err := service.GetPriceHistoryByItemIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make(map[uuid.UUID][]cart.PriceChange, len(ids))
+			for _, id := range ids {
+				result[id] = []cart.PriceChange{}
+			}
+			for _, change := range changes {
+				result[change.ItemID] = append(result[change.ItemID], change)
+			}
+			return result, nil
+		}),
+		ScheduledPrices: NewLoader(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]cart.ScheduledPrice, error) {
+			prices, err := service.GetScheduledPricesByItemIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make(map[uuid.UUID][]cart.ScheduledPrice, len(ids))
+			for _, id := range ids {
+				result[id] = []cart.ScheduledPrice{}
+			}
+			for _, price := range prices {
+				result[price.ItemID] = append(result[price.ItemID], price)
+			}
+			return result, nil
+		}),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersContextKey{}, l)
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	l, _ := ctx.Value(loadersContextKey{}).(*loaders)
+	return l
+}
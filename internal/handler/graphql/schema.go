@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+
+	cart "github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+var priceChangeType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "PriceChange",
+	Fields: graphql.Fields{
+		"price": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return int64(p.Source.(cart.PriceChange).Price), nil
+			},
+		},
+		"effectiveAt": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.DateTime),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(cart.PriceChange).EffectiveAt, nil
+			},
+		},
+	},
+})
+
+var scheduledPriceType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ScheduledPrice",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(cart.ScheduledPrice).ID.String(), nil
+			},
+		},
+		"price": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return int64(p.Source.(cart.ScheduledPrice).Price), nil
+			},
+		},
+		"effectiveAt": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.DateTime),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(cart.ScheduledPrice).EffectiveAt, nil
+			},
+		},
+	},
+})
+
+var itemType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Item",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(cart.Item).ID.String(), nil
+			},
+		},
+		"name": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(cart.Item).Name, nil
+			},
+		},
+		"price": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return int64(p.Source.(cart.Item).Price), nil
+			},
+		},
+		"manufacturer": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(cart.Item).Manufacturer, nil
+			},
+		},
+		"priceHistory": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(priceChangeType))),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return loadersFromContext(p.Context).PriceHistory.Load(p.Context, p.Source.(cart.Item).ID), nil
+			},
+		},
+		"scheduledPrices": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(scheduledPriceType))),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return loadersFromContext(p.Context).ScheduledPrices.Load(p.Context, p.Source.(cart.Item).ID), nil
+			},
+		},
+	},
+})
+
+var itemFilterType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "ItemFilter",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"manufacturer": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"name":         &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Case-insensitive substring match."},
+		"minPrice":     &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"maxPrice":     &graphql.InputObjectFieldConfig{Type: graphql.Int},
+	},
+})
+
+var itemInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "ItemInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"name":         &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"price":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+		"manufacturer": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+	},
+})
+
+// NewSchema builds the catalog schema on top of service.
+func NewSchema(service cart.Service) (graphql.Schema, error) {
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"items": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(itemType))),
+				Args: graphql.FieldConfigArgument{
+					"filter":   &graphql.ArgumentConfig{Type: itemFilterType},
+					"page":     &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+					"pageSize": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					page, _ := p.Args["page"].(int)
+					pageSize, _ := p.Args["pageSize"].(int)
+					if page < 0 {
+						page = 0
+					}
+					if pageSize <= 0 || pageSize > maxPageSize {
+						pageSize = defaultPageSize
+					}
+
+					filter := getFilter(p.Args["filter"])
+					return service.FindItems(p.Context, filter, int64(page), int64(pageSize))
+				},
+			},
+			"item": &graphql.Field{
+				Type: itemType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := getID(p.Args["id"])
+					if err != nil {
+						return nil, err
+					}
+					return loadersFromContext(p.Context).Items.Load(p.Context, id), nil
+				},
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createItem": &graphql.Field{
+				Type: graphql.NewNonNull(itemType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(itemInputType)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					input := p.Args["input"].(map[string]interface{})
+					return service.AddItem(p.Context, &cart.ItemDTO{
+						Name:         input["name"].(string),
+						Price:        cart.Decimal(input["price"].(int)),
+						Manufacturer: input["manufacturer"].(string),
+					})
+				},
+			},
+			"updateItem": &graphql.Field{
+				Type: graphql.NewNonNull(itemType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(itemInputType)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := getID(p.Args["id"])
+					if err != nil {
+						return nil, err
+					}
+
+					if _, err := service.GetItemByID(p.Context, id); err != nil {
+						return nil, fmt.Errorf("item %s not found", id)
+					}
+
+					input := p.Args["input"].(map[string]interface{})
+					result, serviceError := service.UpdateItem(p.Context, &cart.Item{
+						ID:           id,
+						Name:         input["name"].(string),
+						Price:        cart.Decimal(input["price"].(int)),
+						Manufacturer: input["manufacturer"].(string),
+					})
+					if serviceError != nil {
+						return nil, errors.New(serviceError.Message())
+					}
+					return result, nil
+				},
+			},
+			"deleteItem": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := getID(p.Args["id"])
+					if err != nil {
+						return nil, err
+					}
+
+					result, serviceError := service.RemoveItem(p.Context, id)
+					if serviceError != nil {
+						return nil, errors.New(serviceError.Message())
+					}
+					return result.String(), nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
+
+func getID(arg interface{}) (uuid.UUID, error) {
+	rawID, _ := arg.(string)
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return uuid.Nil, errors.New("id: must be a valid UUID.")
+	}
+	return id, nil
+}
+
+func getFilter(arg interface{}) cart.Filter {
+	var filter cart.Filter
+
+	input, ok := arg.(map[string]interface{})
+	if !ok {
+		return filter
+	}
+
+	if manufacturer, ok := input["manufacturer"].(string); ok {
+		filter.Manufacturer = manufacturer
+	}
+	if name, ok := input["name"].(string); ok {
+		filter.Name = name
+	}
+	if minPrice, ok := input["minPrice"].(int); ok {
+		filter.MinPrice = cart.Decimal(minPrice)
+	}
+	if maxPrice, ok := input["maxPrice"].(int); ok {
+		filter.MaxPrice = cart.Decimal(maxPrice)
+	}
+
+	return filter
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
+)
+
+// UnaryAuthInterceptor authenticates the credential in each call's metadata with whichever of
+// schemes it is presented for, such as "authorization: Bearer <token>" or "x-api-key: <key>", and
+// attaches the principal to the call's context.
+func UnaryAuthInterceptor(schemes ...auth.Scheme) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, schemes)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor is UnaryAuthInterceptor for streaming calls.
+func StreamAuthInterceptor(schemes ...auth.Scheme) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), schemes)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func authenticate(ctx context.Context, schemes []auth.Scheme) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	for _, scheme := range schemes {
+		credential, ok := scheme.Credential(md.Get)
+		if !ok {
+			continue
+		}
+
+		principal, err := scheme.Authenticator.Authenticate(ctx, credential)
+		if errors.Is(err, auth.ErrUnauthenticated) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		} else if err != nil {
+			slog.ErrorContext(ctx, "unable to authenticate call", "scheme", scheme.Name, "error", err)
+			return nil, status.Error(codes.Unavailable, "the credential couldn't be checked")
+		}
+
+		return auth.WithPrincipal(ctx, principal), nil
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "a credential is required")
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
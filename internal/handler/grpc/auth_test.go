@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/handler/grpc/itempb"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
+	cart "github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+type stubAuthenticator struct{}
+
+func (stubAuthenticator) Authenticate(ctx context.Context, token string) (auth.Principal, error) {
+	if token == "unreachable" {
+		return auth.Principal{}, errors.New("connection refused")
+	}
+	if token != "valid" {
+		return auth.Principal{}, auth.ErrUnauthenticated
+	}
+	return auth.Principal{Subject: "merchandiser@example.com"}, nil
+}
+
+func newAuthenticatedTestClient(t *testing.T, service cart.Service) itempb.ItemServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	schemes := []auth.Scheme{auth.BearerScheme(stubAuthenticator{}), auth.APIKeyScheme(stubAuthenticator{})}
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryAuthInterceptor(schemes...)),
+		grpc.StreamInterceptor(StreamAuthInterceptor(schemes...)),
+	)
+	itempb.RegisterItemServiceServer(server, NewItemServer(service))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial bufnet: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return itempb.NewItemServiceClient(conn)
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func Test_UnaryAuthInterceptor_WhenTokenIsMissingOrInvalid_ShouldReturnUnauthenticated(t *testing.T) {
+	client := newAuthenticatedTestClient(t, &cart.ServiceMock{})
+
+	for _, ctx := range []context.Context{context.Background(), withToken("invalid")} {
+		_, err := client.GetItem(ctx, &itempb.GetItemRequest{Id: uuid.New().String()})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("expected %s, got %v", codes.Unauthenticated, err)
+		}
+	}
+}
+
+func Test_UnaryAuthInterceptor_WhenCredentialCannotBeChecked_ShouldReturnUnavailable(t *testing.T) {
+	client := newAuthenticatedTestClient(t, &cart.ServiceMock{})
+
+	_, err := client.GetItem(withToken("unreachable"), &itempb.GetItemRequest{Id: uuid.New().String()})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected %s, got %v", codes.Unavailable, err)
+	}
+}
+
+func Test_UnaryAuthInterceptor_WhenTokenIsValid_ShouldAttachPrincipal(t *testing.T) {
+	var subject string
+	client := newAuthenticatedTestClient(t, &cart.ServiceMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (cart.Item, error) {
+			principal, _ := auth.PrincipalFromContext(ctx)
+			subject = principal.Subject
+			return cart.Item{ID: id, Name: "Apple", Price: 199, Manufacturer: "Orchard"}, nil
+		},
+	})
+
+	if _, err := client.GetItem(withToken("valid"), &itempb.GetItemRequest{Id: uuid.New().String()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if subject != "merchandiser@example.com" {
+		t.Errorf("expected the principal to reach the service, got %q", subject)
+	}
+}
+
+func Test_UnaryAuthInterceptor_WhenAPIKeyIsValid_ShouldAttachPrincipal(t *testing.T) {
+	client := newAuthenticatedTestClient(t, &cart.ServiceMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (cart.Item, error) {
+			return cart.Item{ID: id, Name: "Apple", Price: 199, Manufacturer: "Orchard"}, nil
+		},
+	})
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "valid")
+	if _, err := client.GetItem(ctx, &itempb.GetItemRequest{Id: uuid.New().String()}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func Test_StreamAuthInterceptor_WhenTokenIsMissing_ShouldReturnUnauthenticated(t *testing.T) {
+	client := newAuthenticatedTestClient(t, &cart.ServiceMock{})
+
+	stream, err := client.StreamItems(context.Background(), &itempb.StreamItemsRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+
+	if err == io.EOF || status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected %s, got %v", codes.Unauthenticated, err)
+	}
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/handler/grpc/itempb"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
+	cart "github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+const defaultStreamPageSize = 50
+
+// NewItemServer ..
+func NewItemServer(service cart.Service) *ItemServer {
+	return &ItemServer{Service: service}
+}
+
+// ItemServer implements itempb.ItemServiceServer on top of the same item.Service the HTTP
+// handlers use.
+type ItemServer struct {
+	itempb.UnimplementedItemServiceServer
+	Service cart.Service
+}
+
+// ListItems ..
+func (s *ItemServer) ListItems(ctx context.Context, request *itempb.ListItemsRequest) (*itempb.ListItemsResponse, error) {
+	pageSize := request.GetPageSize()
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	data, err := s.Service.GetItems(ctx, request.GetPage(), pageSize)
+	if err != nil {
+		return nil, serviceStatus(err)
+	}
+
+	items := make([]*itempb.Item, 0, len(data))
+	for _, item := range data {
+		items = append(items, toProto(item))
+	}
+
+	return &itempb.ListItemsResponse{Items: items}, nil
+}
+
+// GetItem ..
+func (s *ItemServer) GetItem(ctx context.Context, request *itempb.GetItemRequest) (*itempb.GetItemResponse, error) {
+	id, err := parseID(request.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.Service.GetItemByID(ctx, id)
+	if err != nil {
+		return nil, lookupError(err)
+	}
+
+	return &itempb.GetItemResponse{Item: toProto(data)}, nil
+}
+
+// CreateItem ..
+func (s *ItemServer) CreateItem(ctx context.Context, request *itempb.CreateItemRequest) (*itempb.CreateItemResponse, error) {
+	data, err := s.Service.AddItem(ctx, &cart.ItemDTO{
+		Name:         request.GetName(),
+		Price:        cart.Decimal(request.GetPrice()),
+		Manufacturer: request.GetManufacturer(),
+	})
+	if err != nil {
+		var validationErrors validation.Errors
+		if errors.As(err, &validationErrors) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, serviceStatus(err)
+	}
+
+	return &itempb.CreateItemResponse{Item: toProto(data)}, nil
+}
+
+// UpdateItem ..
+func (s *ItemServer) UpdateItem(ctx context.Context, request *itempb.UpdateItemRequest) (*itempb.UpdateItemResponse, error) {
+	id, err := parseID(request.GetItem().GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.Service.GetItemByID(ctx, id); err != nil {
+		return nil, lookupError(err)
+	}
+
+	data, serviceError := s.Service.UpdateItem(ctx, &cart.Item{
+		ID:           id,
+		Name:         request.GetItem().GetName(),
+		Price:        cart.Decimal(request.GetItem().GetPrice()),
+		Manufacturer: request.GetItem().GetManufacturer(),
+	})
+	if serviceError != nil {
+		return nil, ToStatus(serviceError)
+	}
+
+	return &itempb.UpdateItemResponse{Item: toProto(data)}, nil
+}
+
+// DeleteItem ..
+func (s *ItemServer) DeleteItem(ctx context.Context, request *itempb.DeleteItemRequest) (*itempb.DeleteItemResponse, error) {
+	id, err := parseID(request.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	result, serviceError := s.Service.RemoveItem(ctx, id)
+	if serviceError != nil {
+		return nil, ToStatus(serviceError)
+	}
+
+	return &itempb.DeleteItemResponse{Id: result.String()}, nil
+}
+
+// StreamItems ..
+func (s *ItemServer) StreamItems(request *itempb.StreamItemsRequest, stream itempb.ItemService_StreamItemsServer) error {
+	pageSize := request.GetPageSize()
+	if pageSize <= 0 {
+		pageSize = defaultStreamPageSize
+	}
+
+	for page := int64(0); ; page++ {
+		data, err := s.Service.GetItems(stream.Context(), page, pageSize)
+		if err != nil {
+			return serviceStatus(err)
+		}
+
+		for _, item := range data {
+			if err := stream.Send(&itempb.StreamItemsResponse{Item: toProto(item)}); err != nil {
+				return err
+			}
+		}
+
+		if int64(len(data)) < pageSize {
+			return nil
+		}
+	}
+}
+
+// ToStatus maps a ServiceError onto the closest gRPC status code.
+func ToStatus(serviceError cart.ServiceError) error {
+	switch serviceError.StatusCode() {
+	case cart.InvalidItem, cart.InvalidPrice:
+		return status.Error(codes.InvalidArgument, serviceError.Message())
+	case cart.ItemNotFound:
+		return status.Error(codes.NotFound, serviceError.Message())
+	case cart.Forbidden:
+		return status.Error(codes.PermissionDenied, serviceError.Message())
+	default:
+		return status.Error(codes.Internal, serviceError.Message())
+	}
+}
+
+func lookupError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, "item not found")
+	}
+	return serviceStatus(err)
+}
+
+// serviceStatus maps errors returned by item.Service methods that don't return a ServiceError.
+func serviceStatus(err error) error {
+	if errors.Is(err, auth.ErrForbidden) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func parseID(rawID string) (uuid.UUID, error) {
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "id: must be a valid UUID.")
+	}
+	return id, nil
+}
+
+func toProto(item cart.Item) *itempb.Item {
+	return &itempb.Item{
+		Id:           item.ID.String(),
+		Name:         item.Name,
+		Price:        int64(item.Price),
+		Manufacturer: item.Manufacturer,
+	}
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/handler/grpc/itempb"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
+	cart "github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+func newTestClient(t *testing.T, service cart.Service) itempb.ItemServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer()
+	itempb.RegisterItemServiceServer(server, NewItemServer(service))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial bufnet: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return itempb.NewItemServiceClient(conn)
+}
+
+func Test_ItemServer_GetItem_WhenItemExists_ShouldReturnItem(t *testing.T) {
+	id := uuid.New()
+	client := newTestClient(t, &cart.ServiceMock{
+		GetItemByIDFunc: func(ctx context.Context, itemID uuid.UUID) (cart.Item, error) {
+			return cart.Item{ID: itemID, Name: "Apple", Price: 199, Manufacturer: "Orchard"}, nil
+		},
+	})
+
+	response, err := client.GetItem(context.Background(), &itempb.GetItemRequest{Id: id.String()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if response.GetItem().GetId() != id.String() || response.GetItem().GetPrice() != 199 {
+		t.Errorf("unexpected item: %v", response.GetItem())
+	}
+}
+
+func Test_ItemServer_GetItem_WhenItemDoesNotExist_ShouldReturnNotFound(t *testing.T) {
+	client := newTestClient(t, &cart.ServiceMock{
+		GetItemByIDFunc: func(ctx context.Context, itemID uuid.UUID) (cart.Item, error) {
+			return cart.Item{}, sql.ErrNoRows
+		},
+	})
+
+	_, err := client.GetItem(context.Background(), &itempb.GetItemRequest{Id: uuid.New().String()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func Test_ItemServer_GetItem_WhenIDIsInvalid_ShouldReturnInvalidArgument(t *testing.T) {
+	client := newTestClient(t, &cart.ServiceMock{})
+
+	_, err := client.GetItem(context.Background(), &itempb.GetItemRequest{Id: "not-a-uuid"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func Test_ItemServer_CreateItem_WhenItemIsInvalid_ShouldReturnInvalidArgument(t *testing.T) {
+	client := newTestClient(t, cart.NewService(&cart.RepositoryMock{}))
+
+	_, err := client.CreateItem(context.Background(), &itempb.CreateItemRequest{Name: "", Price: 0})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func Test_ItemServer_UpdateItem_WhenServiceFails_ShouldMapServiceError(t *testing.T) {
+	id := uuid.New()
+	client := newTestClient(t, &cart.ServiceMock{
+		GetItemByIDFunc: func(ctx context.Context, itemID uuid.UUID) (cart.Item, error) {
+			return cart.Item{ID: itemID}, nil
+		},
+		UpdateItemFunc: func(ctx context.Context, item *cart.Item) (cart.Item, cart.ServiceError) {
+			return cart.Item{}, cart.CreateServiceError("price: must be no less than 99.", cart.InvalidItem)
+		},
+	})
+
+	_, err := client.UpdateItem(context.Background(), &itempb.UpdateItemRequest{
+		Item: &itempb.Item{Id: id.String(), Name: "Apple", Price: 1, Manufacturer: "Orchard"},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func Test_ItemServer_DeleteItem_WhenServiceFails_ShouldReturnInternal(t *testing.T) {
+	client := newTestClient(t, &cart.ServiceMock{
+		RemoveItemFunc: func(ctx context.Context, id uuid.UUID) (uuid.UUID, cart.ServiceError) {
+			return uuid.Nil, cart.CreateServiceError("boom", cart.UnknownException)
+		},
+	})
+
+	_, err := client.DeleteItem(context.Background(), &itempb.DeleteItemRequest{Id: uuid.New().String()})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected Internal, got %v", err)
+	}
+}
+
+func Test_ItemServer_DeleteItem_WhenRoleIsNotGranted_ShouldReturnPermissionDenied(t *testing.T) {
+	policy, _ := auth.LoadPolicy("")
+	client := newTestClient(t, auth.NewItemService(&cart.ServiceMock{}, policy))
+
+	_, err := client.DeleteItem(context.Background(), &itempb.DeleteItemRequest{Id: uuid.New().String()})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+}
+
+func Test_ItemServer_ListItems_WhenRoleIsNotGranted_ShouldReturnPermissionDenied(t *testing.T) {
+	policy, _ := auth.LoadPolicy("")
+	client := newTestClient(t, auth.NewItemService(&cart.ServiceMock{}, policy))
+
+	_, err := client.ListItems(context.Background(), &itempb.ListItemsRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+}
+
+func Test_ItemServer_StreamItems_WhenManyPages_ShouldSendEveryItem(t *testing.T) {
+	var items []cart.Item
+	for i := 0; i < 5; i++ {
+		items = append(items, cart.Item{ID: uuid.New(), Name: "Apple", Price: 199, Manufacturer: "Orchard"})
+	}
+
+	client := newTestClient(t, &cart.ServiceMock{
+		GetItemsFunc: func(ctx context.Context, page int64, pageSize int64) ([]cart.Item, error) {
+			start := page * pageSize
+			if start >= int64(len(items)) {
+				return []cart.Item{}, nil
+			}
+			end := start + pageSize
+			if end > int64(len(items)) {
+				end = int64(len(items))
+			}
+			return items[start:end], nil
+		},
+	})
+
+	stream, err := client.StreamItems(context.Background(), &itempb.StreamItemsRequest{PageSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var received int
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		received++
+	}
+
+	if received != len(items) {
+		t.Errorf("expected %d items, got %d", len(items), received)
+	}
+}
@@ -2,36 +2,168 @@ package handler
 
 import (
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
 	"net/http"
+	"time"
 
+	graphqlHandlers "github.com/tjmaynes/shopping-cart-service-go/internal/handler/graphql"
 	handlers "github.com/tjmaynes/shopping-cart-service-go/internal/handler/http"
+	middlewares "github.com/tjmaynes/shopping-cart-service-go/internal/handler/middleware"
+	openapiHandlers "github.com/tjmaynes/shopping-cart-service-go/internal/handler/openapi"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
 )
 
-// Initialize ..
-func Initialize(itemHandler *handlers.ItemHandler, healthCheckHandler *handlers.HealthCheckHandler) http.Handler {
+var (
+	// LegacyDeprecatedAt is when the unversioned aliases of the /v1 routes were deprecated.
+	LegacyDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	// LegacySunsetAt is when the unversioned aliases of the /v1 routes will be removed.
+	LegacySunsetAt = time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC)
+)
+
+// Initialize routes the API. The audit, event, webhook and API key handlers are nil when items
+// aren't stored in Postgres, and their routes are left out. So are the API key routes when
+// authentication is disabled, as they would let anyone issue themselves a key.
+func Initialize(
+	itemHandler *handlers.ItemHandler,
+	auditHandler *handlers.AuditHandler,
+	eventHandler *handlers.EventHandler,
+	webhookHandler *handlers.WebhookHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
+	graphqlHandler *graphqlHandlers.GraphQLHandler,
+	openapiHandler *openapiHandlers.OpenAPIHandler,
+	healthCheckHandler *handlers.HealthCheckHandler,
+	openapiValidator *middlewares.OpenAPIValidator,
+	authentication *middlewares.Authentication,
+	rateLimiter *middlewares.RateLimiter,
+	authorizer *middlewares.Authorizer,
+) http.Handler {
 	router := chi.NewRouter()
 	router.Use(
-		middleware.Recoverer,
-		middleware.Logger,
+		middlewares.RequestID,
+		middlewares.Tracing,
+		middlewares.Logger,
+		middlewares.Metrics,
+		middlewares.Recoverer,
+		middlewares.AuditContext,
 	)
 
-	router.Route("/", func(rt chi.Router) {
-		rt.Mount("/items", addItemRouter(itemHandler))
+	router.Route("/v1", func(rt chi.Router) {
+		rt.Use(rateLimiter.LimitIP, authentication.Authenticate, rateLimiter.Limit, openapiValidator.Validate)
+		addV1Routes(rt, itemHandler, auditHandler, eventHandler, webhookHandler, authorizer)
+	})
+
+	router.Group(func(rt chi.Router) {
+		rt.Use(middlewares.Deprecate(LegacyDeprecatedAt, LegacySunsetAt, "/v1"), rateLimiter.LimitIP, authentication.Authenticate, rateLimiter.Limit, openapiValidator.Validate)
+		addV1Routes(rt, itemHandler, auditHandler, eventHandler, webhookHandler, authorizer)
+	})
+
+	router.Group(func(rt chi.Router) {
+		rt.Use(rateLimiter.LimitIP, authentication.Authenticate, rateLimiter.Limit, openapiValidator.Validate)
+		rt.Get("/graphql", graphqlHandler.Query)
+		rt.Post("/graphql", graphqlHandler.Query)
+	})
+
+	if apiKeyHandler != nil && len(authentication.Schemes) > 0 {
+		router.Route("/admin", func(rt chi.Router) {
+			rt.Use(rateLimiter.LimitIP, authentication.Authenticate, rateLimiter.Limit, openapiValidator.Validate, authorizer.Require(auth.ManageAPIKeys))
+			rt.Mount("/api-keys", addAPIKeyRouter(apiKeyHandler))
+		})
+	}
+
+	// Probes are polled by the orchestrator, so they're public and not rate limited.
+	router.Group(func(rt chi.Router) {
+		rt.Use(openapiValidator.Validate)
+		rt.Get("/livez", healthCheckHandler.GetLiveness)
+		rt.Get("/readyz", healthCheckHandler.GetReadiness)
+	})
+
+	// Health checks and the API's own documentation stay public.
+	router.Group(func(rt chi.Router) {
+		rt.Use(rateLimiter.Limit, openapiValidator.Validate)
 		rt.Get("/health", healthCheckHandler.GetHealthCheckHandler)
+		rt.Get(openapiHandlers.SpecPath, openapiHandler.GetSpec)
+		rt.Get(openapiHandlers.DocsPath, http.RedirectHandler(openapiHandlers.DocsPath+"/", http.StatusMovedPermanently).ServeHTTP)
+		rt.Get(openapiHandlers.DocsPath+"/*", openapiHandler.GetDocs)
 	})
 
 	return router
 }
 
-func addItemRouter(itemHandler *handlers.ItemHandler) http.Handler {
+// InitializeAdmin routes the operational endpoints served on the admin port, apart from the API.
+func InitializeAdmin(metricsHandler http.Handler) http.Handler {
+	router := chi.NewRouter()
+	router.Use(middlewares.Recoverer)
+
+	router.Get("/metrics", metricsHandler.ServeHTTP)
+
+	return router
+}
+
+// addV1Routes registers the versioned REST resources. They are mounted under /v1 and, until
+// LegacySunsetAt, at the root as deprecated aliases.
+func addV1Routes(
+	router chi.Router,
+	itemHandler *handlers.ItemHandler,
+	auditHandler *handlers.AuditHandler,
+	eventHandler *handlers.EventHandler,
+	webhookHandler *handlers.WebhookHandler,
+	authorizer *middlewares.Authorizer,
+) {
+	router.Mount("/items", addItemRouter(itemHandler, auditHandler, eventHandler, authorizer))
+	if webhookHandler != nil {
+		router.With(authorizer.Require(auth.ManageWebhooks)).Mount("/webhooks", addWebhookRouter(webhookHandler))
+	}
+	if auditHandler != nil {
+		router.With(authorizer.Require(auth.ReadAuditLog)).Get("/audit", auditHandler.GetAuditEntries)
+	}
+}
+
+func addItemRouter(
+	itemHandler *handlers.ItemHandler,
+	auditHandler *handlers.AuditHandler,
+	eventHandler *handlers.EventHandler,
+	authorizer *middlewares.Authorizer,
+) http.Handler {
+	router := chi.NewRouter()
+
+	read := router.With(authorizer.Require(auth.ReadItems))
+	read.Get("/", itemHandler.GetItems)
+	if eventHandler != nil {
+		read.Get("/events", eventHandler.StreamItemEvents)
+	}
+	read.Get("/{id}", itemHandler.GetItemByID)
+	read.Get("/{id}/prices", itemHandler.GetItemPrices)
+	read.Get("/{id}/prices/scheduled", itemHandler.GetScheduledItemPrices)
+	if auditHandler != nil {
+		read.With(authorizer.Require(auth.ReadAuditLog)).Get("/{id}/history", auditHandler.GetItemHistory)
+	}
+
+	router.With(authorizer.Require(auth.CreateItems)).Post("/", itemHandler.AddItem)
+	router.With(authorizer.Require(auth.UpdateItems)).Put("/{id}", itemHandler.UpdateItem)
+	router.With(authorizer.Require(auth.UpdateItems)).Post("/{id}/prices/scheduled", itemHandler.ScheduleItemPrice)
+	router.With(authorizer.Require(auth.DeleteItems)).Delete("/{id}", itemHandler.RemoveItem)
+
+	return router
+}
+
+func addWebhookRouter(webhookHandler *handlers.WebhookHandler) http.Handler {
+	router := chi.NewRouter()
+
+	router.Get("/", webhookHandler.GetSubscriptions)
+	router.Get("/{id}", webhookHandler.GetSubscriptionByID)
+	router.Post("/", webhookHandler.AddSubscription)
+	router.Delete("/{id}", webhookHandler.RemoveSubscription)
+	router.Get("/{id}/deliveries", webhookHandler.GetDeliveries)
+
+	return router
+}
+
+func addAPIKeyRouter(apiKeyHandler *handlers.APIKeyHandler) http.Handler {
 	router := chi.NewRouter()
 
-	router.Get("/", itemHandler.GetItems)
-	router.Get("/{id}", itemHandler.GetItemByID)
-	router.Post("/", itemHandler.AddItem)
-	router.Put("/{id}", itemHandler.UpdateItem)
-	router.Delete("/{id}", itemHandler.RemoveItem)
+	router.Get("/", apiKeyHandler.GetAPIKeys)
+	router.Post("/", apiKeyHandler.AddAPIKey)
+	router.Delete("/{id}", apiKeyHandler.RemoveAPIKey)
 
 	return router
 }
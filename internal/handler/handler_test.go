@@ -0,0 +1,646 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	graphqlHandlers "github.com/tjmaynes/shopping-cart-service-go/internal/handler/graphql"
+	handlers "github.com/tjmaynes/shopping-cart-service-go/internal/handler/http"
+	middlewares "github.com/tjmaynes/shopping-cart-service-go/internal/handler/middleware"
+	openapiHandlers "github.com/tjmaynes/shopping-cart-service-go/internal/handler/openapi"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/apikey"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/audit"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/event"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/health"
+	cart "github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/metrics"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/ratelimit"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/webhook"
+)
+
+func newTestRouter(t *testing.T, itemHandler *handlers.ItemHandler, authenticator auth.Authenticator, policy *auth.Policy, limiter *ratelimit.Limiter) chi.Router {
+	openapiValidator, err := middlewares.NewOpenAPIValidator(openapiHandlers.Spec, false)
+	if err != nil {
+		t.Fatalf("failed to load the OpenAPI spec: %v", err)
+	}
+
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open a mock database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	checks := health.New(time.Second)
+	checks.Register("database", health.DatabaseCheck(db))
+
+	return Initialize(
+		itemHandler,
+		&handlers.AuditHandler{Service: audit.NewService(&audit.RepositoryMock{
+			GetEntriesFunc: func(ctx context.Context, filter audit.Filter, page int64, pageSize int64) ([]audit.Entry, error) {
+				return []audit.Entry{}, nil
+			},
+			GetEntriesByEntityFunc: func(ctx context.Context, entityType string, id uuid.UUID, page int64, pageSize int64) ([]audit.Entry, error) {
+				return []audit.Entry{}, nil
+			},
+		})},
+		&handlers.EventHandler{},
+		&handlers.WebhookHandler{Service: webhook.NewService(&webhook.RepositoryMock{
+			GetSubscriptionsFunc: func(ctx context.Context, page int64, pageSize int64) ([]webhook.Subscription, error) {
+				return []webhook.Subscription{}, nil
+			},
+		})},
+		&handlers.APIKeyHandler{Service: apikey.NewService(&apikey.RepositoryMock{
+			GetAPIKeysFunc: func(ctx context.Context, page int64, pageSize int64) ([]apikey.APIKey, error) {
+				return []apikey.APIKey{}, nil
+			},
+			AddAPIKeyFunc: func(ctx context.Context, name string, prefix string, hash string, scopes []string, expiresAt *time.Time) (apikey.APIKey, error) {
+				return apikey.APIKey{ID: uuid.New(), Name: name, Prefix: prefix, Scopes: scopes, CreatedAt: time.Now()}, nil
+			},
+		})},
+		&graphqlHandlers.GraphQLHandler{},
+		&openapiHandlers.OpenAPIHandler{},
+		handlers.NewHealthCheckHandler(checks),
+		openapiValidator,
+		middlewares.NewAuthentication(auth.BearerScheme(authenticator)),
+		middlewares.NewRateLimiter(limiter),
+		middlewares.NewAuthorizer(policy),
+	).(chi.Router)
+}
+
+func Test_Initialize_EveryRoute_ShouldBeDocumentedInOpenAPISpec(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openapiHandlers.Spec, &spec); err != nil {
+		t.Fatalf("spec is not valid JSON: %v", err)
+	}
+
+	router := newTestRouter(t, &handlers.ItemHandler{}, stubAuthenticator{}, nil, nil)
+
+	registered := make(map[string]bool)
+	err := chi.Walk(router, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		if route == openapiHandlers.DocsPath || strings.HasPrefix(route, openapiHandlers.DocsPath+"/") {
+			return nil
+		}
+
+		path := route
+		if len(path) > 1 {
+			path = strings.TrimSuffix(path, "/")
+		}
+		registered[method+" "+path] = true
+
+		operations, ok := spec.Paths[path]
+		if !ok {
+			t.Errorf("route %s %s is missing from the OpenAPI spec", method, path)
+			return nil
+		}
+		if _, ok := operations[strings.ToLower(method)]; !ok {
+			t.Errorf("route %s %s is missing from the OpenAPI spec", method, path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk routes: %v", err)
+	}
+
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			if method == "parameters" {
+				continue
+			}
+			if !registered[strings.ToUpper(method)+" "+path] {
+				t.Errorf("the OpenAPI spec documents %s %s, which is not registered", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
+
+func Test_Initialize_Routes_ShouldServeVersionedRoutesAndDeprecatedAliases(t *testing.T) {
+	id := uuid.New()
+	router := newTestRouter(t, &handlers.ItemHandler{Service: &cart.ServiceMock{
+		GetItemsFunc: func(ctx context.Context, page int64, pageSize int64) ([]cart.Item, error) {
+			return []cart.Item{}, nil
+		},
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (cart.Item, error) {
+			return cart.Item{ID: id, Name: "Lens", Price: 120000, Manufacturer: "Canon"}, nil
+		},
+	}}, nil, nil, nil)
+
+	tests := []struct {
+		method     string
+		path       string
+		status     int
+		deprecated bool
+	}{
+		{"GET", "/v1/items", http.StatusOK, false},
+		{"GET", "/items", http.StatusOK, true},
+		{"GET", "/v1/items/" + id.String(), http.StatusOK, false},
+		{"GET", "/items/" + id.String(), http.StatusOK, true},
+		{"GET", "/v1/items/not-a-uuid", http.StatusBadRequest, false},
+		{"GET", "/items/not-a-uuid", http.StatusBadRequest, true},
+		{"PUT", "/v1/items", http.StatusMethodNotAllowed, false},
+		{"PUT", "/items", http.StatusMethodNotAllowed, true},
+		{"GET", "/v1/webhooks/not-a-uuid", http.StatusBadRequest, false},
+		{"GET", "/webhooks/not-a-uuid", http.StatusBadRequest, true},
+		{"GET", "/v1/audit?page=-1", http.StatusBadRequest, false},
+		{"GET", "/audit?page=-1", http.StatusBadRequest, true},
+		{"GET", "/v1/health", http.StatusNotFound, false},
+		{"GET", "/health", http.StatusOK, false},
+		{"GET", "/livez", http.StatusOK, false},
+		{"GET", "/readyz", http.StatusOK, false},
+		{"GET", openapiHandlers.SpecPath, http.StatusOK, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.method+" "+test.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(test.method, test.path, nil))
+
+			if recorder.Code != test.status {
+				t.Errorf("expected %d, got %d", test.status, recorder.Code)
+			}
+
+			deprecation := recorder.Header().Get("Deprecation")
+			if test.deprecated != (deprecation != "") {
+				t.Errorf("expected deprecated to be %v, got Deprecation %q", test.deprecated, deprecation)
+			}
+			if !test.deprecated {
+				return
+			}
+
+			if sunset := recorder.Header().Get("Sunset"); sunset != LegacySunsetAt.Format(http.TimeFormat) {
+				t.Errorf("unexpected Sunset %q", sunset)
+			}
+			successor := "</v1" + strings.Split(test.path, "?")[0] + `>; rel="successor-version"`
+			if link := recorder.Header().Get("Link"); link != successor {
+				t.Errorf("expected Link %q, got %q", successor, link)
+			}
+		})
+	}
+}
+
+func Test_Initialize_WhenPostgresOnlyHandlersAreNil_ShouldLeaveTheirRoutesOut(t *testing.T) {
+	openapiValidator, err := middlewares.NewOpenAPIValidator(openapiHandlers.Spec, false)
+	if err != nil {
+		t.Fatalf("failed to load the OpenAPI spec: %v", err)
+	}
+
+	router := Initialize(
+		&handlers.ItemHandler{Service: &cart.ServiceMock{
+			GetItemsFunc: func(ctx context.Context, page int64, pageSize int64) ([]cart.Item, error) {
+				return []cart.Item{}, nil
+			},
+		}},
+		nil,
+		nil,
+		nil,
+		nil,
+		&graphqlHandlers.GraphQLHandler{},
+		&openapiHandlers.OpenAPIHandler{},
+		handlers.NewHealthCheckHandler(health.New(time.Second)),
+		openapiValidator,
+		middlewares.NewAuthentication(),
+		middlewares.NewRateLimiter(nil),
+		middlewares.NewAuthorizer(nil),
+	)
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/v1/items", http.StatusOK},
+		{"/v1/items/" + uuid.NewString() + "/history", http.StatusNotFound},
+		{"/v1/webhooks", http.StatusNotFound},
+		{"/v1/audit", http.StatusNotFound},
+		{"/admin/api-keys", http.StatusNotFound},
+		{"/health", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest("GET", test.path, nil))
+
+			if recorder.Code != test.status {
+				t.Errorf("expected %d, got %d", test.status, recorder.Code)
+			}
+		})
+	}
+}
+
+func Test_Initialize_WhenAuthenticationIsDisabled_ShouldLeaveAPIKeyRoutesOut(t *testing.T) {
+	openapiValidator, err := middlewares.NewOpenAPIValidator(openapiHandlers.Spec, false)
+	if err != nil {
+		t.Fatalf("failed to load the OpenAPI spec: %v", err)
+	}
+
+	router := Initialize(
+		&handlers.ItemHandler{},
+		nil,
+		nil,
+		nil,
+		&handlers.APIKeyHandler{Service: apikey.NewService(&apikey.RepositoryMock{})},
+		&graphqlHandlers.GraphQLHandler{},
+		&openapiHandlers.OpenAPIHandler{},
+		handlers.NewHealthCheckHandler(health.New(time.Second)),
+		openapiValidator,
+		middlewares.NewAuthentication(),
+		middlewares.NewRateLimiter(nil),
+		middlewares.NewAuthorizer(nil),
+	)
+
+	request := httptest.NewRequest("POST", "/admin/api-keys", strings.NewReader(`{"name": "erp", "scopes": ["api-keys:manage"]}`))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("expected API keys not to be issued without authentication, got %d", recorder.Code)
+	}
+}
+
+// stubAuthenticator accepts tokens naming a role and authenticates them as a principal with it.
+type stubAuthenticator struct{}
+
+func (stubAuthenticator) Authenticate(ctx context.Context, token string) (auth.Principal, error) {
+	switch token {
+	case "shopper", "merchandiser", "admin":
+		return auth.Principal{Subject: token + "@example.com", Roles: []string{token}}, nil
+	default:
+		return auth.Principal{}, auth.ErrUnauthenticated
+	}
+}
+
+func Test_Initialize_Routes_ShouldRequireAuthenticationExceptForPublicRoutes(t *testing.T) {
+	router := newTestRouter(t, &handlers.ItemHandler{Service: &cart.ServiceMock{
+		GetItemsFunc: func(ctx context.Context, page int64, pageSize int64) ([]cart.Item, error) {
+			return []cart.Item{}, nil
+		},
+	}}, stubAuthenticator{}, nil, nil)
+
+	tests := []struct {
+		method        string
+		path          string
+		authorization string
+		status        int
+	}{
+		{"GET", "/v1/items", "", http.StatusUnauthorized},
+		{"GET", "/v1/items", "Bearer invalid", http.StatusUnauthorized},
+		{"GET", "/v1/items", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"GET", "/v1/items", "Bearer shopper", http.StatusOK},
+		{"GET", "/items", "", http.StatusUnauthorized},
+		{"DELETE", "/v1/items/" + uuid.New().String(), "", http.StatusUnauthorized},
+		{"GET", "/v1/webhooks", "", http.StatusUnauthorized},
+		{"GET", "/v1/audit", "", http.StatusUnauthorized},
+		{"POST", "/graphql", "", http.StatusUnauthorized},
+		{"GET", "/health", "", http.StatusOK},
+		{"GET", "/readyz", "", http.StatusOK},
+		{"GET", openapiHandlers.SpecPath, "", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.method+" "+test.path+" "+test.authorization, func(t *testing.T) {
+			request := httptest.NewRequest(test.method, test.path, nil)
+			if test.authorization != "" {
+				request.Header.Set("Authorization", test.authorization)
+			}
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Errorf("expected %d, got %d", test.status, recorder.Code)
+			}
+			if test.status == http.StatusUnauthorized && !strings.HasPrefix(recorder.Header().Get("WWW-Authenticate"), "Bearer") {
+				t.Errorf("expected a Bearer challenge, got %q", recorder.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func Test_Initialize_ItemRoutes_ShouldEnforcePolicy(t *testing.T) {
+	policy, err := auth.LoadPolicy("")
+	if err != nil {
+		t.Fatalf("failed to load the default policy: %v", err)
+	}
+
+	item := cart.Item{ID: uuid.New(), Name: "Lens", Price: 120000, Manufacturer: "Canon"}
+	router := newTestRouter(t, &handlers.ItemHandler{Service: &cart.ServiceMock{
+		GetItemsFunc: func(ctx context.Context, page int64, pageSize int64) ([]cart.Item, error) {
+			return []cart.Item{item}, nil
+		},
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (cart.Item, error) {
+			return item, nil
+		},
+		AddItemFunc: func(ctx context.Context, dto *cart.ItemDTO) (cart.Item, error) {
+			return item, nil
+		},
+		UpdateItemFunc: func(ctx context.Context, updated *cart.Item) (cart.Item, cart.ServiceError) {
+			return *updated, nil
+		},
+		RemoveItemFunc: func(ctx context.Context, id uuid.UUID) (uuid.UUID, cart.ServiceError) {
+			return id, nil
+		},
+	}}, stubAuthenticator{}, policy, nil)
+
+	body := `{"name": "Lens", "price": 120000, "manufacturer": "Canon"}`
+	tests := []struct {
+		role   string
+		method string
+		path   string
+		status int
+	}{
+		{"shopper", "GET", "/v1/items", http.StatusOK},
+		{"shopper", "GET", "/v1/items/" + item.ID.String(), http.StatusOK},
+		{"shopper", "POST", "/v1/items", http.StatusForbidden},
+		{"shopper", "PUT", "/v1/items/" + item.ID.String(), http.StatusForbidden},
+		{"shopper", "DELETE", "/v1/items/" + item.ID.String(), http.StatusForbidden},
+		{"merchandiser", "POST", "/v1/items", http.StatusCreated},
+		{"merchandiser", "PUT", "/v1/items/" + item.ID.String(), http.StatusOK},
+		{"merchandiser", "DELETE", "/v1/items/" + item.ID.String(), http.StatusForbidden},
+		{"merchandiser", "DELETE", "/items/" + item.ID.String(), http.StatusForbidden},
+		{"admin", "DELETE", "/v1/items/" + item.ID.String(), http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.role+" "+test.method+" "+test.path, func(t *testing.T) {
+			var request *http.Request
+			if test.method == "POST" || test.method == "PUT" {
+				request = httptest.NewRequest(test.method, test.path, strings.NewReader(body))
+				request.Header.Set("Content-Type", "application/json")
+			} else {
+				request = httptest.NewRequest(test.method, test.path, nil)
+			}
+			request.Header.Set("Authorization", "Bearer "+test.role)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Errorf("expected %d, got %d: %s", test.status, recorder.Code, recorder.Body.String())
+			}
+			if test.status == http.StatusForbidden && recorder.Header().Get("Content-Type") != "application/problem+json" {
+				t.Errorf("expected a problem response, got %s", recorder.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func Test_Initialize_APIKeyRoutes_ShouldRequireManageAPIKeysPermission(t *testing.T) {
+	policy, err := auth.LoadPolicy("")
+	if err != nil {
+		t.Fatalf("failed to load the default policy: %v", err)
+	}
+
+	router := newTestRouter(t, &handlers.ItemHandler{}, stubAuthenticator{}, policy, nil)
+
+	tests := []struct {
+		role   string
+		method string
+		status int
+	}{
+		{"merchandiser", "GET", http.StatusForbidden},
+		{"merchandiser", "POST", http.StatusForbidden},
+		{"admin", "GET", http.StatusOK},
+		{"admin", "POST", http.StatusCreated},
+	}
+
+	for _, test := range tests {
+		t.Run(test.role+" "+test.method, func(t *testing.T) {
+			var request *http.Request
+			if test.method == "POST" {
+				request = httptest.NewRequest(test.method, "/admin/api-keys", strings.NewReader(`{"name": "erp", "scopes": ["items:read"]}`))
+				request.Header.Set("Content-Type", "application/json")
+			} else {
+				request = httptest.NewRequest(test.method, "/admin/api-keys", nil)
+			}
+			request.Header.Set("Authorization", "Bearer "+test.role)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Errorf("expected %d, got %d: %s", test.status, recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func Test_Initialize_WebhookAndAuditRoutes_ShouldRequireTheirPermissions(t *testing.T) {
+	policy, err := auth.LoadPolicy("")
+	if err != nil {
+		t.Fatalf("failed to load the default policy: %v", err)
+	}
+
+	router := newTestRouter(t, &handlers.ItemHandler{}, stubAuthenticator{}, policy, nil)
+
+	tests := []struct {
+		role   string
+		method string
+		path   string
+		status int
+	}{
+		{"merchandiser", "GET", "/v1/webhooks", http.StatusForbidden},
+		{"merchandiser", "POST", "/v1/webhooks", http.StatusForbidden},
+		{"merchandiser", "DELETE", "/v1/webhooks/" + uuid.NewString(), http.StatusForbidden},
+		{"merchandiser", "GET", "/v1/audit", http.StatusForbidden},
+		{"merchandiser", "GET", "/v1/items/" + uuid.NewString() + "/history", http.StatusForbidden},
+		{"admin", "GET", "/v1/webhooks", http.StatusOK},
+		{"admin", "GET", "/v1/audit", http.StatusOK},
+		{"admin", "GET", "/v1/items/" + uuid.NewString() + "/history", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.role+" "+test.method+" "+test.path, func(t *testing.T) {
+			var request *http.Request
+			if test.method == "POST" {
+				request = httptest.NewRequest(test.method, test.path, strings.NewReader(`{"url": "https://example.com/hooks", "event_types": ["item.created"], "secret": "0123456789abcdef"}`))
+				request.Header.Set("Content-Type", "application/json")
+			} else {
+				request = httptest.NewRequest(test.method, test.path, nil)
+			}
+			request.Header.Set("Authorization", "Bearer "+test.role)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Errorf("expected %d, got %d: %s", test.status, recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func Test_Initialize_Routes_WhenClientExceedsRateLimit_ShouldReturnTooManyRequests(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), &ratelimit.Limits{
+		Default: ratelimit.Limit{Requests: 100, Window: time.Minute},
+		Routes:  map[string]ratelimit.Limit{"GET /items": {Requests: 2, Window: time.Minute}},
+	})
+	router := newTestRouter(t, &handlers.ItemHandler{Service: &cart.ServiceMock{
+		GetItemsFunc: func(ctx context.Context, page int64, pageSize int64) ([]cart.Item, error) {
+			return []cart.Item{}, nil
+		},
+	}}, stubAuthenticator{}, nil, limiter)
+
+	get := func(path string, token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest("GET", path, nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	first := get("/v1/items", "shopper")
+	if first.Code != http.StatusOK || first.Header().Get("RateLimit-Limit") != "2" || first.Header().Get("RateLimit-Remaining") != "1" {
+		t.Fatalf("unexpected response %d with headers %v", first.Code, first.Header())
+	}
+
+	get("/items", "shopper")
+
+	limited := get("/v1/items", "shopper")
+	if limited.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected %d, got %d", http.StatusTooManyRequests, limited.Code)
+	}
+	if limited.Header().Get("Retry-After") != "30" || limited.Header().Get("Content-Type") != "application/problem+json" {
+		t.Errorf("unexpected headers %v", limited.Header())
+	}
+
+	if other := get("/v1/items", "admin"); other.Code != http.StatusOK {
+		t.Errorf("expected other clients not to be limited, got %d", other.Code)
+	}
+	if health := get("/health", "shopper"); health.Code != http.StatusOK || health.Header().Get("RateLimit-Limit") != "100" {
+		t.Errorf("expected /health to have the default limit, got %d with headers %v", health.Code, health.Header())
+	}
+}
+
+func Test_InitializeAdmin_GetMetrics_ShouldServePrometheusMetrics(t *testing.T) {
+	router := InitializeAdmin(metrics.Handler())
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d", http.StatusOK, recorder.Code)
+	}
+	for _, name := range []string{"shopping_cart_items_created_total", "go_goroutines"} {
+		if !strings.Contains(recorder.Body.String(), name) {
+			t.Errorf("expected %s to be exported", name)
+		}
+	}
+}
+
+func Test_HealthCheckHandler_WhenShuttingDown_ShouldFailReadinessButNotLiveness(t *testing.T) {
+	checks := health.New(time.Second)
+	checks.Register("database", func(ctx context.Context) error { return nil })
+	sut := handlers.NewHealthCheckHandler(checks)
+
+	get := func(handler http.HandlerFunc) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest("GET", "/", nil))
+		return recorder
+	}
+
+	if ready := get(sut.GetReadiness); ready.Code != http.StatusOK {
+		t.Fatalf("Expected %d before shutdown. Got %d", http.StatusOK, ready.Code)
+	}
+
+	checks.Shutdown()
+
+	if ready := get(sut.GetReadiness); ready.Code != http.StatusServiceUnavailable || ready.Body.String() != `{"status":"down"}` {
+		t.Errorf("Expected readiness to fail. Got %d %s", ready.Code, ready.Body.String())
+	}
+	if live := get(sut.GetLiveness); live.Code != http.StatusOK {
+		t.Errorf("Expected liveness to pass. Got %d", live.Code)
+	}
+
+	report := get(sut.GetHealthCheckHandler)
+	var body health.Report
+	if err := json.Unmarshal(report.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected a JSON report. Got %s", report.Body.String())
+	}
+	if report.Code != http.StatusServiceUnavailable || len(body.Checks) != 2 || body.Checks[0].Status != health.StatusUp || body.Checks[1].Status != health.StatusDown {
+		t.Errorf("Expected the report to be down with only the shutdown check failing. Got %d %+v", report.Code, body)
+	}
+}
+
+func Test_EventHandler_Close_ShouldEndStreamsButNotTheBroker(t *testing.T) {
+	broker := event.NewBroker()
+	eventHandler := handlers.NewEventHandler(nil, broker)
+
+	streamed := make(chan struct{})
+	go func() {
+		eventHandler.StreamItemEvents(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/items/events", nil))
+		close(streamed)
+	}()
+
+	eventHandler.Close()
+
+	select {
+	case <-streamed:
+	case <-time.After(time.Second):
+		t.Fatal("expected the stream to end once the handler is closed")
+	}
+
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+	broker.Publish(event.Event{ID: 1, Type: event.ItemUpdated, ItemID: uuid.New()})
+	if published, ok := <-events; !ok || published.ID != 1 {
+		t.Errorf("expected the broker to keep publishing, got %+v, %t", published, ok)
+	}
+}
+
+func Test_EventHandler_StreamItemEvents_WhenResuming_ShouldSendEventsThatCommitAfterTheLastOne(t *testing.T) {
+	broker := event.NewBroker()
+	recordedAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var recordedAtCalled time.Time
+	mockRepository := &event.RepositoryMock{
+		GetEventFunc: func(ctx context.Context, id int64) (event.Event, error) {
+			return event.Event{ID: id, Type: event.ItemCreated, RecordedAt: recordedAt}, nil
+		},
+		GetEventsRecordedSinceFunc: func(ctx context.Context, since time.Time, id int64, limit int64) ([]event.Event, error) {
+			recordedAtCalled = since
+
+			// Event 11 was recorded before event 12 but commits after it has been replayed, and event
+			// 12 is relayed live as well.
+			broker.Publish(event.Event{ID: 12, Type: event.ItemUpdated, RecordedAt: recordedAt.Add(2 * time.Second)})
+			broker.Publish(event.Event{ID: 11, Type: event.ItemUpdated, RecordedAt: recordedAt.Add(time.Second)})
+			broker.Close()
+
+			return []event.Event{
+				{ID: 10, Type: event.ItemCreated, RecordedAt: recordedAt},
+				{ID: 12, Type: event.ItemUpdated, RecordedAt: recordedAt.Add(2 * time.Second)},
+			}, nil
+		},
+	}
+
+	request := httptest.NewRequest("GET", "/v1/items/events", nil)
+	request.Header.Set("Last-Event-ID", "10")
+	recorder := httptest.NewRecorder()
+
+	handlers.NewEventHandler(mockRepository, broker).StreamItemEvents(recorder, request)
+
+	if expected := recordedAt.Add(-event.CatchUpOverlap); !recordedAtCalled.Equal(expected) {
+		t.Errorf("Expected to replay from %s. Got %s", expected, recordedAtCalled)
+	}
+
+	var ids []string
+	for _, line := range strings.Split(recorder.Body.String(), "\n") {
+		if id, ok := strings.CutPrefix(line, "id: "); ok {
+			ids = append(ids, id)
+		}
+	}
+	if strings.Join(ids, ",") != "12,11" {
+		t.Errorf("Expected events 12 and 11 to be sent once each. Got %v", ids)
+	}
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/handler/content"
+	jsonHandler "github.com/tjmaynes/shopping-cart-service-go/internal/handler/json"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/apikey"
+)
+
+// NewAPIKeyHandler ..
+func NewAPIKeyHandler(service apikey.Service) *APIKeyHandler {
+	return &APIKeyHandler{Service: service}
+}
+
+// APIKeyHandler ..
+type APIKeyHandler struct {
+	Service apikey.Service
+}
+
+// GetAPIKeys ..
+func (h *APIKeyHandler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	page, pageSize := getPagination(r)
+
+	data, err := h.Service.GetAPIKeys(r.Context(), page, pageSize)
+	if err != nil {
+		writeInternalError(w, r, err.Error())
+		return
+	}
+
+	content.CreateResponse(w, r, http.StatusOK, map[string][]apikey.APIKey{"data": data})
+}
+
+// AddAPIKey responds with the new key, which can't be retrieved again.
+func (h *APIKeyHandler) AddAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	var key apikey.APIKeyDTO
+	err := content.Decode(r, &key)
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	data, serviceError := h.Service.AddAPIKey(r.Context(), &key)
+	if serviceError != nil {
+		writeAPIKeyServiceError(w, r, serviceError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	content.CreateResponse(w, r, http.StatusCreated, map[string]apikey.CreatedAPIKey{"data": data})
+}
+
+// RemoveAPIKey revokes a key.
+func (h *APIKeyHandler) RemoveAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "DELETE" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, errorCode := getUUID(r)
+	if errorCode >= 400 {
+		http.Error(w, http.StatusText(errorCode), errorCode)
+		return
+	}
+
+	_, serviceError := h.Service.RemoveAPIKey(r.Context(), id)
+	if serviceError != nil {
+		writeAPIKeyServiceError(w, r, serviceError)
+		return
+	}
+
+	content.CreateResponse(w, r, http.StatusOK, http.StatusText(200))
+}
+
+func writeAPIKeyServiceError(w http.ResponseWriter, r *http.Request, serviceError apikey.ServiceError) {
+	switch serviceError.StatusCode() {
+	case apikey.InvalidAPIKey:
+		jsonHandler.CreateErrorResponse(w, http.StatusBadRequest, serviceError.Message())
+	case apikey.APIKeyNotFound:
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	default:
+		writeInternalError(w, r, serviceError.Message())
+	}
+}
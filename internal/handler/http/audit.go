@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/handler/content"
+	jsonHandler "github.com/tjmaynes/shopping-cart-service-go/internal/handler/json"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/audit"
+)
+
+// NewAuditHandler ..
+func NewAuditHandler(service audit.Service) *AuditHandler {
+	return &AuditHandler{Service: service}
+}
+
+// AuditHandler ..
+type AuditHandler struct {
+	Service audit.Service
+}
+
+// GetAuditEntries ..
+func (a *AuditHandler) GetAuditEntries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter := audit.Filter{Actor: r.URL.Query().Get("actor")}
+	if rawSince := r.URL.Query().Get("since"); rawSince != "" {
+		since, err := time.Parse(time.RFC3339, rawSince)
+		if err != nil {
+			jsonHandler.CreateErrorResponse(w, http.StatusBadRequest, "since: must be an RFC 3339 timestamp.")
+			return
+		}
+		filter.Since = since
+	}
+
+	page, pageSize := getPagination(r)
+
+	data, err := a.Service.GetEntries(r.Context(), filter, page, pageSize)
+	if err != nil {
+		writeInternalError(w, r, err.Error())
+		return
+	}
+
+	content.CreateResponse(w, r, http.StatusOK, map[string][]audit.Entry{"data": data})
+}
+
+// GetItemHistory ..
+func (a *AuditHandler) GetItemHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, errorCode := getUUID(r)
+	if errorCode >= 400 {
+		http.Error(w, http.StatusText(errorCode), errorCode)
+		return
+	}
+
+	page, pageSize := getPagination(r)
+
+	data, err := a.Service.GetEntriesByEntity(r.Context(), audit.ItemEntityType, id, page, pageSize)
+	if err != nil {
+		writeInternalError(w, r, err.Error())
+		return
+	}
+
+	content.CreateResponse(w, r, http.StatusOK, map[string][]audit.Entry{"data": data})
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/event"
+)
+
+const (
+	eventStreamHeartbeatInterval = 15 * time.Second
+	eventStreamReplayBatchSize   = 100
+)
+
+// NewEventHandler ..
+func NewEventHandler(repository event.Repository, broker *event.Broker) *EventHandler {
+	return &EventHandler{Repository: repository, Broker: broker, closed: make(chan struct{})}
+}
+
+// EventHandler ..
+type EventHandler struct {
+	Repository event.Repository
+	Broker     *event.Broker
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// Close ends every event stream, and any started later, without closing the broker, which the
+// background workers still read from.
+func (e *EventHandler) Close() {
+	e.closeOnce.Do(func() { close(e.closed) })
+}
+
+// StreamItemEvents streams item changes as Server-Sent Events. Clients resuming with a
+// Last-Event-ID header are first replayed every event they missed, catching up the way the
+// listener does: from shortly before the event they last received was recorded, so events that
+// committed after it are included. Some events they already received may be sent again, with the
+// same id; if their last event has been pruned, every retained event is replayed.
+func (e *EventHandler) StreamItemEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	var lastEventID int64
+	if rawLastEventID := r.Header.Get("Last-Event-ID"); rawLastEventID != "" {
+		id, err := strconv.ParseInt(rawLastEventID, 10, 64)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		lastEventID = id
+	}
+
+	seen := event.NewSeen()
+	if lastEventID > 0 {
+		lastEvent, err := e.Repository.GetEvent(r.Context(), lastEventID)
+		if err == nil {
+			seen.Add(lastEvent)
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	controller := http.NewResponseController(w)
+	// Streams outlive the server's write timeout.
+	controller.SetWriteDeadline(time.Time{})
+
+	events, unsubscribe := e.Broker.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	recordedAt, id := seen.CatchUpFrom(), int64(0)
+	for lastEventID > 0 {
+		missed, err := e.Repository.GetEventsRecordedSince(r.Context(), recordedAt, id, eventStreamReplayBatchSize)
+		if err != nil {
+			return
+		}
+
+		for _, missedEvent := range missed {
+			if !seen.Add(missedEvent) {
+				continue
+			}
+			if err := writeEvent(w, missedEvent); err != nil {
+				return
+			}
+		}
+
+		if len(missed) < eventStreamReplayBatchSize {
+			break
+		}
+		last := missed[len(missed)-1]
+		recordedAt, id = last.RecordedAt, last.ID
+	}
+
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(eventStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-e.closed:
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case liveEvent, ok := <-events:
+			if !ok {
+				return
+			}
+			if !seen.Add(liveEvent) {
+				continue
+			}
+			if err := writeEvent(w, liveEvent); err != nil {
+				return
+			}
+		}
+
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, e event.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
@@ -1,31 +1,57 @@
 package handler
 
 import (
-	"database/sql"
-	jsonHandler "github.com/tjmaynes/shopping-cart-service-go/internal/handler/json"
+	"log/slog"
 	"net/http"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/handler/content"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/health"
 )
 
 // NewHealthCheckHandler ..
-func NewHealthCheckHandler(dbConn *sql.DB) *HealthCheckHandler {
-	return &HealthCheckHandler{DbConn: dbConn}
+func NewHealthCheckHandler(health *health.Health) *HealthCheckHandler {
+	return &HealthCheckHandler{Health: health}
 }
 
 // HealthCheckHandler ..
 type HealthCheckHandler struct {
-	DbConn *sql.DB
+	Health *health.Health
+}
+
+// GetLiveness reports that the process is up and serving requests, without checking any of its
+// dependencies, so that a failing database doesn't get every replica restarted.
+func (h *HealthCheckHandler) GetLiveness(w http.ResponseWriter, r *http.Request) {
+	content.CreateResponse(w, r, http.StatusOK, map[string]string{"status": health.StatusUp})
+}
+
+// GetReadiness reports whether the service is ready to serve requests: its checks pass and it
+// isn't shutting down.
+func (h *HealthCheckHandler) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	report := h.check(r)
+	content.CreateResponse(w, r, statusCode(report), map[string]string{"status": report.Status})
 }
 
-// GetHealthCheckHandler ..
+// GetHealthCheckHandler reports the status and latency of each check.
 func (h *HealthCheckHandler) GetHealthCheckHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != "GET" {
-		http.Error(w, http.StatusText(405), 405)
-		return
+	report := h.check(r)
+	content.CreateResponse(w, r, statusCode(report), report)
+}
+
+func (h *HealthCheckHandler) check(r *http.Request) health.Report {
+	report := h.Health.Check(r.Context())
+	if !report.Up() {
+		for _, result := range report.Checks {
+			if result.Status != health.StatusUp {
+				slog.WarnContext(r.Context(), "health check failed", "check", result.Name, "error", result.Error)
+			}
+		}
 	}
+	return report
+}
 
-	if err := h.DbConn.Ping(); err != nil {
-		http.Error(w, http.StatusText(500), 500)
-	} else {
-		jsonHandler.CreateResponse(w, http.StatusOK, map[string]string{"message": "PONG!"})
+func statusCode(report health.Report) int {
+	if report.Up() {
+		return http.StatusOK
 	}
+	return http.StatusServiceUnavailable
 }
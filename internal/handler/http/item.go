@@ -1,13 +1,17 @@
 package handler
 
 import (
-	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"log/slog"
 	"net/http"
 	"strconv"
-	"strings"
 
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/handler/content"
 	jsonHandler "github.com/tjmaynes/shopping-cart-service-go/internal/handler/json"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
 	cart "github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
 )
 
@@ -28,23 +32,19 @@ func (c *ItemHandler) GetItems(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	page, err := strconv.ParseInt(r.URL.Query().Get("page"), 10, 64)
-	if err != nil {
-		page = 0
-	}
-
-	pageSize, err := strconv.ParseInt(r.URL.Query().Get("pageSize"), 10, 64)
-	if err != nil {
-		pageSize = 10
-	}
+	page, pageSize := getPagination(r)
 
 	data, err := c.Service.GetItems(r.Context(), page, pageSize)
+	if errors.Is(err, auth.ErrForbidden) {
+		writeForbidden(w, err.Error())
+		return
+	}
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeInternalError(w, r, err.Error())
 		return
 	}
 
-	jsonHandler.CreateResponse(w, http.StatusOK, map[string][]cart.Item{"data": data})
+	content.CreateResponse(w, r, http.StatusOK, map[string][]cart.Item{"data": data})
 }
 
 // GetItemByID ..
@@ -54,18 +54,23 @@ func (c *ItemHandler) GetItemByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, errorCode := getID(r.URL.Path)
+	id, errorCode := getUUID(r)
 	if errorCode >= 400 {
 		http.Error(w, http.StatusText(errorCode), errorCode)
+		return
 	}
 
-	data, err := c.Service.GetItemByID(r.Context(), uuid.MustParse(*id))
+	data, err := c.Service.GetItemByID(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		writeForbidden(w, err.Error())
+		return
+	}
 	if err != nil {
 		http.Error(w, http.StatusText(404), 404)
 		return
 	}
 
-	jsonHandler.CreateResponse(w, http.StatusOK, map[string]cart.Item{"data": data})
+	content.CreateResponse(w, r, http.StatusOK, map[string]cart.Item{"data": data})
 }
 
 // AddItem ..
@@ -75,29 +80,29 @@ func (c *ItemHandler) AddItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := r.ParseForm()
+	var item cart.ItemDTO
+	err := content.Decode(r, &item)
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusUnprocessableEntity), http.StatusUnprocessableEntity)
+		writeDecodeError(w, err)
 		return
 	}
 
-	itemName := r.Form.Get("name")
-	itemManufacturer := r.Form.Get("manufacturer")
-	itemPrice, errorCode := getItemPrice(r.Form.Get("price"))
-	if errorCode >= 400 {
-		http.Error(w, http.StatusText(errorCode), errorCode)
+	data, err := c.Service.AddItem(r.Context(), &item)
+	if errors.Is(err, auth.ErrForbidden) {
+		writeForbidden(w, err.Error())
 		return
 	}
-
-	item := cart.ItemDTO{Name: itemName, Price: itemPrice, Manufacturer: itemManufacturer}
-
-	data, err := c.Service.AddItem(r.Context(), &item)
 	if err != nil {
+		var validationErrors validation.Errors
+		if errors.As(err, &validationErrors) {
+			jsonHandler.CreateErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		http.Error(w, http.StatusText(500), 500)
 		return
 	}
 
-	jsonHandler.CreateResponse(w, http.StatusCreated, map[string]cart.Item{"data": data})
+	content.CreateResponse(w, r, http.StatusCreated, map[string]cart.Item{"data": data})
 }
 
 // UpdateItem ..
@@ -107,32 +112,24 @@ func (c *ItemHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rawId, errorCode := getID(r.URL.Path)
+	id, errorCode := getUUID(r)
 	if errorCode >= 400 {
 		http.Error(w, http.StatusText(errorCode), errorCode)
+		return
 	}
 
-	decoder := json.NewDecoder(r.Body)
-	type RawItemRequest struct {
-		Name         string `json:"name"`
-		Price        string `json:"price"`
-		Manufacturer string `json:"manufacturer"`
-	}
-	var rawItemRequest RawItemRequest
-	err := decoder.Decode(&rawItemRequest)
+	var request cart.ItemDTO
+	err := content.Decode(r, &request)
 	if err != nil {
-		panic(err)
-	}
-
-	price, errorCode := getItemPrice(rawItemRequest.Price)
-	if errorCode >= 400 {
-		http.Error(w, http.StatusText(errorCode), errorCode)
+		writeDecodeError(w, err)
 		return
 	}
 
-	id := uuid.MustParse(*rawId)
-
 	result, err := c.Service.GetItemByID(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		writeForbidden(w, err.Error())
+		return
+	}
 	if err != nil {
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
@@ -145,9 +142,9 @@ func (c *ItemHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 
 	item := cart.Item{
 		ID:           id,
-		Name:         rawItemRequest.Name,
-		Price:        price,
-		Manufacturer: rawItemRequest.Manufacturer,
+		Name:         request.Name,
+		Price:        request.Price,
+		Manufacturer: request.Manufacturer,
 	}
 
 	result, serviceError := c.Service.UpdateItem(r.Context(), &item)
@@ -155,13 +152,17 @@ func (c *ItemHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 		switch serviceError.StatusCode() {
 		case cart.InvalidItem:
 			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		case cart.ItemNotFound:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		case cart.Forbidden:
+			writeForbidden(w, serviceError.Message())
 		default:
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			writeInternalError(w, r, serviceError.Message())
 		}
 		return
 	}
 
-	jsonHandler.CreateResponse(w, http.StatusOK, map[string]cart.Item{"data": result})
+	content.CreateResponse(w, r, http.StatusOK, map[string]cart.Item{"data": result})
 }
 
 // RemoveItem ..
@@ -171,33 +172,160 @@ func (c *ItemHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, errorCode := getID(r.URL.Path)
+	id, errorCode := getUUID(r)
 	if errorCode >= 400 {
 		http.Error(w, http.StatusText(errorCode), errorCode)
+		return
 	}
 
-	_, serviceError := c.Service.RemoveItem(r.Context(), uuid.MustParse(*id))
+	_, serviceError := c.Service.RemoveItem(r.Context(), id)
 	if serviceError != nil {
+		if serviceError.StatusCode() == cart.Forbidden {
+			writeForbidden(w, serviceError.Message())
+			return
+		}
 		http.Error(w, serviceError.Message(), 500)
 		return
 	}
 
-	jsonHandler.CreateResponse(w, http.StatusOK, http.StatusText(200))
+	content.CreateResponse(w, r, http.StatusOK, http.StatusText(200))
 }
 
-func getID(urlPath string) (*string, int) {
-	params := strings.Split(urlPath, "/")
-	if len(params) < 2 {
-		return nil, http.StatusBadRequest
+// GetItemPrices ..
+func (c *ItemHandler) GetItemPrices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, errorCode := getUUID(r)
+	if errorCode >= 400 {
+		http.Error(w, http.StatusText(errorCode), errorCode)
+		return
+	}
+
+	page, pageSize := getPagination(r)
+
+	data, serviceError := c.Service.GetPriceHistory(r.Context(), id, page, pageSize)
+	if serviceError != nil {
+		switch serviceError.StatusCode() {
+		case cart.ItemNotFound:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		case cart.Forbidden:
+			writeForbidden(w, serviceError.Message())
+		default:
+			writeInternalError(w, r, serviceError.Message())
+		}
+		return
 	}
 
-	return &params[2], 0
+	content.CreateResponse(w, r, http.StatusOK, map[string][]cart.PriceChange{"data": data})
 }
 
-func getItemPrice(rawPrice string) (cart.Decimal, int) {
-	result, err := strconv.ParseInt(rawPrice, 10, 64)
+// GetScheduledItemPrices ..
+func (c *ItemHandler) GetScheduledItemPrices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, errorCode := getUUID(r)
+	if errorCode >= 400 {
+		http.Error(w, http.StatusText(errorCode), errorCode)
+		return
+	}
+
+	data, serviceError := c.Service.GetScheduledPrices(r.Context(), id)
+	if serviceError != nil {
+		switch serviceError.StatusCode() {
+		case cart.ItemNotFound:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		case cart.Forbidden:
+			writeForbidden(w, serviceError.Message())
+		default:
+			writeInternalError(w, r, serviceError.Message())
+		}
+		return
+	}
+
+	content.CreateResponse(w, r, http.StatusOK, map[string][]cart.ScheduledPrice{"data": data})
+}
+
+// ScheduleItemPrice ..
+func (c *ItemHandler) ScheduleItemPrice(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, errorCode := getUUID(r)
+	if errorCode >= 400 {
+		http.Error(w, http.StatusText(errorCode), errorCode)
+		return
+	}
+
+	var price cart.ScheduledPriceDTO
+	err := content.Decode(r, &price)
 	if err != nil {
-		return 0, http.StatusBadRequest
+		writeDecodeError(w, err)
+		return
 	}
-	return cart.Decimal(result), 0
+
+	result, serviceError := c.Service.SchedulePrice(r.Context(), id, &price)
+	if serviceError != nil {
+		switch serviceError.StatusCode() {
+		case cart.InvalidPrice:
+			jsonHandler.CreateErrorResponse(w, http.StatusBadRequest, serviceError.Message())
+		case cart.ItemNotFound:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		case cart.Forbidden:
+			writeForbidden(w, serviceError.Message())
+		default:
+			writeInternalError(w, r, serviceError.Message())
+		}
+		return
+	}
+
+	content.CreateResponse(w, r, http.StatusCreated, map[string]cart.ScheduledPrice{"data": result})
+}
+
+func getUUID(r *http.Request) (uuid.UUID, int) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return uuid.Nil, http.StatusBadRequest
+	}
+
+	return id, 0
+}
+
+func getPagination(r *http.Request) (int64, int64) {
+	page, err := strconv.ParseInt(r.URL.Query().Get("page"), 10, 64)
+	if err != nil {
+		page = 0
+	}
+
+	pageSize, err := strconv.ParseInt(r.URL.Query().Get("pageSize"), 10, 64)
+	if err != nil {
+		pageSize = 10
+	}
+
+	return page, pageSize
+}
+
+func writeDecodeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, content.ErrUnsupportedMediaType) {
+		http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+}
+
+func writeForbidden(w http.ResponseWriter, detail string) {
+	jsonHandler.CreateProblemResponse(w, jsonHandler.Problem{Status: http.StatusForbidden, Detail: detail})
+}
+
+// writeInternalError logs the cause of a 500, which isn't disclosed to the client.
+func writeInternalError(w http.ResponseWriter, r *http.Request, cause string) {
+	slog.ErrorContext(r.Context(), "request failed", "method", r.Method, "path", r.URL.Path, "error", cause)
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 }
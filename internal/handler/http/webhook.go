@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/handler/content"
+	jsonHandler "github.com/tjmaynes/shopping-cart-service-go/internal/handler/json"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/webhook"
+)
+
+// NewWebhookHandler ..
+func NewWebhookHandler(service webhook.Service) *WebhookHandler {
+	return &WebhookHandler{Service: service}
+}
+
+// WebhookHandler ..
+type WebhookHandler struct {
+	Service webhook.Service
+}
+
+// GetSubscriptions ..
+func (h *WebhookHandler) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	page, pageSize := getPagination(r)
+
+	data, err := h.Service.GetSubscriptions(r.Context(), page, pageSize)
+	if err != nil {
+		writeInternalError(w, r, err.Error())
+		return
+	}
+
+	content.CreateResponse(w, r, http.StatusOK, map[string][]webhook.Subscription{"data": data})
+}
+
+// GetSubscriptionByID ..
+func (h *WebhookHandler) GetSubscriptionByID(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, errorCode := getUUID(r)
+	if errorCode >= 400 {
+		http.Error(w, http.StatusText(errorCode), errorCode)
+		return
+	}
+
+	data, serviceError := h.Service.GetSubscriptionByID(r.Context(), id)
+	if serviceError != nil {
+		writeWebhookServiceError(w, r, serviceError)
+		return
+	}
+
+	content.CreateResponse(w, r, http.StatusOK, map[string]webhook.Subscription{"data": data})
+}
+
+// AddSubscription ..
+func (h *WebhookHandler) AddSubscription(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	var subscription webhook.SubscriptionDTO
+	err := content.Decode(r, &subscription)
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	data, serviceError := h.Service.AddSubscription(r.Context(), &subscription)
+	if serviceError != nil {
+		writeWebhookServiceError(w, r, serviceError)
+		return
+	}
+
+	content.CreateResponse(w, r, http.StatusCreated, map[string]webhook.Subscription{"data": data})
+}
+
+// RemoveSubscription ..
+func (h *WebhookHandler) RemoveSubscription(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "DELETE" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, errorCode := getUUID(r)
+	if errorCode >= 400 {
+		http.Error(w, http.StatusText(errorCode), errorCode)
+		return
+	}
+
+	_, serviceError := h.Service.RemoveSubscription(r.Context(), id)
+	if serviceError != nil {
+		writeWebhookServiceError(w, r, serviceError)
+		return
+	}
+
+	content.CreateResponse(w, r, http.StatusOK, http.StatusText(200))
+}
+
+// GetDeliveries ..
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, errorCode := getUUID(r)
+	if errorCode >= 400 {
+		http.Error(w, http.StatusText(errorCode), errorCode)
+		return
+	}
+
+	page, pageSize := getPagination(r)
+
+	data, serviceError := h.Service.GetDeliveries(r.Context(), id, page, pageSize)
+	if serviceError != nil {
+		writeWebhookServiceError(w, r, serviceError)
+		return
+	}
+
+	content.CreateResponse(w, r, http.StatusOK, map[string][]webhook.Delivery{"data": data})
+}
+
+func writeWebhookServiceError(w http.ResponseWriter, r *http.Request, serviceError webhook.ServiceError) {
+	switch serviceError.StatusCode() {
+	case webhook.InvalidSubscription:
+		jsonHandler.CreateErrorResponse(w, http.StatusBadRequest, serviceError.Message())
+	case webhook.SubscriptionNotFound:
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	default:
+		writeInternalError(w, r, serviceError.Message())
+	}
+}
@@ -17,3 +17,37 @@ func CreateResponse(w http.ResponseWriter, code int, payload interface{}) {
 	w.WriteHeader(code)
 	w.Write(response)
 }
+
+// ProblemContentType ..
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body.
+type Problem struct {
+	Type   string         `json:"type"`
+	Title  string         `json:"title"`
+	Status int            `json:"status"`
+	Detail string         `json:"detail,omitempty"`
+	Errors []ProblemError `json:"errors,omitempty"`
+}
+
+// ProblemError points at the part of a request that caused a Problem.
+type ProblemError struct {
+	In     string `json:"in"`
+	Name   string `json:"name,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// CreateProblemResponse ..
+func CreateProblemResponse(w http.ResponseWriter, problem Problem) {
+	if problem.Type == "" {
+		problem.Type = "about:blank"
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+
+	response, _ := json.Marshal(problem)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	w.Write(response)
+}
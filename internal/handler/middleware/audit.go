@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/audit"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/logging"
+)
+
+// AuditContext attaches the request id assigned by the RequestID middleware to the request
+// context, so audit entries can be traced back to the request that made them. The actor comes from
+// the authenticated principal; nothing the client sends is trusted for attribution.
+func AuditContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := audit.WithRequestID(r.Context(), logging.RequestIDFromContext(r.Context()))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	jsonHandler "github.com/tjmaynes/shopping-cart-service-go/internal/handler/json"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
+)
+
+// Authentication ..
+type Authentication struct {
+	Schemes []auth.Scheme
+	Realm   string
+}
+
+// NewAuthentication returns middleware that authenticates requests with whichever of schemes they
+// present a credential for. Schemes with a nil Authenticator are skipped, and without any
+// authentication is disabled.
+func NewAuthentication(schemes ...auth.Scheme) *Authentication {
+	enabled := make([]auth.Scheme, 0, len(schemes))
+	for _, scheme := range schemes {
+		if scheme.Authenticator != nil {
+			enabled = append(enabled, scheme)
+		}
+	}
+	return &Authentication{Schemes: enabled, Realm: "shopping-cart-service"}
+}
+
+// Authenticate rejects requests without a valid credential with a 401 problem response and
+// attaches the authenticated principal to the request context. Requests whose credential couldn't
+// be checked get a 503.
+func (a *Authentication) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(a.Schemes) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		for _, scheme := range a.Schemes {
+			credential, ok := scheme.Credential(r.Header.Values)
+			if !ok {
+				continue
+			}
+
+			principal, err := scheme.Authenticator.Authenticate(r.Context(), credential)
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				a.unauthorized(w, scheme.Name, err.Error())
+				return
+			} else if err != nil {
+				slog.ErrorContext(r.Context(), "unable to authenticate request", "scheme", scheme.Name, "error", err)
+				jsonHandler.CreateProblemResponse(w, jsonHandler.Problem{
+					Status: http.StatusServiceUnavailable,
+					Detail: "The credential couldn't be checked, try again later.",
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+			return
+		}
+
+		a.unauthorized(w, "", "A credential is required.")
+	})
+}
+
+// unauthorized challenges for every scheme, flagging the one whose credential was rejected.
+func (a *Authentication) unauthorized(w http.ResponseWriter, rejected string, detail string) {
+	for _, scheme := range a.Schemes {
+		challenge := fmt.Sprintf("%s realm=%q", scheme.Name, a.Realm)
+		if scheme.Name == rejected {
+			challenge += `, error="invalid_token"`
+		}
+		w.Header().Add("WWW-Authenticate", challenge)
+	}
+
+	jsonHandler.CreateProblemResponse(w, jsonHandler.Problem{
+		Status: http.StatusUnauthorized,
+		Detail: detail,
+	})
+}
+
+// Authorizer ..
+type Authorizer struct {
+	Policy *auth.Policy
+}
+
+// NewAuthorizer returns middleware that checks permissions against policy. A nil policy disables
+// authorization.
+func NewAuthorizer(policy *auth.Policy) *Authorizer {
+	return &Authorizer{Policy: policy}
+}
+
+// Require rejects requests whose principal isn't granted permission with a 403 problem response.
+func (a *Authorizer) Require(permission auth.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if a.Policy == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if err := a.Policy.Authorize(r.Context(), permission); err != nil {
+				jsonHandler.CreateProblemResponse(w, jsonHandler.Problem{
+					Status: http.StatusForbidden,
+					Detail: err.Error(),
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/audit"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
+)
+
+type stubAuthenticator struct{}
+
+func (stubAuthenticator) Authenticate(ctx context.Context, token string) (auth.Principal, error) {
+	if token != "valid" {
+		return auth.Principal{}, auth.ErrUnauthenticated
+	}
+	return auth.Principal{Subject: "merchandiser@example.com"}, nil
+}
+
+type failingAuthenticator struct{}
+
+func (failingAuthenticator) Authenticate(ctx context.Context, token string) (auth.Principal, error) {
+	return auth.Principal{}, errors.New("connection refused")
+}
+
+func Test_Authentication_Authenticate_WhenTokenIsValid_ShouldAttachPrincipal(t *testing.T) {
+	sut := NewAuthentication(auth.BearerScheme(stubAuthenticator{}))
+
+	var actor string
+	request := httptest.NewRequest("GET", "/v1/items", nil)
+	request.Header.Set("Authorization", "bearer valid")
+	request.Header.Set("X-Actor", "spoofed@example.com")
+	recorder := httptest.NewRecorder()
+
+	AuditContext(sut.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor = audit.ActorFromContext(r.Context())
+	}))).ServeHTTP(recorder, request)
+
+	if actor != "merchandiser@example.com" {
+		t.Errorf("expected the principal to be the actor, got %q", actor)
+	}
+}
+
+func Test_AuditContext_WhenRequestIsUnauthenticated_ShouldNotTrustActorHeader(t *testing.T) {
+	var actor string
+	request := httptest.NewRequest("GET", "/v1/items", nil)
+	request.Header.Set("X-Actor", "spoofed@example.com")
+	recorder := httptest.NewRecorder()
+
+	AuditContext(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor = audit.ActorFromContext(r.Context())
+	})).ServeHTTP(recorder, request)
+
+	if actor != audit.AnonymousActor {
+		t.Errorf("expected the actor to be %q, got %q", audit.AnonymousActor, actor)
+	}
+}
+
+func Test_Authentication_Authenticate_WhenTokenIsInvalid_ShouldChallenge(t *testing.T) {
+	sut := NewAuthentication(auth.BearerScheme(stubAuthenticator{}))
+
+	request := httptest.NewRequest("GET", "/v1/items", nil)
+	request.Header.Set("Authorization", "Bearer expired")
+	recorder := httptest.NewRecorder()
+
+	sut.Authenticate(http.HandlerFunc(ok)).ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("expected %d, got %d", http.StatusUnauthorized, recorder.Code)
+	}
+	expected := `Bearer realm="shopping-cart-service", error="invalid_token"`
+	if challenge := recorder.Header().Get("WWW-Authenticate"); challenge != expected {
+		t.Errorf("expected %s, got %s", expected, challenge)
+	}
+	decodeProblem(t, recorder)
+}
+
+func Test_Authentication_Authenticate_WhenCredentialCannotBeChecked_ShouldReturnServiceUnavailable(t *testing.T) {
+	sut := NewAuthentication(auth.APIKeyScheme(failingAuthenticator{}))
+
+	request := httptest.NewRequest("GET", "/v1/items", nil)
+	request.Header.Set("X-API-Key", "sck_valid")
+	recorder := httptest.NewRecorder()
+
+	sut.Authenticate(http.HandlerFunc(ok)).ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected %d, got %d", http.StatusServiceUnavailable, recorder.Code)
+	}
+	if challenge := recorder.Header().Get("WWW-Authenticate"); challenge != "" {
+		t.Errorf("expected the credential not to be challenged, got %s", challenge)
+	}
+	decodeProblem(t, recorder)
+}
+
+func Test_Authentication_Authenticate_WhenSchemesAreComposed_ShouldAcceptEither(t *testing.T) {
+	sut := NewAuthentication(auth.BearerScheme(stubAuthenticator{}), auth.APIKeyScheme(stubAuthenticator{}))
+
+	tests := []struct {
+		header string
+		value  string
+		status int
+	}{
+		{"Authorization", "Bearer valid", http.StatusOK},
+		{"Authorization", "ApiKey valid", http.StatusOK},
+		{"X-API-Key", "valid", http.StatusOK},
+		{"X-API-Key", "revoked", http.StatusUnauthorized},
+		{"Authorization", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.header+" "+test.value, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/v1/items", nil)
+			request.Header.Set(test.header, test.value)
+			recorder := httptest.NewRecorder()
+
+			sut.Authenticate(http.HandlerFunc(ok)).ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Errorf("expected %d, got %d", test.status, recorder.Code)
+			}
+		})
+	}
+}
+
+func Test_Authentication_Authenticate_WhenCredentialIsMissing_ShouldChallengeEveryScheme(t *testing.T) {
+	sut := NewAuthentication(auth.BearerScheme(stubAuthenticator{}), auth.APIKeyScheme(stubAuthenticator{}))
+
+	recorder := httptest.NewRecorder()
+	sut.Authenticate(http.HandlerFunc(ok)).ServeHTTP(recorder, httptest.NewRequest("GET", "/v1/items", nil))
+
+	challenges := recorder.Header().Values("WWW-Authenticate")
+	if len(challenges) != 2 || challenges[0] != `Bearer realm="shopping-cart-service"` || challenges[1] != `ApiKey realm="shopping-cart-service"` {
+		t.Errorf("unexpected challenges %v", challenges)
+	}
+	decodeProblem(t, recorder)
+}
+
+func Test_Authentication_Authenticate_WhenAuthenticatorIsNil_ShouldCallNext(t *testing.T) {
+	sut := NewAuthentication(auth.BearerScheme(nil))
+
+	recorder := httptest.NewRecorder()
+	sut.Authenticate(http.HandlerFunc(ok)).ServeHTTP(recorder, httptest.NewRequest("GET", "/v1/items", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected %d, got %d", http.StatusOK, recorder.Code)
+	}
+}
+
+func Test_Authorizer_Require_WhenRoleIsNotGranted_ShouldReturnForbidden(t *testing.T) {
+	policy, _ := auth.LoadPolicy("")
+	sut := NewAuthorizer(policy)
+
+	request := httptest.NewRequest("DELETE", "/v1/items/1", nil)
+	request = request.WithContext(auth.WithPrincipal(request.Context(), auth.Principal{Subject: "shopper@example.com", Roles: []string{"shopper"}}))
+	recorder := httptest.NewRecorder()
+
+	sut.Require(auth.DeleteItems)(http.HandlerFunc(ok)).ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected %d, got %d", http.StatusForbidden, recorder.Code)
+	}
+	decodeProblem(t, recorder)
+}
+
+func Test_Authorizer_Require_WhenRoleIsGranted_ShouldCallNext(t *testing.T) {
+	policy, _ := auth.LoadPolicy("")
+	sut := NewAuthorizer(policy)
+
+	request := httptest.NewRequest("DELETE", "/v1/items/1", nil)
+	request = request.WithContext(auth.WithPrincipal(request.Context(), auth.Principal{Subject: "admin@example.com", Roles: []string{"admin"}}))
+	recorder := httptest.NewRecorder()
+
+	sut.Require(auth.DeleteItems)(http.HandlerFunc(ok)).ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected %d, got %d", http.StatusOK, recorder.Code)
+	}
+}
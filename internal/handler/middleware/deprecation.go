@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Deprecate marks every response as coming from a deprecated route: Deprecation carries the date
+// the route was deprecated (RFC 9745), Sunset the date it will be removed (RFC 8594), and Link
+// points at the same path under successor.
+func Deprecate(deprecatedAt time.Time, sunsetAt time.Time, successor string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", fmt.Sprintf("@%d", deprecatedAt.Unix()))
+			w.Header().Set("Sunset", sunsetAt.UTC().Format(http.TimeFormat))
+			w.Header().Add("Link", fmt.Sprintf("<%s%s>; rel=\"successor-version\"", successor, r.URL.Path))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
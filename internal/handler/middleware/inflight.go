@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// InFlight counts the requests being served, so that shutdown can report what it's waiting on.
+type InFlight struct {
+	count atomic.Int64
+}
+
+// Track counts the requests served by next while they're being served.
+func (f *InFlight) Track(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.count.Add(1)
+		defer f.count.Add(-1)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Count is the number of requests being served.
+func (f *InFlight) Count() int64 {
+	return f.count.Load()
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_InFlight_Track_ShouldCountRequestsWhileTheyAreServed(t *testing.T) {
+	sut := &InFlight{}
+
+	var during int64
+	handler := sut.Track(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		during = sut.Count()
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/items", nil))
+
+	if during != 1 || sut.Count() != 0 {
+		t.Errorf("expected 1 request in flight while served and 0 after, got %d and %d", during, sut.Count())
+	}
+}
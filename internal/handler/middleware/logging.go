@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+
+	jsonHandler "github.com/tjmaynes/shopping-cart-service-go/internal/handler/json"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/logging"
+)
+
+// RequestIDHeader ..
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the request ids accepted from clients, which end up in every log line.
+const maxRequestIDLength = 128
+
+// RequestID attaches the request id from the X-Request-ID header, or a new one when it is missing
+// or unusable, to the request context and echoes it on the response.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !isRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+func isRequestID(value string) bool {
+	if value == "" || len(value) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range value {
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// Logger logs every request once it has been served, at error level for 5xx responses. The
+// request's headers, with credentials redacted, are included at debug level.
+func Logger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(recorder, r)
+
+		status := recorder.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", status),
+			slog.Int("bytes", recorder.BytesWritten()),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("remote_addr", r.RemoteAddr),
+			slog.String("user_agent", r.UserAgent()),
+		}
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			attrs = append(attrs, slog.String("route", rctx.RoutePattern()))
+		}
+		if slog.Default().Enabled(r.Context(), slog.LevelDebug) {
+			attrs = append(attrs, logging.Headers("headers", r.Header))
+		}
+
+		slog.LogAttrs(r.Context(), level, "request", attrs...)
+	})
+}
+
+// Recoverer turns a panic in a handler into a 500 problem response, logging it with its stack.
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			slog.ErrorContext(r.Context(), "handler panicked", "panic", recovered, "stack", string(debug.Stack()))
+			jsonHandler.CreateProblemResponse(w, jsonHandler.Problem{Status: http.StatusInternalServerError})
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/logging"
+)
+
+func useTestLogger(t *testing.T, level string) *bytes.Buffer {
+	var output bytes.Buffer
+	logger, err := logging.New(&output, level, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	return &output
+}
+
+func Test_RequestID_WhenHeaderIsGiven_ShouldPropagateIt(t *testing.T) {
+	var requestID string
+	request := httptest.NewRequest("GET", "/v1/items", nil)
+	request.Header.Set(RequestIDHeader, "checkout-42")
+	recorder := httptest.NewRecorder()
+
+	RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = logging.RequestIDFromContext(r.Context())
+	})).ServeHTTP(recorder, request)
+
+	if requestID != "checkout-42" || recorder.Header().Get(RequestIDHeader) != "checkout-42" {
+		t.Errorf("expected checkout-42 to be propagated, got %q and %q", requestID, recorder.Header().Get(RequestIDHeader))
+	}
+}
+
+func Test_RequestID_WhenHeaderIsMissingOrUnusable_ShouldGenerateOne(t *testing.T) {
+	for _, header := range []string{"", "has spaces\n", strings.Repeat("a", 129)} {
+		request := httptest.NewRequest("GET", "/v1/items", nil)
+		request.Header.Set(RequestIDHeader, header)
+		recorder := httptest.NewRecorder()
+
+		RequestID(http.HandlerFunc(ok)).ServeHTTP(recorder, request)
+
+		if generated := recorder.Header().Get(RequestIDHeader); generated == "" || generated == header {
+			t.Errorf("expected a new request id for %q, got %q", header, generated)
+		}
+	}
+}
+
+func Test_Logger_ShouldLogRequestAsJSONWithRequestIDAndRedactedHeaders(t *testing.T) {
+	output := useTestLogger(t, "debug")
+
+	request := httptest.NewRequest("DELETE", "/v1/items/1", nil)
+	request.Header.Set(RequestIDHeader, "checkout-42")
+	request.Header.Set("Authorization", "Bearer eyJhbGciOi")
+	recorder := httptest.NewRecorder()
+
+	RequestID(Logger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))).ServeHTTP(recorder, request)
+
+	var record struct {
+		Level     string            `json:"level"`
+		Method    string            `json:"method"`
+		Status    int               `json:"status"`
+		RequestID string            `json:"request_id"`
+		Headers   map[string]string `json:"headers"`
+	}
+	if err := json.Unmarshal(output.Bytes(), &record); err != nil {
+		t.Fatalf("expected a JSON line, got %q", output.String())
+	}
+
+	if record.Level != "INFO" || record.Method != "DELETE" || record.Status != http.StatusNoContent || record.RequestID != "checkout-42" {
+		t.Errorf("unexpected record %+v", record)
+	}
+	if record.Headers["Authorization"] != logging.Redacted {
+		t.Errorf("expected the Authorization header to be redacted, got %q", record.Headers["Authorization"])
+	}
+}
+
+func Test_Recoverer_WhenHandlerPanics_ShouldRespondWithProblemAndLogIt(t *testing.T) {
+	output := useTestLogger(t, "info")
+
+	recorder := httptest.NewRecorder()
+	Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})).ServeHTTP(recorder, httptest.NewRequest("GET", "/v1/items", nil))
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Fatalf("expected %d, got %d", http.StatusInternalServerError, recorder.Code)
+	}
+	decodeProblem(t, recorder)
+
+	if !strings.Contains(output.String(), `"panic":"boom"`) || !strings.Contains(output.String(), `"level":"ERROR"`) {
+		t.Errorf("expected the panic to be logged, got %s", output.String())
+	}
+}
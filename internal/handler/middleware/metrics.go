@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/metrics"
+)
+
+// unmatchedRoute labels the metrics of requests that didn't match any route.
+const unmatchedRoute = "unmatched"
+
+// Metrics records the rate, errors and duration of requests by their route pattern, such as
+// /v1/items/{id}, rather than their path, so that ids don't multiply the series exported.
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		metrics.HTTPRequestsInFlight.Inc()
+		defer metrics.HTTPRequestsInFlight.Dec()
+
+		next.ServeHTTP(recorder, r)
+
+		status := recorder.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		route := unmatchedRoute
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+
+		metrics.HTTPRequests.WithLabelValues(r.Method, route, strconv.Itoa(status)).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
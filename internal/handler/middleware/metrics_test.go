@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/metrics"
+)
+
+func Test_Metrics_ShouldLabelRequestsByRoutePattern(t *testing.T) {
+	router := chi.NewRouter()
+	router.Use(Metrics)
+	router.Route("/v1", func(rt chi.Router) {
+		rt.Get("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+	})
+
+	counter := metrics.HTTPRequests.WithLabelValues("GET", "/v1/items/{id}", "404")
+	before := testutil.ToFloat64(counter)
+
+	for _, id := range []string{"3f0c4c1e-0d52-4a4b-9d38-6c1f8a1f2b11", "9a7f3e52-64a1-4b0e-8c55-1d2b3c4d5e6f"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/items/"+id, nil))
+	}
+
+	if got := testutil.ToFloat64(counter) - before; got != 2 {
+		t.Errorf("expected both requests to be counted under /v1/items/{id}, got %v", got)
+	}
+}
+
+func Test_Metrics_WhenNoRouteMatches_ShouldLabelRequestsAsUnmatched(t *testing.T) {
+	router := chi.NewRouter()
+	router.Use(Metrics)
+	router.Get("/health", ok)
+
+	counter := metrics.HTTPRequests.WithLabelValues("GET", unmatchedRoute, "404")
+	before := testutil.ToFloat64(counter)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/unknown/path", nil))
+
+	if got := testutil.ToFloat64(counter) - before; got != 1 {
+		t.Errorf("expected the request to be counted as unmatched, got %v", got)
+	}
+}
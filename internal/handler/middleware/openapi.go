@@ -0,0 +1,358 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/google/uuid"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/handler/content"
+	jsonHandler "github.com/tjmaynes/shopping-cart-service-go/internal/handler/json"
+)
+
+func init() {
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewCallbackValidator(func(value string) error {
+		_, err := uuid.Parse(value)
+		return err
+	}))
+
+	openapi3filter.RegisterBodyDecoder(content.MsgPack, msgPackBodyDecoder)
+}
+
+// NewOpenAPIValidator builds a validator for the OpenAPI document in spec. When validateResponses
+// is set, responses are checked against the document too; that buffers every response, so it is
+// meant for tests rather than production.
+func NewOpenAPIValidator(spec []byte, validateResponses bool) (*OpenAPIValidator, error) {
+	spec, err := withNullableTypes(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Match on paths alone so the document's example server URL doesn't constrain the host.
+	doc.Servers = nil
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenAPIValidator{Router: router, ValidateResponses: validateResponses}, nil
+}
+
+// OpenAPIValidator ..
+type OpenAPIValidator struct {
+	Router            routers.Router
+	ValidateResponses bool
+}
+
+// Validate rejects requests whose path parameters, query or body do not match the OpenAPI
+// document with a 400 problem response, bodies in a media type the operation doesn't take with
+// 415, and Accept headers none of its responses can satisfy with 406. Requests for undocumented
+// routes are passed through so the router can answer with 404 or 405.
+func (v *OpenAPIValidator) Validate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := v.Router.FindRoute(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !acceptsContentType(route, r) {
+			jsonHandler.CreateProblemResponse(w, jsonHandler.Problem{
+				Status: http.StatusUnsupportedMediaType,
+				Detail: "The request body's Content-Type is not supported by this operation.",
+				Errors: []jsonHandler.ProblemError{{In: "header", Name: "Content-Type", Reason: r.Header.Get("Content-Type")}},
+			})
+			return
+		}
+
+		if !satisfiesAccept(route, r) {
+			jsonHandler.CreateProblemResponse(w, jsonHandler.Problem{
+				Status: http.StatusNotAcceptable,
+				Detail: "None of this operation's response media types match the Accept header.",
+				Errors: []jsonHandler.ProblemError{{In: "header", Name: "Accept", Reason: r.Header.Get("Accept")}},
+			})
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				MultiError:         true,
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			},
+		}
+
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			jsonHandler.CreateProblemResponse(w, jsonHandler.Problem{
+				Status: http.StatusBadRequest,
+				Detail: "The request does not match the API specification.",
+				Errors: toProblemErrors(err),
+			})
+			return
+		}
+
+		if !v.ValidateResponses || isStreaming(route) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		recorder := &responseRecorder{header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 recorder.status,
+			Header:                 recorder.header,
+			Body:                   io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+			Options:                &openapi3filter.Options{MultiError: true, IncludeResponseStatus: true},
+		})
+		if err != nil {
+			slog.ErrorContext(r.Context(), "response does not match the API specification", "method", r.Method, "path", r.URL.Path, "error", err)
+			jsonHandler.CreateProblemResponse(w, jsonHandler.Problem{
+				Status: http.StatusInternalServerError,
+				Detail: "The response does not match the API specification.",
+				Errors: toProblemErrors(err),
+			})
+			return
+		}
+
+		for key, values := range recorder.header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(recorder.status)
+		w.Write(recorder.body.Bytes())
+	})
+}
+
+// withNullableTypes rewrites OpenAPI 3.1 type arrays such as ["string", "null"] into the
+// OpenAPI 3.0 form kin-openapi validates against: "type": "string", "nullable": true.
+func withNullableTypes(spec []byte) ([]byte, error) {
+	var doc interface{}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, err
+	}
+
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch value := node.(type) {
+		case map[string]interface{}:
+			if types, ok := value["type"].([]interface{}); ok {
+				var remaining []interface{}
+				for _, t := range types {
+					if t == "null" {
+						value["nullable"] = true
+					} else {
+						remaining = append(remaining, t)
+					}
+				}
+				if len(remaining) == 1 {
+					value["type"] = remaining[0]
+				} else {
+					value["type"] = remaining
+				}
+			}
+			for _, child := range value {
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+	walk(doc)
+
+	return json.Marshal(doc)
+}
+
+// acceptsContentType reports whether the operation takes the request body's media type. Bodies
+// sent without a Content-Type are treated as JSON, as content.Decode does, and MessagePack aliases
+// are rewritten to the one name the document lists.
+func acceptsContentType(route *routers.Route, r *http.Request) bool {
+	if route.Operation == nil || route.Operation.RequestBody == nil || route.Operation.RequestBody.Value == nil {
+		return true
+	}
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		return true
+	}
+
+	if r.Header.Get("Content-Type") == "" {
+		r.Header.Set("Content-Type", content.JSON)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	if canonical := content.Canonical(mediaType); canonical != mediaType {
+		r.Header.Set("Content-Type", canonical)
+		mediaType = canonical
+	}
+
+	return route.Operation.RequestBody.Value.Content.Get(mediaType) != nil
+}
+
+// satisfiesAccept reports whether one of the operation's successful responses can be written in a
+// media type the Accept header allows.
+func satisfiesAccept(route *routers.Route, r *http.Request) bool {
+	if route.Operation == nil || route.Operation.Responses == nil {
+		return true
+	}
+
+	var offers []string
+	for status, response := range route.Operation.Responses.Map() {
+		if !strings.HasPrefix(status, "2") || response.Value == nil {
+			continue
+		}
+		for mediaType := range response.Value.Content {
+			offers = append(offers, mediaType)
+		}
+	}
+	if len(offers) == 0 {
+		return true
+	}
+
+	_, err := content.Negotiate(r.Header.Get("Accept"), offers)
+	return err == nil
+}
+
+// msgPackBodyDecoder decodes MessagePack bodies into the same values a JSON body would produce so
+// they are validated against the same schemas.
+func msgPackBodyDecoder(body io.Reader, _ http.Header, _ *openapi3.SchemaRef, _ openapi3filter.EncodingFn) (interface{}, error) {
+	var value interface{}
+	if err := msgpack.NewDecoder(body).Decode(&value); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	err = json.Unmarshal(data, &decoded)
+	return decoded, err
+}
+
+func isStreaming(route *routers.Route) bool {
+	if route.Operation == nil || route.Operation.Responses == nil {
+		return false
+	}
+
+	for _, response := range route.Operation.Responses.Map() {
+		if response.Value != nil && response.Value.Content.Get("text/event-stream") != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toProblemErrors(err error) []jsonHandler.ProblemError {
+	var requestError *openapi3filter.RequestError
+	if errors.As(err, &requestError) {
+		if requestError.Parameter != nil {
+			return []jsonHandler.ProblemError{{
+				In:     requestError.Parameter.In,
+				Name:   requestError.Parameter.Name,
+				Reason: reason(requestError.Err, requestError.Reason),
+			}}
+		}
+
+		if schemaErrors := schemaProblemErrors("body", requestError.Err); len(schemaErrors) > 0 {
+			return schemaErrors
+		}
+
+		return []jsonHandler.ProblemError{{In: "body", Reason: reason(requestError.Err, requestError.Reason)}}
+	}
+
+	var responseError *openapi3filter.ResponseError
+	if errors.As(err, &responseError) {
+		if schemaErrors := schemaProblemErrors("response", responseError.Err); len(schemaErrors) > 0 {
+			return schemaErrors
+		}
+
+		return []jsonHandler.ProblemError{{In: "response", Reason: reason(responseError.Err, responseError.Reason)}}
+	}
+
+	var multiError openapi3.MultiError
+	if errors.As(err, &multiError) {
+		var problemErrors []jsonHandler.ProblemError
+		for _, e := range multiError {
+			problemErrors = append(problemErrors, toProblemErrors(e)...)
+		}
+		return problemErrors
+	}
+
+	return []jsonHandler.ProblemError{{In: "request", Reason: err.Error()}}
+}
+
+func schemaProblemErrors(in string, err error) []jsonHandler.ProblemError {
+	var multiError openapi3.MultiError
+	if errors.As(err, &multiError) {
+		var problemErrors []jsonHandler.ProblemError
+		for _, e := range multiError {
+			problemErrors = append(problemErrors, schemaProblemErrors(in, e)...)
+		}
+		return problemErrors
+	}
+
+	var schemaError *openapi3.SchemaError
+	if errors.As(err, &schemaError) {
+		return []jsonHandler.ProblemError{{
+			In:     in,
+			Name:   strings.Join(schemaError.JSONPointer(), "."),
+			Reason: schemaError.Reason,
+		}}
+	}
+
+	return nil
+}
+
+func reason(err error, fallback string) string {
+	var schemaError *openapi3.SchemaError
+	if errors.As(err, &schemaError) {
+		return schemaError.Reason
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return fallback
+}
+
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	return r.body.Write(data)
+}
@@ -0,0 +1,298 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/handler/content"
+	jsonHandler "github.com/tjmaynes/shopping-cart-service-go/internal/handler/json"
+	openapiHandlers "github.com/tjmaynes/shopping-cart-service-go/internal/handler/openapi"
+)
+
+func newTestValidator(t *testing.T, validateResponses bool) *OpenAPIValidator {
+	sut, err := NewOpenAPIValidator(openapiHandlers.Spec, validateResponses)
+	if err != nil {
+		t.Fatalf("failed to load the OpenAPI spec: %v", err)
+	}
+	return sut
+}
+
+func serve(sut *OpenAPIValidator, next http.HandlerFunc, request *http.Request) (*httptest.ResponseRecorder, bool) {
+	var called bool
+	recorder := httptest.NewRecorder()
+	sut.Validate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		next(w, r)
+	})).ServeHTTP(recorder, request)
+	return recorder, called
+}
+
+func ok(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func decodeProblem(t *testing.T, recorder *httptest.ResponseRecorder) jsonHandler.Problem {
+	if contentType := recorder.Header().Get("Content-Type"); contentType != jsonHandler.ProblemContentType {
+		t.Fatalf("expected a problem response, got %s", contentType)
+	}
+
+	var problem jsonHandler.Problem
+	if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("failed to decode problem: %v", err)
+	}
+	return problem
+}
+
+func hasError(problem jsonHandler.Problem, in string, name string) bool {
+	for _, e := range problem.Errors {
+		if e.In == in && e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func Test_OpenAPIValidator_Validate_WhenRequestIsValid_ShouldCallNext(t *testing.T) {
+	sut := newTestValidator(t, false)
+
+	body := `{"name": "Apple", "price": "199", "manufacturer": "Orchard"}`
+	request := httptest.NewRequest("PUT", "/items/"+uuid.New().String(), strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+
+	recorder, called := serve(sut, ok, request)
+
+	if !called || recorder.Code != http.StatusOK {
+		t.Errorf("expected the request to reach the handler, got %d", recorder.Code)
+	}
+}
+
+func Test_OpenAPIValidator_Validate_WhenPriceIsNumeric_ShouldCallNext(t *testing.T) {
+	sut := newTestValidator(t, false)
+
+	body := `{"name": "Apple", "price": 199, "manufacturer": "Orchard"}`
+	request := httptest.NewRequest("PUT", "/items/"+uuid.New().String(), strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+
+	recorder, called := serve(sut, ok, request)
+
+	if !called || recorder.Code != http.StatusOK {
+		t.Errorf("expected the request to reach the handler, got %d", recorder.Code)
+	}
+}
+
+func Test_OpenAPIValidator_Validate_WhenBodyIsMsgPack_ShouldCallNext(t *testing.T) {
+	sut := newTestValidator(t, false)
+
+	body, _ := msgpack.Marshal(map[string]interface{}{"name": "Apple", "price": 199, "manufacturer": "Orchard"})
+	request := httptest.NewRequest("POST", "/items", strings.NewReader(string(body)))
+	request.Header.Set("Content-Type", "application/x-msgpack")
+
+	recorder, called := serve(sut, ok, request)
+
+	if !called || recorder.Code != http.StatusOK {
+		t.Errorf("expected the request to reach the handler, got %d", recorder.Code)
+	}
+}
+
+func Test_OpenAPIValidator_Validate_WhenContentTypeIsUnsupported_ShouldReturnUnsupportedMediaType(t *testing.T) {
+	sut := newTestValidator(t, false)
+
+	request := httptest.NewRequest("POST", "/items", strings.NewReader("name=Apple"))
+	request.Header.Set("Content-Type", "text/plain")
+
+	recorder, called := serve(sut, ok, request)
+
+	if called {
+		t.Errorf("expected the handler not to be called")
+	}
+	if recorder.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected %d, got %d", http.StatusUnsupportedMediaType, recorder.Code)
+	}
+	if problem := decodeProblem(t, recorder); !hasError(problem, "header", "Content-Type") {
+		t.Errorf("expected a Content-Type error, got %+v", problem.Errors)
+	}
+}
+
+func Test_OpenAPIValidator_Validate_WhenAcceptCannotBeSatisfied_ShouldReturnNotAcceptable(t *testing.T) {
+	sut := newTestValidator(t, false)
+
+	request := httptest.NewRequest("GET", "/items", nil)
+	request.Header.Set("Accept", "application/xml")
+
+	recorder, called := serve(sut, ok, request)
+
+	if called {
+		t.Errorf("expected the handler not to be called")
+	}
+	if recorder.Code != http.StatusNotAcceptable {
+		t.Fatalf("expected %d, got %d", http.StatusNotAcceptable, recorder.Code)
+	}
+	if problem := decodeProblem(t, recorder); !hasError(problem, "header", "Accept") {
+		t.Errorf("expected an Accept error, got %+v", problem.Errors)
+	}
+}
+
+func Test_OpenAPIValidator_Validate_WhenBodyIsInvalid_ShouldReturnProblem(t *testing.T) {
+	sut := newTestValidator(t, false)
+
+	body := `{"name": "Apple", "price": "", "manufacturer": "Orchard"}`
+	request := httptest.NewRequest("PUT", "/items/"+uuid.New().String(), strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+
+	recorder, called := serve(sut, ok, request)
+
+	if called {
+		t.Fatalf("the handler should not have been called")
+	}
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+
+	problem := decodeProblem(t, recorder)
+	if problem.Status != http.StatusBadRequest || !hasError(problem, "body", "price") {
+		t.Errorf("unexpected problem: %+v", problem)
+	}
+}
+
+func Test_OpenAPIValidator_Validate_WhenBodyIsMalformed_ShouldReturnProblem(t *testing.T) {
+	sut := newTestValidator(t, false)
+
+	request := httptest.NewRequest("PUT", "/items/"+uuid.New().String(), strings.NewReader(`{"name": `))
+	request.Header.Set("Content-Type", "application/json")
+
+	recorder, called := serve(sut, ok, request)
+
+	if called || recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+}
+
+func Test_OpenAPIValidator_Validate_WhenFormIsInvalid_ShouldReturnProblem(t *testing.T) {
+	sut := newTestValidator(t, false)
+
+	form := url.Values{}
+	form.Add("name", "Apple")
+	form.Add("price", "cheap")
+	form.Add("manufacturer", "Orchard")
+	request := httptest.NewRequest("POST", "/items", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	recorder, called := serve(sut, ok, request)
+
+	if called || recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+}
+
+func Test_OpenAPIValidator_Validate_WhenPathParameterIsInvalid_ShouldReturnProblem(t *testing.T) {
+	sut := newTestValidator(t, false)
+
+	recorder, called := serve(sut, ok, httptest.NewRequest("GET", "/items/not-a-uuid", nil))
+
+	if called || recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+
+	if problem := decodeProblem(t, recorder); !hasError(problem, "path", "id") {
+		t.Errorf("unexpected problem: %+v", problem)
+	}
+}
+
+func Test_OpenAPIValidator_Validate_WhenQueryParameterIsInvalid_ShouldReturnProblem(t *testing.T) {
+	sut := newTestValidator(t, false)
+
+	recorder, called := serve(sut, ok, httptest.NewRequest("GET", "/items?page=-1", nil))
+
+	if called || recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+
+	if problem := decodeProblem(t, recorder); !hasError(problem, "query", "page") {
+		t.Errorf("unexpected problem: %+v", problem)
+	}
+}
+
+func Test_OpenAPIValidator_Validate_WhenRouteIsNotDocumented_ShouldCallNext(t *testing.T) {
+	sut := newTestValidator(t, false)
+
+	_, called := serve(sut, ok, httptest.NewRequest("PUT", "/items", nil))
+
+	if !called {
+		t.Errorf("undocumented routes should be left to the router")
+	}
+}
+
+func Test_OpenAPIValidator_Validate_WhenResponseIsInvalid_ShouldReturnProblem(t *testing.T) {
+	sut := newTestValidator(t, true)
+
+	recorder, _ := serve(sut, func(w http.ResponseWriter, r *http.Request) {
+		jsonHandler.CreateResponse(w, http.StatusOK, map[string]string{"unexpected": "shape"})
+	}, httptest.NewRequest("GET", "/items", nil))
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Fatalf("expected %d, got %d", http.StatusInternalServerError, recorder.Code)
+	}
+	decodeProblem(t, recorder)
+}
+
+func Test_OpenAPIValidator_Validate_WhenResponseIsValid_ShouldWriteResponse(t *testing.T) {
+	sut := newTestValidator(t, true)
+
+	recorder, _ := serve(sut, func(w http.ResponseWriter, r *http.Request) {
+		jsonHandler.CreateResponse(w, http.StatusOK, map[string]string{"status": "up"})
+	}, httptest.NewRequest("GET", "/livez", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	if body := recorder.Body.String(); body != `{"status":"up"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+}
+
+func Test_OpenAPIValidator_Validate_WhenResponseIsCSV_ShouldWriteResponse(t *testing.T) {
+	sut := newTestValidator(t, true)
+
+	request := httptest.NewRequest("GET", "/audit", nil)
+	request.Header.Set("Accept", "text/csv")
+
+	recorder, _ := serve(sut, func(w http.ResponseWriter, r *http.Request) {
+		content.CreateResponse(w, r, http.StatusOK, map[string][]struct {
+			ID int64 `json:"id"`
+		}{"data": {{ID: 1}}})
+	}, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	if body := recorder.Body.String(); body != "id\n1\n" {
+		t.Errorf("unexpected body %q", body)
+	}
+}
+
+func Test_OpenAPIValidator_Validate_WhenResponseIsMsgPack_ShouldWriteResponse(t *testing.T) {
+	sut := newTestValidator(t, true)
+
+	request := httptest.NewRequest("GET", "/livez", nil)
+	request.Header.Set("Accept", "application/msgpack")
+
+	recorder, _ := serve(sut, func(w http.ResponseWriter, r *http.Request) {
+		content.CreateResponse(w, r, http.StatusOK, map[string]string{"status": "up"})
+	}, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+
+	var message map[string]string
+	if err := msgpack.Unmarshal(recorder.Body.Bytes(), &message); err != nil || message["status"] != "up" {
+		t.Errorf("unexpected body %q: %v", recorder.Body.String(), err)
+	}
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	jsonHandler "github.com/tjmaynes/shopping-cart-service-go/internal/handler/json"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/ratelimit"
+)
+
+// RateLimiter ..
+type RateLimiter struct {
+	Limiter *ratelimit.Limiter
+}
+
+// NewRateLimiter returns middleware that limits each client's requests with limiter. A nil
+// limiter disables rate limiting.
+func NewRateLimiter(limiter *ratelimit.Limiter) *RateLimiter {
+	return &RateLimiter{Limiter: limiter}
+}
+
+// Limit counts requests against their route's limit per client, identified by the authenticated
+// principal (an API key or token subject) or else the client's IP, so it must run after
+// authentication. Every response carries RateLimit-* headers, and requests over the limit get a
+// 429 problem response with Retry-After. When the limiter's store fails, requests are let through.
+func (l *RateLimiter) Limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.Limiter == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		result, err := l.Limiter.Allow(r.Context(), client(r), r.Method, routePattern(r))
+		l.enforce(w, r, next, result, err)
+	})
+}
+
+// LimitIP counts requests against the per-IP limit of the client's IP, whoever they authenticate
+// as. It runs before authentication, so guessing credentials is limited too. Requests over the
+// limit are rejected as by Limit.
+func (l *RateLimiter) LimitIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.Limiter == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		result, err := l.Limiter.AllowIP(r.Context(), clientIP(r))
+		l.enforce(w, r, next, result, err)
+	})
+}
+
+func (l *RateLimiter) enforce(w http.ResponseWriter, r *http.Request, next http.Handler, result ratelimit.Result, err error) {
+	if err != nil {
+		slog.ErrorContext(r.Context(), "rate limiting is unavailable", "error", err)
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	header := w.Header()
+	header.Set("RateLimit-Limit", strconv.Itoa(result.Limit.Requests))
+	header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	header.Set("RateLimit-Reset", seconds(result.Reset))
+	header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%s", result.Limit.Requests, seconds(result.Limit.Window)))
+
+	if !result.Allowed {
+		header.Set("Retry-After", seconds(result.RetryAfter))
+		jsonHandler.CreateProblemResponse(w, jsonHandler.Problem{
+			Status: http.StatusTooManyRequests,
+			Detail: fmt.Sprintf("The rate limit of %d requests per %s was exceeded.", result.Limit.Requests, result.Limit.Window),
+		})
+		return
+	}
+
+	next.ServeHTTP(w, r)
+}
+
+func client(r *http.Request) string {
+	if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+		return principal.Subject
+	}
+
+	return "ip:" + clientIP(r)
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// routePattern matches r against the whole router, since middleware runs before the route, and
+// falls back to the path for unknown routes.
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.Routes != nil {
+		match := chi.NewRouteContext()
+		if rctx.Routes.Match(match, r.Method, r.URL.Path) {
+			return match.RoutePattern()
+		}
+	}
+	return r.URL.Path
+}
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/ratelimit"
+)
+
+type unavailableStore struct{}
+
+func (unavailableStore) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (time.Time, bool, error) {
+	return time.Time{}, false, errors.New("connection refused")
+}
+
+func Test_RateLimiter_Limit_WhenStoreFails_ShouldCallNext(t *testing.T) {
+	sut := NewRateLimiter(ratelimit.NewLimiter(unavailableStore{}, &ratelimit.Limits{Default: ratelimit.Limit{Requests: 1, Window: time.Minute}}))
+
+	recorder := httptest.NewRecorder()
+	sut.Limit(http.HandlerFunc(ok)).ServeHTTP(recorder, httptest.NewRequest("GET", "/v1/items", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected %d, got %d", http.StatusOK, recorder.Code)
+	}
+}
+
+func Test_RateLimiter_Limit_WhenUnauthenticated_ShouldLimitByClientIP(t *testing.T) {
+	sut := NewRateLimiter(ratelimit.NewLimiter(ratelimit.NewMemoryStore(), &ratelimit.Limits{Default: ratelimit.Limit{Requests: 1, Window: time.Minute}}))
+
+	codes := make([]int, 0, 3)
+	for _, remoteAddr := range []string{"192.0.2.1:1234", "192.0.2.1:5678", "192.0.2.2:1234"} {
+		request := httptest.NewRequest("GET", "/health", nil)
+		request.RemoteAddr = remoteAddr
+		recorder := httptest.NewRecorder()
+		sut.Limit(http.HandlerFunc(ok)).ServeHTTP(recorder, request)
+		codes = append(codes, recorder.Code)
+	}
+
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests || codes[2] != http.StatusOK {
+		t.Errorf("expected only the second request from the same IP to be limited, got %v", codes)
+	}
+}
+
+func Test_RateLimiter_LimitIP_ShouldLimitCredentialGuessingBeforeAuthentication(t *testing.T) {
+	sut := NewRateLimiter(ratelimit.NewLimiter(ratelimit.NewMemoryStore(), &ratelimit.Limits{
+		Default: ratelimit.Limit{Requests: 100, Window: time.Minute},
+		PerIP:   ratelimit.Limit{Requests: 2, Window: time.Minute},
+	}))
+	authentication := NewAuthentication(auth.BearerScheme(stubAuthenticator{}))
+
+	codes := make([]int, 0, 3)
+	for _, token := range []string{"guess-1", "guess-2", "valid"} {
+		request := httptest.NewRequest("GET", "/v1/items", nil)
+		request.RemoteAddr = "192.0.2.1:1234"
+		request.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		sut.LimitIP(authentication.Authenticate(http.HandlerFunc(ok))).ServeHTTP(recorder, request)
+		codes = append(codes, recorder.Code)
+	}
+
+	if codes[0] != http.StatusUnauthorized || codes[1] != http.StatusUnauthorized || codes[2] != http.StatusTooManyRequests {
+		t.Errorf("expected the third attempt from the same IP to be limited, got %v", codes)
+	}
+}
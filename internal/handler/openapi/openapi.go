@@ -0,0 +1,55 @@
+package handler
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/swaggest/swgui/v5emb"
+)
+
+const (
+	// SpecPath is where the OpenAPI document is served.
+	SpecPath = "/openapi.json"
+
+	// DocsPath is where the Swagger UI is served.
+	DocsPath = "/docs"
+)
+
+// Spec is the OpenAPI 3.1 document describing every route registered by handler.Initialize.
+//
+//go:embed openapi.json
+var Spec []byte
+
+// NewOpenAPIHandler ..
+func NewOpenAPIHandler() *OpenAPIHandler {
+	return &OpenAPIHandler{
+		Docs: v5emb.New("Shopping Cart Service", SpecPath, DocsPath+"/"),
+	}
+}
+
+// OpenAPIHandler ..
+type OpenAPIHandler struct {
+	Docs http.Handler
+}
+
+// GetSpec ..
+func (o *OpenAPIHandler) GetSpec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(Spec)
+}
+
+// GetDocs serves the embedded Swagger UI and its assets.
+func (o *OpenAPIHandler) GetDocs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	o.Docs.ServeHTTP(w, r)
+}
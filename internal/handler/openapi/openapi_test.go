@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/audit"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/event"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/webhook"
+)
+
+type document struct {
+	OpenAPI    string `json:"openapi"`
+	Components struct {
+		Schemas map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func Test_Spec_ShouldBeOpenAPI31(t *testing.T) {
+	var doc document
+	if err := json.Unmarshal(Spec, &doc); err != nil {
+		t.Fatalf("spec is not valid JSON: %v", err)
+	}
+
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("expected openapi 3.1.0, got %s", doc.OpenAPI)
+	}
+}
+
+func Test_Spec_Schemas_ShouldMatchModelJSONTags(t *testing.T) {
+	var doc document
+	if err := json.Unmarshal(Spec, &doc); err != nil {
+		t.Fatalf("spec is not valid JSON: %v", err)
+	}
+
+	models := map[string]interface{}{
+		"Item":              item.Item{},
+		"ItemDTO":           item.ItemDTO{},
+		"PriceChange":       item.PriceChange{},
+		"ScheduledPrice":    item.ScheduledPrice{},
+		"ScheduledPriceDTO": item.ScheduledPriceDTO{},
+		"Event":             event.Event{},
+		"AuditEntry":        audit.Entry{},
+		"Subscription":      webhook.Subscription{},
+		"SubscriptionDTO":   webhook.SubscriptionDTO{},
+		"Delivery":          webhook.Delivery{},
+	}
+
+	for name, model := range models {
+		schema, ok := doc.Components.Schemas[name]
+		if !ok {
+			t.Errorf("schema %s is missing from the spec", name)
+			continue
+		}
+
+		var properties []string
+		for property := range schema.Properties {
+			properties = append(properties, property)
+		}
+		sort.Strings(properties)
+
+		fields := jsonFields(reflect.TypeOf(model))
+		if !reflect.DeepEqual(properties, fields) {
+			t.Errorf("schema %s has properties %v, but the model serializes %v", name, properties, fields)
+		}
+	}
+}
+
+func Test_OpenAPIHandler_GetSpec_ShouldServeJSON(t *testing.T) {
+	sut := NewOpenAPIHandler()
+
+	recorder := httptest.NewRecorder()
+	sut.GetSpec(recorder, httptest.NewRequest("GET", SpecPath, nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d", http.StatusOK, recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("unexpected content type %s", contentType)
+	}
+}
+
+func Test_OpenAPIHandler_GetDocs_ShouldServeSwaggerUI(t *testing.T) {
+	sut := NewOpenAPIHandler()
+
+	recorder := httptest.NewRecorder()
+	sut.GetDocs(recorder, httptest.NewRequest("GET", DocsPath+"/", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d", http.StatusOK, recorder.Code)
+	}
+	if !strings.Contains(recorder.Body.String(), SpecPath) {
+		t.Errorf("expected the docs page to load %s", SpecPath)
+	}
+}
+
+func jsonFields(modelType reflect.Type) []string {
+	var fields []string
+	for i := 0; i < modelType.NumField(); i++ {
+		name := strings.Split(modelType.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -3,79 +3,510 @@ package api
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"google.golang.org/grpc"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/db/migrations"
 	driver "github.com/tjmaynes/shopping-cart-service-go/internal/driver"
 	"github.com/tjmaynes/shopping-cart-service-go/internal/handler"
+	graphqlHandlers "github.com/tjmaynes/shopping-cart-service-go/internal/handler/graphql"
+	grpcHandlers "github.com/tjmaynes/shopping-cart-service-go/internal/handler/grpc"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/handler/grpc/itempb"
 	handlers "github.com/tjmaynes/shopping-cart-service-go/internal/handler/http"
+	middlewares "github.com/tjmaynes/shopping-cart-service-go/internal/handler/middleware"
+	openapiHandlers "github.com/tjmaynes/shopping-cart-service-go/internal/handler/openapi"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/apikey"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/audit"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/cache"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/config"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/event"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/health"
 	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/logging"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/metrics"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/migrate"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/outbox"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/ratelimit"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/tracing"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/webhook"
+)
+
+const (
+	priceSchedulerInterval    = 15 * time.Second
+	webhookDispatcherInterval = 5 * time.Second
+	outboxRelayInterval       = time.Second
+	eventPrunerInterval       = time.Hour
+	eventRetention            = 7 * 24 * time.Hour
+	healthCheckTimeout        = 2 * time.Second
 )
 
 // API ..
 type API struct {
-	DbConn  *sql.DB
-	Handler http.Handler
+	DbConn            *sql.DB
+	ItemRepository    item.Repository
+	ItemCache         *cache.ItemRepository
+	Handler           http.Handler
+	InFlight          *middlewares.InFlight
+	Config            config.Config
+	EventBroker       *event.Broker
+	EventHandler      *handlers.EventHandler
+	Health            *health.Health
+	ShutdownTracing   func(context.Context) error
+	AdminHandler      http.Handler
+	GRPCServer        *grpc.Server
+	PriceScheduler    *item.PriceScheduler
+	ItemEventListener *event.Listener
+	ItemEventPruner   *event.Pruner
+	WebhookDispatcher *webhook.Dispatcher
+	OutboxRelay       *outbox.Relay
 }
 
-// NewAPI ..
-func NewAPI(dbSource string) *API {
-	dbConn, err := driver.ConnectDB(dbSource)
+// NewAPI sets the service up as configured by cfg, connecting to its database.
+func NewAPI(cfg config.Config) (_ *API, err error) {
+	logger, err := logging.New(os.Stderr, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure logging: %w", err)
+	}
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing.Exporter)
 	if err != nil {
-		log.Println(err)
-		os.Exit(-1)
+		return nil, fmt.Errorf("unable to configure tracing: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			shutdownTracing(context.Background())
+		}
+	}()
+
+	// Items may be stored in memory or SQLite instead of Postgres, in which case only the features
+	// built on items alone are available: audit, events, webhooks and API keys are left out.
+	var dbConn *sql.DB
+	var repository item.Repository
+	scheme := driver.SchemeOf(cfg.Database.URL)
+	switch scheme {
+	case driver.SchemeMemory:
+		slog.Warn("DATABASE_URL is memory:, items will be lost when the service stops")
+		repository = item.NewMemoryRepository()
+	case driver.SchemeSQLite:
+		dbConn, err = driver.ConnectSQLite(cfg.Database.URL)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open database: %w", err)
+		}
+		repository = item.NewSQLiteRepository(dbConn)
+	default:
+		dbConn, err = driver.ConnectDB(cfg.Database)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to database: %w", err)
+		}
+		repository = item.NewRepository(dbConn)
+	}
+	postgres := scheme == driver.SchemePostgres
+	if !postgres {
+		slog.Warn("DATABASE_URL is not Postgres, audit, events, webhooks and API keys are disabled", "scheme", scheme)
+	}
+	defer func() {
+		if err != nil && dbConn != nil {
+			dbConn.Close()
+		}
+	}()
+
+	if dbConn != nil {
+		if err := metrics.RegisterDB(dbConn, "shopping-cart"); err != nil {
+			return nil, fmt.Errorf("unable to export database metrics: %w", err)
+		}
+	}
+
+	if cfg.Database.AutoMigrate && postgres {
+		if err := autoMigrate(dbConn); err != nil {
+			return nil, fmt.Errorf("unable to migrate database: %w", err)
+		}
+	}
+
+	var apiKeyService apikey.Service
+	var apiKeyHandler *handlers.APIKeyHandler
+	if postgres {
+		apiKeyService = apikey.NewService(apikey.NewRepository(dbConn))
+		apiKeyHandler = handlers.NewAPIKeyHandler(apiKeyService)
+	}
+
+	var schemes []auth.Scheme
+	var policy *auth.Policy
+	var grpcOptions []grpc.ServerOption
+	if !cfg.Auth.Disabled {
+		if cfg.Auth.JWKSSource != "" {
+			schemes = append(schemes, auth.BearerScheme(auth.NewJWTAuthenticator(auth.NewKeySet(cfg.Auth.JWKSSource, auth.DefaultKeySetTTL), cfg.Auth.JWTIssuer, cfg.Auth.JWTAudience)))
+		}
+		if apiKeyService != nil {
+			schemes = append(schemes, auth.APIKeyScheme(apiKeyService))
+		}
+		if len(schemes) == 0 {
+			return nil, errors.New("unable to authenticate requests without JWKS_SOURCE or Postgres for API keys, unless AUTH_DISABLED is set")
+		}
+		grpcOptions = append(grpcOptions,
+			grpc.UnaryInterceptor(grpcHandlers.UnaryAuthInterceptor(schemes...)),
+			grpc.StreamInterceptor(grpcHandlers.StreamAuthInterceptor(schemes...)),
+		)
+
+		policy, err = auth.LoadPolicy(cfg.Auth.PolicyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load policy: %w", err)
+		}
+	} else {
+		slog.Warn("AUTH_DISABLED is set, requests will not be authenticated or authorized")
 	}
 
-	cartRepository := item.NewRepository(dbConn)
-	cartService := item.NewService(cartRepository)
+	eventBroker := event.NewBroker()
+
+	// Cache hits skip the query, so they aren't observed as one.
+	cartRepository := metrics.NewItemRepository(repository)
+	var itemCache *cache.ItemRepository
+	if cfg.Cache.Size > 0 {
+		itemCache = cache.NewItemRepository(cartRepository, eventBroker, cfg.Cache.Size, cfg.Cache.TTL)
+		cartRepository = itemCache
+	}
+	cartRepository = tracing.NewItemRepository(cartRepository)
+
+	// Postgres records audit entries in the same transaction as each item mutation.
+	var auditHandler *handlers.AuditHandler
+	if postgres {
+		auditHandler = handlers.NewAuditHandler(audit.NewService(audit.NewRepository(dbConn)))
+	}
+
+	itemService := tracing.NewItemService(metrics.NewItemService(item.NewService(cartRepository)))
+	cartService := itemService
+	if policy != nil {
+		cartService = auth.NewItemService(itemService, policy)
+	}
 	cartHandler := handlers.NewItemHandler(cartService)
 
-	healthCheckHandler := handlers.NewHealthCheckHandler(dbConn)
+	var eventHandler *handlers.EventHandler
+	var webhookHandler *handlers.WebhookHandler
+	var itemEventListener *event.Listener
+	var itemEventPruner *event.Pruner
+	var webhookDispatcher *webhook.Dispatcher
+	var outboxRelay *outbox.Relay
+	if postgres {
+		eventRepository := event.NewRepository(dbConn)
+		eventHandler = handlers.NewEventHandler(eventRepository, eventBroker)
+		itemEventListener = event.NewListener(cfg.Database.URL, eventRepository, eventBroker)
+		itemEventPruner = event.NewPruner(eventRepository, eventRetention, eventPrunerInterval)
+
+		webhookRepository := webhook.NewRepository(dbConn)
+		webhookService := webhook.NewService(webhookRepository)
+		webhookHandler = handlers.NewWebhookHandler(webhookService)
+		webhookDispatcher = webhook.NewDispatcher(webhookRepository, webhookDispatcherInterval)
+
+		// Item changes are relayed from the outbox once, by whichever replica drains it: recorded as
+		// item events, whose notifications reach every replica's event streams and item cache, and
+		// enqueued as webhook deliveries.
+		outboxRelay = outbox.NewRelay(metrics.NewOutboxRepository(outbox.NewRepository(dbConn)), outbox.Publishers{
+			event.NewOutboxPublisher(eventRepository),
+			webhook.NewOutboxPublisher(webhookService),
+		}, outboxRelayInterval)
+	}
+
+	graphqlHandler, err := graphqlHandlers.NewGraphQLHandler(cartService)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create GraphQL handler: %w", err)
+	}
+
+	checks := health.New(healthCheckTimeout)
+	if dbConn != nil {
+		checks.Register("database", health.DatabaseCheck(dbConn))
+	}
+	if postgres {
+		migrationVersions, err := migrations.Versions()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read migrations: %w", err)
+		}
+		checks.Register("migrations", health.MigrationsCheck(dbConn, migrationVersions))
+	}
+	healthCheckHandler := handlers.NewHealthCheckHandler(checks)
+
+	openapiValidator, err := middlewares.NewOpenAPIValidator(openapiHandlers.Spec, cfg.Server.ValidateResponses)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create OpenAPI validator: %w", err)
+	}
+
+	limits, err := ratelimit.LoadLimits(cfg.RateLimit.LimitsFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load rate limits: %w", err)
+	}
+
+	var rateLimiter *ratelimit.Limiter
+	switch store := strings.ToLower(cfg.RateLimit.Store); store {
+	case "", "memory":
+		rateLimiter = ratelimit.NewLimiter(ratelimit.NewMemoryStore(), limits)
+	case "postgres":
+		if !postgres {
+			return nil, fmt.Errorf("rate limit store postgres needs a Postgres DATABASE_URL, not %s", scheme)
+		}
+		rateLimiter = ratelimit.NewLimiter(ratelimit.NewPostgresStore(dbConn), limits)
+	case "disabled":
+		slog.Warn("RATE_LIMIT_STORE is disabled, requests will not be rate limited")
+	default:
+		return nil, fmt.Errorf("rate limit store must be memory, postgres or disabled, not %q", store)
+	}
+
+	inFlight := &middlewares.InFlight{}
+
+	grpcServer := grpc.NewServer(grpcOptions...)
+	itempb.RegisterItemServiceServer(grpcServer, grpcHandlers.NewItemServer(cartService))
 
 	return &API{
-		DbConn:  dbConn,
-		Handler: handler.Initialize(cartHandler, healthCheckHandler),
+		DbConn:            dbConn,
+		ItemRepository:    repository,
+		ItemCache:         itemCache,
+		InFlight:          inFlight,
+		Config:            cfg,
+		EventBroker:       eventBroker,
+		EventHandler:      eventHandler,
+		Handler:           inFlight.Track(handler.Initialize(cartHandler, auditHandler, eventHandler, webhookHandler, apiKeyHandler, graphqlHandler, openapiHandlers.NewOpenAPIHandler(), healthCheckHandler, openapiValidator, middlewares.NewAuthentication(schemes...), middlewares.NewRateLimiter(rateLimiter), middlewares.NewAuthorizer(policy))),
+		AdminHandler:      handler.InitializeAdmin(metrics.Handler()),
+		Health:            checks,
+		ShutdownTracing:   shutdownTracing,
+		GRPCServer:        grpcServer,
+		PriceScheduler:    item.NewPriceScheduler(itemService, priceSchedulerInterval),
+		ItemEventListener: itemEventListener,
+		ItemEventPruner:   itemEventPruner,
+		WebhookDispatcher: webhookDispatcher,
+		OutboxRelay:       outboxRelay,
+	}, nil
+}
+
+// Run serves until the process receives SIGINT or SIGTERM, then shuts down gracefully.
+func (a *API) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return a.Serve(ctx)
+}
+
+// Serve runs the background workers and serves HTTP on the configured port, gRPC on the gRPC port
+// when it's set, and the admin endpoints such as /metrics on the admin port when it's set, until
+// ctx is done or a server fails. It then shuts everything down gracefully.
+func (a *API) Serve(ctx context.Context) error {
+	serverPort, grpcPort, adminPort := a.Config.Server.Port, a.Config.Server.GRPCPort, a.Config.Server.AdminPort
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", serverPort))
+	if err != nil {
+		return fmt.Errorf("unable to listen on port %s: %w", serverPort, err)
+	}
+
+	var grpcListener net.Listener
+	if grpcPort != "" {
+		grpcListener, err = net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+		if err != nil {
+			listener.Close()
+			return fmt.Errorf("unable to listen on gRPC port %s: %w", grpcPort, err)
+		}
+	}
+
+	var adminListener net.Listener
+	if adminPort != "" {
+		adminListener, err = net.Listen("tcp", fmt.Sprintf(":%s", adminPort))
+		if err != nil {
+			listener.Close()
+			if grpcListener != nil {
+				grpcListener.Close()
+			}
+			return fmt.Errorf("unable to listen on admin port %s: %w", adminPort, err)
+		}
+	}
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	for _, run := range a.workers() {
+		workers.Add(1)
+		go func(run func(context.Context)) {
+			defer workers.Done()
+			run(workersCtx)
+		}(run)
+	}
+
+	serveErrors := make(chan error, 3)
+
+	server := newHTTPServer(a.Handler, a.Config.Server)
+	// Event streams only end when their client goes away, so they're closed for the server to drain.
+	if a.EventHandler != nil {
+		server.RegisterOnShutdown(a.EventHandler.Close)
+	}
+	slog.Info("running server", "port", serverPort)
+	go func() {
+		if err := server.Serve(listener); err != http.ErrServerClosed {
+			serveErrors <- fmt.Errorf("HTTP server: %w", err)
+		}
+	}()
+
+	if grpcListener != nil {
+		slog.Info("running gRPC server", "port", grpcPort)
+		go func() {
+			if err := a.GRPCServer.Serve(grpcListener); err != nil {
+				serveErrors <- fmt.Errorf("gRPC server: %w", err)
+			}
+		}()
+	}
+
+	var adminServer *http.Server
+	if adminListener != nil {
+		adminServer = newHTTPServer(a.AdminHandler, a.Config.Server)
+		slog.Info("running admin server", "port", adminPort)
+		go func() {
+			if err := adminServer.Serve(adminListener); err != http.ErrServerClosed {
+				serveErrors <- fmt.Errorf("admin server: %w", err)
+			}
+		}()
 	}
+
+	select {
+	case <-ctx.Done():
+	case err = <-serveErrors:
+		slog.Error("server failed", "error", err)
+	}
+
+	a.shutdown(server, adminServer, stopWorkers, &workers)
+
+	return err
 }
 
-// Run ..
-func (a *API) Run(serverPort string) {
-	server := &http.Server{
-		Addr:           fmt.Sprintf(":%s", serverPort),
-		Handler:        a.Handler,
-		ReadTimeout:    10 * time.Second,
-		WriteTimeout:   10 * time.Second,
-		MaxHeaderBytes: 1 << 20,
+// shutdown fails readiness and waits the shutdown delay for load balancers to stop sending
+// requests, then gives the servers, the background workers, the tracer provider and the database
+// pool, stopped in that order, the shutdown timeout to finish. Whatever is still running then is
+// cut off. The event broker is only closed once the workers reading from it have stopped, so that
+// changes made while draining still reach them.
+func (a *API) shutdown(server *http.Server, adminServer *http.Server, stopWorkers context.CancelFunc, workers *sync.WaitGroup) {
+	a.Health.Shutdown()
+	slog.Info("shutting down", "delay", a.Config.Shutdown.Delay, "timeout", a.Config.Shutdown.Timeout, "in_flight", a.InFlight.Count())
+	time.Sleep(a.Config.Shutdown.Delay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.Config.Shutdown.Timeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		slog.Error("HTTP server shutdown", "in_flight", a.InFlight.Count(), "error", err)
+		server.Close()
 	}
 
-	log.Println(fmt.Sprintf("Running server on port %s...", serverPort))
+	stopGRPCServer(ctx, a.GRPCServer)
 
-	idleConnsClosed := make(chan struct{})
-	go setupGracefulShutdown(server, a.DbConn, idleConnsClosed)
+	stopWorkers()
+	if err := wait(ctx, workers); err != nil {
+		slog.Error("background workers shutdown", "error", err)
+	}
+	a.EventBroker.Close()
 
-	if err := server.ListenAndServe(); err != http.ErrServerClosed {
-		log.Printf("Server closed: %v", err)
+	// The admin server stays up until the rest has stopped so that metrics cover the drain.
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			slog.Error("admin server shutdown", "error", err)
+			adminServer.Close()
+		}
 	}
 
-	<-idleConnsClosed
+	if err := a.ShutdownTracing(ctx); err != nil {
+		slog.Error("tracer provider shutdown", "error", err)
+	}
+
+	if a.DbConn != nil {
+		if err := a.DbConn.Close(); err != nil {
+			slog.Error("database shutdown", "error", err)
+		}
+	}
+
+	slog.Info("shut down")
 }
 
-func setupGracefulShutdown(server *http.Server, db *sql.DB, idleConnsClosed chan struct{}) {
-	sigint := make(chan os.Signal, 1)
-	signal.Notify(sigint, os.Interrupt)
-	signal.Notify(sigint, syscall.SIGTERM)
-	<-sigint
+// workers returns the background workers to run, leaving out those that need Postgres when items
+// are stored elsewhere and the item cache's when it's disabled.
+func (a *API) workers() []func(context.Context) {
+	workers := []func(context.Context){a.PriceScheduler.Run}
+	if a.ItemCache != nil {
+		workers = append(workers, a.ItemCache.Run)
+	}
+	if a.ItemEventListener != nil {
+		workers = append(workers, a.ItemEventListener.Run)
+	}
+	if a.ItemEventPruner != nil {
+		workers = append(workers, a.ItemEventPruner.Run)
+	}
+	if a.WebhookDispatcher != nil {
+		workers = append(workers, a.WebhookDispatcher.Run)
+	}
+	if a.OutboxRelay != nil {
+		workers = append(workers, a.OutboxRelay.Run)
+	}
+	return workers
+}
 
-	if err := server.Shutdown(context.Background()); err != nil {
-		log.Printf("HTTP server Shutdown: %v", err)
+// autoMigrate applies the embedded migrations not yet applied to dbConn, waiting its turn while
+// another replica does the same.
+func autoMigrate(dbConn *sql.DB) error {
+	all, err := migrate.Load(migrations.FS)
+	if err != nil {
+		return err
 	}
 
-	defer db.Close()
+	applied, err := migrate.NewMigrator(dbConn, all).Up(context.Background())
+	for _, migration := range applied {
+		slog.Info("applied migration", "migration", migration.Name)
+	}
 
-	close(idleConnsClosed)
+	return err
+}
+
+func newHTTPServer(handler http.Handler, cfg config.Server) *http.Server {
+	return &http.Server{
+		Handler:        handler,
+		ReadTimeout:    cfg.ReadTimeout,
+		WriteTimeout:   cfg.WriteTimeout,
+		IdleTimeout:    cfg.IdleTimeout,
+		MaxHeaderBytes: 1 << 20,
+		ErrorLog:       slog.NewLogLogger(slog.Default().Handler(), slog.LevelError),
+	}
+}
+
+// stopGRPCServer lets in-flight RPCs finish until ctx is done, then cancels them.
+func stopGRPCServer(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		slog.Error("gRPC server shutdown", "error", ctx.Err())
+		server.Stop()
+		<-stopped
+	}
+}
+
+// wait waits for group until ctx is done.
+func wait(ctx context.Context, group *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		group.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
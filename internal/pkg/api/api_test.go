@@ -13,24 +13,29 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/icrowley/fake"
 	driver "github.com/tjmaynes/shopping-cart-service-go/internal/driver"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/audit"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/config"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/health"
 	cart "github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
 )
 
 var (
-	dbConnectionString = flag.String("DATABASE_URL", os.Getenv("DATABASE_URL"), "Database source such as ./db/my.db.")
-	seedDataSource     = flag.String("SEED_DATA_SOURCE", os.Getenv("SEED_DATA_SOURCE"), "Seed data source, such as ./db/seed.json.")
+	dbConnectionString = flag.String("DATABASE_URL", os.Getenv("DATABASE_URL"), "Database source such as ./db/my.db, or memory: when unset.")
+	seedDataSource     = flag.String("SEED_DATA_SOURCE", os.Getenv("SEED_DATA_SOURCE"), "Seed data source, such as ./db/seed.json, or the repository's when unset.")
 	dbConn             = getDbConn()
 )
 
-func Test_HealthCheckEndpoint_WhenDatabaseConnectionIsAlive_ReturnsPong(t *testing.T) {
+func Test_HealthCheckEndpoint_WhenDatabaseConnectionIsAlive_ReturnsUp(t *testing.T) {
 	flag.Parse()
 
-	a := NewAPI(*dbConnectionString)
+	a := newAPI(t)
 
 	request, err := http.NewRequest("GET", "/health", nil)
 	if err != nil {
@@ -44,8 +49,9 @@ func Test_HealthCheckEndpoint_WhenDatabaseConnectionIsAlive_ReturnsPong(t *testi
 		t.Errorf("Expected response code %d. Got %d\n", http.StatusOK, recorder.Code)
 	}
 
-	if body := recorder.Body.String(); body != `{"message":"PONG!"}` {
-		t.Errorf("Expected a PONG! message. Got %s", body)
+	var report health.Report
+	if err := json.Unmarshal(recorder.Body.Bytes(), &report); err != nil || !report.Up() {
+		t.Errorf("Expected every check to be up. Got %s", recorder.Body.String())
 	}
 }
 
@@ -61,7 +67,7 @@ func Test_ItemsEndpoint_WhenUnsupportedMethodIsGiven_Returns405(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		a := NewAPI(*dbConnectionString)
+		a := newAPI(t)
 
 		request, err := http.NewRequest(tt.httpMethod, tt.endpoint, nil)
 		if err != nil {
@@ -80,10 +86,10 @@ func Test_ItemsEndpoint_WhenUnsupportedMethodIsGiven_Returns405(t *testing.T) {
 func Test_ItemsEndpoint_GetItems_WhenItemsExist_ShouldReturnAllItems(t *testing.T) {
 	flag.Parse()
 
-	a := NewAPI(*dbConnectionString)
+	a := newAPI(t)
 
 	ctx := context.Background()
-	cartRepository := cart.NewRepository(dbConn)
+	cartRepository := a.ItemRepository
 
 	setupDatabase(ctx, cartRepository)
 
@@ -117,10 +123,10 @@ func Test_ItemsEndpoint_GetItems_WhenItemsExist_ShouldReturnAllItems(t *testing.
 func Test_ItemsEndpoint_GetItemByID_WhenItemExists_ShouldReturnItem(t *testing.T) {
 	flag.Parse()
 
-	a := NewAPI(*dbConnectionString)
+	a := newAPI(t)
 
 	ctx := context.Background()
-	cartRepository := cart.NewRepository(dbConn)
+	cartRepository := a.ItemRepository
 	items := setupDatabase(ctx, cartRepository)
 
 	item1 := items[0]
@@ -151,7 +157,7 @@ func Test_ItemsEndpoint_GetItemByID_WhenItemExists_ShouldReturnItem(t *testing.T
 func Test_ItemsEndpoint_GetItemByID_WhenItemDoesNotExist_ShouldReturn404(t *testing.T) {
 	flag.Parse()
 
-	a := NewAPI(*dbConnectionString)
+	a := newAPI(t)
 
 	requestURL := fmt.Sprintf("/items/%s", uuid.New())
 
@@ -172,10 +178,10 @@ func Test_ItemsEndpoint_GetItemByID_WhenItemDoesNotExist_ShouldReturn404(t *test
 func Test_ItemsEndpoint_AddItem_WhenGivenValidItem_ShouldReturnItem(t *testing.T) {
 	flag.Parse()
 
-	a := NewAPI(*dbConnectionString)
+	a := newAPI(t)
 
 	ctx := context.Background()
-	cartRepository := cart.NewRepository(dbConn)
+	cartRepository := a.ItemRepository
 
 	setupDatabase(ctx, cartRepository)
 
@@ -222,7 +228,7 @@ func Test_ItemsEndpoint_AddItem_WhenGivenValidItem_ShouldReturnItem(t *testing.T
 func Test_ItemsEndpoint_AddItem_WhenGivenInvalidItem_ShouldReturnBadRequest(t *testing.T) {
 	flag.Parse()
 
-	a := NewAPI(*dbConnectionString)
+	a := newAPI(t)
 
 	form := url.Values{}
 	form.Add("name", "")
@@ -246,10 +252,10 @@ func Test_ItemsEndpoint_AddItem_WhenGivenInvalidItem_ShouldReturnBadRequest(t *t
 func Test_ItemsEndpoint_UpdateItem_WhenGivenValidItemAndItemExists_ShouldReturnUpdatedItem(t *testing.T) {
 	flag.Parse()
 
-	a := NewAPI(*dbConnectionString)
+	a := newAPI(t)
 
 	ctx := context.Background()
-	cartRepository := cart.NewRepository(dbConn)
+	cartRepository := a.ItemRepository
 	items := setupDatabase(ctx, cartRepository)
 
 	newItem := items[0]
@@ -293,7 +299,7 @@ func Test_ItemsEndpoint_UpdateItem_WhenGivenValidItemAndItemExists_ShouldReturnU
 func Test_ItemsEndpoint_UpdateItem_WhenGivenValidItemAndItemDoesNotExist_ShouldReturnUpdatedItem(t *testing.T) {
 	flag.Parse()
 
-	a := NewAPI(*dbConnectionString)
+	a := newAPI(t)
 
 	unknownItem := cart.Item{
 		ID:           uuid.New(),
@@ -326,7 +332,7 @@ func Test_ItemsEndpoint_UpdateItem_WhenGivenValidItemAndItemDoesNotExist_ShouldR
 func Test_ItemsEndpoint_UpdateItem_WhenGivenInvalidItem_ShouldReturnBadRequest(t *testing.T) {
 	flag.Parse()
 
-	a := NewAPI(*dbConnectionString)
+	a := newAPI(t)
 
 	jsonRequest, _ := json.Marshal(map[string]string{
 		"name":         "",
@@ -352,10 +358,10 @@ func Test_ItemsEndpoint_UpdateItem_WhenGivenInvalidItem_ShouldReturnBadRequest(t
 func Test_ItemsEndpoint_RemoveItem_WhenItemExists_ShouldReturnOkResponse(t *testing.T) {
 	flag.Parse()
 
-	a := NewAPI(*dbConnectionString)
+	a := newAPI(t)
 
 	ctx := context.Background()
-	cartRepository := cart.NewRepository(dbConn)
+	cartRepository := a.ItemRepository
 	items := setupDatabase(ctx, cartRepository)
 
 	newItem := items[1]
@@ -377,6 +383,125 @@ func Test_ItemsEndpoint_RemoveItem_WhenItemExists_ShouldReturnOkResponse(t *test
 	teardownDatabase(ctx)
 }
 
+func Test_ItemsEndpoint_GetItemPrices_WhenItemExists_ShouldReturnPriceHistory(t *testing.T) {
+	flag.Parse()
+
+	a := newAPI(t)
+
+	ctx := context.Background()
+	cartRepository := a.ItemRepository
+	items := setupDatabase(ctx, cartRepository)
+
+	item1 := items[0]
+	requestURL := fmt.Sprintf("/items/%s/prices", item1.ID.String())
+
+	request, err := http.NewRequest("GET", requestURL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := httptest.NewRecorder()
+	a.Handler.ServeHTTP(recorder, request)
+
+	if http.StatusOK != recorder.Code {
+		t.Errorf("Expected response code %d. Got %d\n", http.StatusOK, recorder.Code)
+	}
+
+	var result struct {
+		Data []cart.PriceChange `json:"data"`
+	}
+	err = json.Unmarshal([]byte(recorder.Body.String()), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Data) != 1 || result.Data[0].Price != item1.Price {
+		t.Errorf("Expected a single price change of %d. Got %+v", item1.Price, result.Data)
+	}
+
+	teardownDatabase(ctx)
+}
+
+func Test_ItemsEndpoint_GetItemPrices_WhenItemDoesNotExist_ShouldReturnNotFound(t *testing.T) {
+	flag.Parse()
+
+	a := newAPI(t)
+
+	for _, requestURL := range []string{
+		fmt.Sprintf("/items/%s/prices", uuid.NewString()),
+		fmt.Sprintf("/v1/items/%s/prices/scheduled", uuid.NewString()),
+	} {
+		request, err := http.NewRequest("GET", requestURL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		recorder := httptest.NewRecorder()
+		a.Handler.ServeHTTP(recorder, request)
+
+		if http.StatusNotFound != recorder.Code {
+			t.Errorf("Expected response code %d for %s. Got %d\n", http.StatusNotFound, requestURL, recorder.Code)
+		}
+	}
+}
+
+func Test_ItemsEndpoint_GetItemHistory_WhenItemWasCreated_ShouldReturnCreateEntry(t *testing.T) {
+	flag.Parse()
+
+	if driver.SchemeOf(databaseURL()) != driver.SchemePostgres {
+		t.Skip("the audit log needs Postgres")
+	}
+
+	a := newAPI(t)
+
+	form := url.Values{}
+	form.Add("name", fake.ProductName())
+	form.Add("price", "150")
+	form.Add("manufacturer", fake.Brand())
+
+	request, err := http.NewRequest("POST", "/items", strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("X-Actor", "merchandiser@example.com")
+
+	recorder := httptest.NewRecorder()
+	a.Handler.ServeHTTP(recorder, request)
+
+	var created struct {
+		Data cart.Item `json:"data"`
+	}
+	err = json.Unmarshal([]byte(recorder.Body.String()), &created)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err = http.NewRequest("GET", fmt.Sprintf("/items/%s/history", created.Data.ID), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder = httptest.NewRecorder()
+	a.Handler.ServeHTTP(recorder, request)
+
+	if http.StatusOK != recorder.Code {
+		t.Errorf("Expected response code %d. Got %d\n", http.StatusOK, recorder.Code)
+	}
+
+	var result struct {
+		Data []audit.Entry `json:"data"`
+	}
+	err = json.Unmarshal([]byte(recorder.Body.String()), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Data) != 1 || result.Data[0].Operation != audit.Create || result.Data[0].Actor != "merchandiser@example.com" {
+		t.Errorf("Expected a single create entry. Got %+v", result.Data)
+	}
+}
+
 func createResponseBody(items interface{}) string {
 	out, err := json.Marshal(items)
 	if err != nil {
@@ -385,8 +510,158 @@ func createResponseBody(items interface{}) string {
 	return fmt.Sprintf(`{"data":%s}`, out)
 }
 
+func Test_NewAPI_WhenDatabaseIsUnreachable_ShouldReturnError(t *testing.T) {
+	cfg := config.Default()
+	cfg.Auth.Disabled = true
+	cfg.Database.URL = "postgres://postgres@127.0.0.1:1/shopping-cart?sslmode=disable&connect_timeout=1"
+	cfg.Database.ConnectTimeout = time.Second
+
+	a, err := NewAPI(cfg)
+
+	if err == nil || a != nil {
+		t.Errorf("Expected an error. Got %v", err)
+	}
+}
+
+func Test_NewAPI_WhenDatabaseURLIsSQLite_ShouldStoreItemsInIt(t *testing.T) {
+	cfg := config.Default()
+	cfg.Auth.Disabled = true
+	cfg.Database.URL = "sqlite://" + filepath.Join(t.TempDir(), "shopping-cart.db")
+
+	a, err := NewAPI(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.DbConn.Close()
+
+	form := url.Values{}
+	form.Add("name", fake.ProductName())
+	form.Add("price", "150")
+	form.Add("manufacturer", fake.Brand())
+
+	request, err := http.NewRequest("POST", "/v1/items", strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	recorder := httptest.NewRecorder()
+	a.Handler.ServeHTTP(recorder, request)
+
+	if http.StatusCreated != recorder.Code {
+		t.Fatalf("Expected response code %d. Got %d\n", http.StatusCreated, recorder.Code)
+	}
+
+	var count int
+	if err := a.DbConn.QueryRow("SELECT count(*) FROM item").Scan(&count); err != nil || count != 1 {
+		t.Errorf("Expected the item to be stored in SQLite. Got %d, %v", count, err)
+	}
+
+	for _, path := range []string{"/v1/audit", "/v1/webhooks"} {
+		recorder := httptest.NewRecorder()
+		a.Handler.ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+
+		if http.StatusNotFound != recorder.Code {
+			t.Errorf("Expected %s to be left out without Postgres. Got %d", path, recorder.Code)
+		}
+	}
+}
+
+func Test_NewAPI_WhenAuthIsNotDisabled_ShouldRejectUnauthenticatedRequests(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.URL = "memory:"
+	cfg.Auth.JWKSSource = filepath.Join(t.TempDir(), "jwks.json")
+
+	a, err := NewAPI(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, request := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/v1/items", nil),
+		httptest.NewRequest(http.MethodDelete, "/v1/items/"+uuid.NewString(), nil),
+	} {
+		recorder := httptest.NewRecorder()
+		a.Handler.ServeHTTP(recorder, request)
+
+		if http.StatusUnauthorized != recorder.Code {
+			t.Errorf("Expected %s %s to be rejected. Got %d", request.Method, request.URL.Path, recorder.Code)
+		}
+	}
+}
+
+func Test_NewAPI_WhenRateLimitStoreIsPostgresWithoutPostgres_ShouldReturnError(t *testing.T) {
+	cfg := config.Default()
+	cfg.Auth.Disabled = true
+	cfg.Database.URL = "memory:"
+	cfg.RateLimit.Store = "postgres"
+
+	a, err := NewAPI(cfg)
+
+	if err == nil || a != nil {
+		t.Errorf("Expected an error. Got %v", err)
+	}
+}
+
+func Test_Serve_WhenContextIsDone_ShouldShutDownAndCloseDatabase(t *testing.T) {
+	a := newAPI(t)
+	a.Config.Server.Port, a.Config.Server.GRPCPort, a.Config.Server.AdminPort = "0", "0", "0"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error)
+	go func() { served <- a.Serve(ctx) }()
+	cancel()
+
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Fatalf("Expected a clean shutdown. Got %v", err)
+		}
+	case <-time.After(a.Config.Shutdown.Timeout):
+		t.Fatalf("Expected Serve to return within the shutdown timeout")
+	}
+
+	if report := a.Health.Check(context.Background()); report.Up() {
+		t.Errorf("Expected readiness to fail once shut down")
+	}
+	if a.DbConn != nil {
+		if err := a.DbConn.Ping(); err == nil {
+			t.Errorf("Expected the database pool to be closed")
+		}
+	}
+}
+
+func newAPI(t *testing.T) *API {
+	cfg := config.Default()
+	cfg.Auth.Disabled = true
+	cfg.Database.URL = databaseURL()
+
+	a, err := NewAPI(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return a
+}
+
+// databaseURL returns DATABASE_URL, falling back to keeping items in memory so that the tests
+// that don't need Postgres run without it.
+func databaseURL() string {
+	if *dbConnectionString == "" {
+		return "memory:"
+	}
+	return *dbConnectionString
+}
+
 func getDbConn() *sql.DB {
-	dbConn, err := driver.ConnectDB(*dbConnectionString)
+	if driver.SchemeOf(databaseURL()) != driver.SchemePostgres {
+		return nil
+	}
+
+	cfg := config.Default().Database
+	cfg.URL = databaseURL()
+
+	dbConn, err := driver.ConnectDB(cfg)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(-1)
@@ -398,7 +673,12 @@ func getDbConn() *sql.DB {
 func setupDatabase(ctx context.Context, cartRepository cart.Repository) []cart.Item {
 	flag.Parse()
 
-	jsonFile, err := os.Open(*seedDataSource)
+	source := *seedDataSource
+	if source == "" {
+		source = "../../db/seed.json"
+	}
+
+	jsonFile, err := os.Open(source)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(-1)
@@ -428,6 +708,10 @@ func setupDatabase(ctx context.Context, cartRepository cart.Repository) []cart.I
 }
 
 func createDatabase(ctx context.Context) {
+	if dbConn == nil {
+		return
+	}
+
 	stmt, err := dbConn.PrepareContext(ctx, `
 CREATE TABLE cart (
   id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -450,6 +734,10 @@ CREATE TABLE cart (
 }
 
 func teardownDatabase(ctx context.Context) {
+	if dbConn == nil {
+		return
+	}
+
 	stmt, err := dbConn.PrepareContext(ctx, "DROP TABLE cart")
 	if err != nil {
 		return
@@ -0,0 +1,32 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// KeyPrefix starts every key, so leaked keys are easy to recognise.
+const KeyPrefix = "sck_"
+
+// displayLength is how much of a key is kept in the clear to tell keys apart.
+const displayLength = len(KeyPrefix) + 8
+
+// Generate returns a new random key along with its displayable prefix and the hash it is stored as.
+func Generate() (key string, prefix string, hash string, err error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+
+	key = KeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	return key, key[:displayLength], Hash(key), nil
+}
+
+// Hash is the SHA-256 of key. Keys are 256 bits of randomness, so a fast unsalted hash is enough
+// to keep them from being recovered from the database.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,62 @@
+package apikey
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/google/uuid"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
+)
+
+// APIKey describes a key without the key itself, which is only ever returned when it is created.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKey is a new APIKey along with the key, which the caller must store.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyDTO ..
+type APIKeyDTO struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Validate ..
+func (key APIKeyDTO) Validate() error {
+	return validation.ValidateStruct(&key,
+		// Name identifies the client the key was issued to
+		validation.Field(&key.Name, validation.Required, validation.Length(1, 255)),
+		// Scopes must name at least one permission
+		validation.Field(&key.Scopes, validation.Required, validation.Each(validation.By(isPermission))),
+		// ExpiresAt, when given, must be in the future
+		validation.Field(&key.ExpiresAt, validation.By(isInFuture)),
+	)
+}
+
+func isPermission(value interface{}) error {
+	scope, _ := value.(string)
+	if !auth.IsPermission(auth.Permission(scope)) {
+		return errors.New("must be a known permission")
+	}
+	return nil
+}
+
+func isInFuture(value interface{}) error {
+	expiresAt, _ := value.(*time.Time)
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return errors.New("must be in the future")
+	}
+	return nil
+}
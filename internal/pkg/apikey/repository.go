@@ -0,0 +1,96 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Repository ..
+type Repository interface {
+	GetAPIKeys(ctx context.Context, page int64, pageSize int64) ([]APIKey, error)
+	AddAPIKey(ctx context.Context, name string, prefix string, hash string, scopes []string, expiresAt *time.Time) (APIKey, error)
+	RemoveAPIKey(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	UseAPIKey(ctx context.Context, hash string, now time.Time) (APIKey, error)
+}
+
+// NewRepository ..
+func NewRepository(DBConn *sql.DB) Repository {
+	return &repository{DBConn: DBConn}
+}
+
+// repository ..
+type repository struct {
+	DBConn *sql.DB
+}
+
+// GetAPIKeys ..
+func (r *repository) GetAPIKeys(ctx context.Context, page int64, pageSize int64) ([]APIKey, error) {
+	limit := pageSize
+	offset := page * pageSize
+
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT id, name, prefix, scopes, expires_at, last_used_at, created_at FROM api_key ORDER BY created_at LIMIT $1 OFFSET $2", limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payload := make([]APIKey, 0)
+	for rows.Next() {
+		data := new(APIKey)
+		err := rows.Scan(&data.ID, &data.Name, &data.Prefix, (*pq.StringArray)(&data.Scopes), &data.ExpiresAt, &data.LastUsedAt, &data.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, *data)
+	}
+
+	return payload, nil
+}
+
+// AddAPIKey ..
+func (r *repository) AddAPIKey(ctx context.Context, name string, prefix string, hash string, scopes []string, expiresAt *time.Time) (APIKey, error) {
+	key := APIKey{Name: name, Prefix: prefix, Scopes: scopes, ExpiresAt: expiresAt}
+	insertStm := "INSERT INTO api_key (name, prefix, key_hash, scopes, expires_at) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at"
+	err := r.DBConn.QueryRowContext(ctx, insertStm, name, prefix, hash, pq.StringArray(scopes), expiresAt).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		return APIKey{}, err
+	}
+
+	return key, nil
+}
+
+// RemoveAPIKey returns sql.ErrNoRows when there is no such key.
+func (r *repository) RemoveAPIKey(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	result, err := r.DBConn.ExecContext(ctx, "DELETE FROM api_key WHERE id = $1", id)
+	if err != nil {
+		return id, err
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return id, err
+	}
+	if removed == 0 {
+		return id, sql.ErrNoRows
+	}
+
+	return id, nil
+}
+
+// UseAPIKey looks up the unexpired key with hash and records that it was used at now. It returns
+// sql.ErrNoRows when there is no such key.
+func (r *repository) UseAPIKey(ctx context.Context, hash string, now time.Time) (APIKey, error) {
+	var key APIKey
+	updateStm := "UPDATE api_key SET last_used_at = $2 WHERE key_hash = $1 AND (expires_at IS NULL OR expires_at > $2) RETURNING id, name, prefix, scopes, expires_at, last_used_at, created_at"
+	row := r.DBConn.QueryRowContext(ctx, updateStm, hash, now)
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, (*pq.StringArray)(&key.Scopes), &key.ExpiresAt, &key.LastUsedAt, &key.CreatedAt)
+	if err != nil {
+		return APIKey{}, err
+	}
+
+	return key, nil
+}
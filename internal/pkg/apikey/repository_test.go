@@ -0,0 +1,68 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+func Test_APIKeyRepository_UseAPIKey_ShouldOnlyMatchUnexpiredKeys(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	now := time.Now()
+	id := uuid.New()
+
+	mock.ExpectQuery("UPDATE api_key SET last_used_at = \\$2 WHERE key_hash = \\$1 AND \\(expires_at IS NULL OR expires_at > \\$2\\) RETURNING (.+)").
+		WithArgs("hash", now).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "prefix", "scopes", "expires_at", "last_used_at", "created_at"}).
+				AddRow(id, "erp", "sck_abcdefgh", "{items:read,items:update}", nil, now, now),
+		)
+
+	sut := NewRepository(dbConn)
+
+	result, err := sut.UseAPIKey(context.Background(), "hash", now)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when using an API key", err)
+	}
+
+	if result.ID != id || len(result.Scopes) != 2 || result.LastUsedAt == nil || result.ExpiresAt != nil {
+		t.Fatalf("Unexpected API key was given, '%+v'.", result)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_APIKeyRepository_RemoveAPIKey_WhenKeyDoesNotExist_ShouldReturnErrNoRows(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	id := uuid.New()
+	mock.ExpectExec("DELETE FROM api_key WHERE id = \\$1").
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	sut := NewRepository(dbConn)
+
+	if _, err := sut.RemoveAPIKey(context.Background(), id); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
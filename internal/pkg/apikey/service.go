@@ -0,0 +1,86 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
+)
+
+// Service manages API keys and, as an auth.Authenticator, authenticates requests presenting them.
+type Service interface {
+	GetAPIKeys(ctx context.Context, page int64, pageSize int64) ([]APIKey, error)
+	AddAPIKey(ctx context.Context, key *APIKeyDTO) (CreatedAPIKey, ServiceError)
+	RemoveAPIKey(ctx context.Context, id uuid.UUID) (uuid.UUID, ServiceError)
+	Authenticate(ctx context.Context, key string) (auth.Principal, error)
+}
+
+// NewService ..
+func NewService(repository Repository) Service {
+	return &service{
+		Repository: repository,
+	}
+}
+
+type service struct {
+	Repository Repository
+}
+
+// GetAPIKeys ..
+func (s *service) GetAPIKeys(ctx context.Context, page int64, pageSize int64) ([]APIKey, error) {
+	return s.Repository.GetAPIKeys(ctx, page, pageSize)
+}
+
+// AddAPIKey ..
+func (s *service) AddAPIKey(ctx context.Context, key *APIKeyDTO) (CreatedAPIKey, ServiceError) {
+	err := key.Validate()
+	if err != nil {
+		return CreatedAPIKey{}, CreateServiceError(err.Error(), InvalidAPIKey)
+	}
+
+	secret, prefix, hash, err := Generate()
+	if err != nil {
+		return CreatedAPIKey{}, CreateServiceError(err.Error(), UnknownException)
+	}
+
+	result, err := s.Repository.AddAPIKey(ctx, key.Name, prefix, hash, key.Scopes, key.ExpiresAt)
+	if err != nil {
+		return CreatedAPIKey{}, CreateServiceError(err.Error(), UnknownException)
+	}
+
+	return CreatedAPIKey{APIKey: result, Key: secret}, nil
+}
+
+// RemoveAPIKey ..
+func (s *service) RemoveAPIKey(ctx context.Context, id uuid.UUID) (uuid.UUID, ServiceError) {
+	result, err := s.Repository.RemoveAPIKey(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return id, CreateServiceError(err.Error(), APIKeyNotFound)
+	} else if err != nil {
+		return id, CreateServiceError(err.Error(), UnknownException)
+	}
+
+	return result, nil
+}
+
+// Authenticate authenticates an unexpired key as a principal holding the key's scopes. Errors
+// looking the key up are returned as they are, rather than rejecting the key.
+func (s *service) Authenticate(ctx context.Context, key string) (auth.Principal, error) {
+	if !strings.HasPrefix(key, KeyPrefix) {
+		return auth.Principal{}, fmt.Errorf("%w: malformed API key", auth.ErrUnauthenticated)
+	}
+
+	result, err := s.Repository.UseAPIKey(ctx, Hash(key), time.Now())
+	if errors.Is(err, sql.ErrNoRows) {
+		return auth.Principal{}, fmt.Errorf("%w: unknown, revoked or expired API key", auth.ErrUnauthenticated)
+	} else if err != nil {
+		return auth.Principal{}, err
+	}
+
+	return auth.Principal{Subject: "api-key:" + result.ID.String(), Scopes: result.Scopes}, nil
+}
@@ -0,0 +1,143 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/auth"
+)
+
+func Test_APIKeyService_AddAPIKey_WhenGivenInvalidKey_ShouldReturnServiceError(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name string
+		key  APIKeyDTO
+	}{
+		{"missing name", APIKeyDTO{Scopes: []string{"items:read"}}},
+		{"missing scopes", APIKeyDTO{Name: "erp"}},
+		{"unknown scope", APIKeyDTO{Name: "erp", Scopes: []string{"items:purchase"}}},
+		{"expired", APIKeyDTO{Name: "erp", Scopes: []string{"items:read"}, ExpiresAt: &past}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockRepository := &RepositoryMock{}
+			sut := NewService(mockRepository)
+
+			_, serviceError := sut.AddAPIKey(context.Background(), &test.key)
+			if serviceError == nil || serviceError.StatusCode() != InvalidAPIKey {
+				t.Fatalf("Expected an %s service error. Got %+v", InvalidAPIKey, serviceError)
+			}
+
+			if calls := len(mockRepository.AddAPIKeyCalls()); calls != 0 {
+				t.Errorf("AddAPIKey was called %d times", calls)
+			}
+		})
+	}
+}
+
+func Test_APIKeyService_AddAPIKey_WhenGivenValidKey_ShouldStoreOnlyItsHash(t *testing.T) {
+	mockRepository := &RepositoryMock{
+		AddAPIKeyFunc: func(ctx context.Context, name string, prefix string, hash string, scopes []string, expiresAt *time.Time) (APIKey, error) {
+			return APIKey{ID: uuid.New(), Name: name, Prefix: prefix, Scopes: scopes, ExpiresAt: expiresAt, CreatedAt: time.Now()}, nil
+		},
+	}
+	sut := NewService(mockRepository)
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	result, serviceError := sut.AddAPIKey(context.Background(), &APIKeyDTO{Name: "erp", Scopes: []string{"items:read", "items:update"}, ExpiresAt: &expiresAt})
+	if serviceError != nil {
+		t.Fatalf("Unexpected service error %s", serviceError.Message())
+	}
+
+	calls := mockRepository.AddAPIKeyCalls()
+	if len(calls) != 1 {
+		t.Fatalf("AddAPIKey was called %d times", len(calls))
+	}
+
+	if !strings.HasPrefix(result.Key, KeyPrefix) || !strings.HasPrefix(result.Key, result.Prefix) {
+		t.Errorf("Unexpected key %q with prefix %q", result.Key, result.Prefix)
+	}
+	if calls[0].Hash != Hash(result.Key) || strings.Contains(calls[0].Hash, result.Key) {
+		t.Errorf("Expected the key's hash to be stored, got %q", calls[0].Hash)
+	}
+}
+
+func Test_APIKeyService_RemoveAPIKey_WhenKeyDoesNotExist_ShouldReturnServiceError(t *testing.T) {
+	mockRepository := &RepositoryMock{
+		RemoveAPIKeyFunc: func(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+			return id, sql.ErrNoRows
+		},
+	}
+	sut := NewService(mockRepository)
+
+	_, serviceError := sut.RemoveAPIKey(context.Background(), uuid.New())
+	if serviceError == nil || serviceError.StatusCode() != APIKeyNotFound {
+		t.Fatalf("Expected an %s service error. Got %+v", APIKeyNotFound, serviceError)
+	}
+}
+
+func Test_APIKeyService_Authenticate_WhenKeyIsValid_ShouldReturnPrincipalWithScopes(t *testing.T) {
+	key, _, hash, err := Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := uuid.New()
+	mockRepository := &RepositoryMock{
+		UseAPIKeyFunc: func(ctx context.Context, keyHash string, now time.Time) (APIKey, error) {
+			if keyHash != hash {
+				return APIKey{}, sql.ErrNoRows
+			}
+			return APIKey{ID: id, Name: "erp", Scopes: []string{"items:read"}, LastUsedAt: &now}, nil
+		},
+	}
+	sut := NewService(mockRepository)
+
+	principal, err := sut.Authenticate(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if principal.Subject != "api-key:"+id.String() || !principal.HasScope("items:read") {
+		t.Errorf("Unexpected principal %+v", principal)
+	}
+}
+
+func Test_APIKeyService_Authenticate_WhenKeyIsUnknownOrMalformed_ShouldReturnUnauthenticated(t *testing.T) {
+	mockRepository := &RepositoryMock{
+		UseAPIKeyFunc: func(ctx context.Context, hash string, now time.Time) (APIKey, error) {
+			return APIKey{}, sql.ErrNoRows
+		},
+	}
+	sut := NewService(mockRepository)
+
+	for _, key := range []string{KeyPrefix + "revoked", "not-an-api-key"} {
+		if _, err := sut.Authenticate(context.Background(), key); !errors.Is(err, auth.ErrUnauthenticated) {
+			t.Errorf("Expected ErrUnauthenticated for %q, got %v", key, err)
+		}
+	}
+
+	if calls := len(mockRepository.UseAPIKeyCalls()); calls != 1 {
+		t.Errorf("Expected malformed keys not to be looked up, UseAPIKey was called %d times", calls)
+	}
+}
+
+func Test_APIKeyService_Authenticate_WhenLookupFails_ShouldReturnItsError(t *testing.T) {
+	failure := errors.New("connection refused")
+	sut := NewService(&RepositoryMock{
+		UseAPIKeyFunc: func(ctx context.Context, hash string, now time.Time) (APIKey, error) {
+			return APIKey{}, failure
+		},
+	})
+
+	_, err := sut.Authenticate(context.Background(), KeyPrefix+"valid")
+	if !errors.Is(err, failure) || errors.Is(err, auth.ErrUnauthenticated) {
+		t.Errorf("Expected the lookup error rather than ErrUnauthenticated, got %v", err)
+	}
+}
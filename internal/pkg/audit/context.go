@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+)
+
+// AnonymousActor is recorded when no actor has been attached to the context.
+const AnonymousActor = "anonymous"
+
+type contextKey string
+
+const (
+	actorKey     contextKey = "audit.actor"
+	requestIDKey contextKey = "audit.requestID"
+)
+
+// WithActor ..
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// ActorFromContext returns the actor attached by WithActor. Authenticated requests carry their
+// principal's subject; anything else is recorded as anonymous.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+// WithRequestID ..
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext ..
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Diff returns the fields that differ between the JSON encodings of before and after, keyed by
+// field name. Either side may be nil, as is the case for creates and deletes.
+func Diff(before interface{}, after interface{}) (map[string]Change, error) {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := toFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]Change)
+	for key, from := range beforeFields {
+		if to := afterFields[key]; !reflect.DeepEqual(from, to) {
+			changes[key] = Change{From: from, To: to}
+		}
+	}
+	for key, to := range afterFields {
+		if _, ok := beforeFields[key]; !ok {
+			changes[key] = Change{From: nil, To: to}
+		}
+	}
+
+	return changes, nil
+}
+
+func toFields(value interface{}) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	if value == nil {
+		return fields, nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(raw, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type product struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Price        int64     `json:"price"`
+	Manufacturer string    `json:"manufacturer"`
+}
+
+func Test_Diff_WhenFieldsChange_ShouldReturnOnlyChangedFields(t *testing.T) {
+	id := uuid.New()
+	before := product{ID: id, Name: "Lens", Price: 120, Manufacturer: "Canon"}
+	after := product{ID: id, Name: "Lens", Price: 150, Manufacturer: "Canon"}
+
+	result, err := Diff(before, after)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when diffing items", err)
+	}
+
+	if len(result) != 1 {
+		t.Fatalf("Expected a single changed field. Got %+v", result)
+	}
+
+	if change := result["price"]; change.From != float64(120) || change.To != float64(150) {
+		t.Errorf("Expected price to change from 120 to 150. Got %+v", change)
+	}
+}
+
+func Test_Diff_WhenBeforeIsNil_ShouldReturnEveryFieldAsAdded(t *testing.T) {
+	after := product{ID: uuid.New(), Name: "Lens", Price: 120, Manufacturer: "Canon"}
+
+	result, err := Diff(nil, after)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when diffing items", err)
+	}
+
+	if len(result) != 4 {
+		t.Fatalf("Expected every field to be reported. Got %+v", result)
+	}
+
+	if change := result["name"]; change.From != nil || change.To != "Lens" {
+		t.Errorf("Expected name to be added. Got %+v", change)
+	}
+}
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ItemEntityType ..
+	ItemEntityType = "item"
+
+	// ScheduledPriceEntityType ..
+	ScheduledPriceEntityType = "scheduled_price"
+)
+
+// Operation ..
+type Operation string
+
+const (
+	// Create ..
+	Create Operation = "create"
+
+	// Update ..
+	Update Operation = "update"
+
+	// Delete ..
+	Delete Operation = "delete"
+)
+
+// Entry ..
+type Entry struct {
+	ID         int64           `json:"id"`
+	Actor      string          `json:"actor"`
+	RequestID  string          `json:"request_id"`
+	Operation  Operation       `json:"operation"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uuid.UUID       `json:"entity_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	Diff       json.RawMessage `json:"diff"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// Change ..
+type Change struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Filter ..
+type Filter struct {
+	Since time.Time
+	Actor string
+}
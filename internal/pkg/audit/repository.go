@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+)
+
+// Repository ..
+type Repository interface {
+	GetEntries(ctx context.Context, filter Filter, page int64, pageSize int64) ([]Entry, error)
+	GetEntriesByEntity(ctx context.Context, entityType string, id uuid.UUID, page int64, pageSize int64) ([]Entry, error)
+}
+
+// Execer is satisfied by *sql.Tx, so entries can be written in the same transaction as the
+// change they describe.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Insert records the given mutation in the audit log. Callers pass the transaction making the
+// change, so the entry commits or rolls back with it.
+func Insert(ctx context.Context, tx Execer, operation Operation, entityType string, id uuid.UUID, before interface{}, after interface{}) error {
+	entry, err := NewEntry(ctx, operation, entityType, id, before, after)
+	if err != nil {
+		return err
+	}
+
+	insertStm := "INSERT INTO audit_log (actor, request_id, operation, entity_type, entity_id, before, after, diff) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
+	_, err = tx.ExecContext(ctx, insertStm,
+		entry.Actor,
+		entry.RequestID,
+		entry.Operation,
+		entry.EntityType,
+		entry.EntityID,
+		nullableJSON(entry.Before),
+		nullableJSON(entry.After),
+		nullableJSON(entry.Diff),
+	)
+	return err
+}
+
+// NewRepository ..
+func NewRepository(DBConn *sql.DB) Repository {
+	return &repository{DBConn: DBConn}
+}
+
+// repository ..
+type repository struct {
+	DBConn *sql.DB
+}
+
+const selectEntries = "SELECT id, actor, request_id, operation, entity_type, entity_id, before, after, diff, created_at FROM audit_log"
+
+// GetEntries ..
+func (r *repository) GetEntries(ctx context.Context, filter Filter, page int64, pageSize int64) ([]Entry, error) {
+	limit := pageSize
+	offset := page * pageSize
+
+	since := sql.NullTime{Time: filter.Since, Valid: !filter.Since.IsZero()}
+
+	rows, err := r.DBConn.QueryContext(ctx, selectEntries+" WHERE ($1::timestamptz IS NULL OR created_at >= $1) AND ($2 = '' OR actor = $2) ORDER BY id DESC LIMIT $3 OFFSET $4", since, filter.Actor, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanEntries(rows)
+}
+
+// GetEntriesByEntity ..
+func (r *repository) GetEntriesByEntity(ctx context.Context, entityType string, id uuid.UUID, page int64, pageSize int64) ([]Entry, error) {
+	limit := pageSize
+	offset := page * pageSize
+
+	rows, err := r.DBConn.QueryContext(ctx, selectEntries+" WHERE entity_type = $1 AND entity_id = $2 ORDER BY id DESC LIMIT $3 OFFSET $4", entityType, id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanEntries(rows)
+}
+
+func scanEntries(rows *sql.Rows) ([]Entry, error) {
+	payload := make([]Entry, 0)
+	for rows.Next() {
+		var before, after, diff []byte
+		data := new(Entry)
+		err := rows.Scan(&data.ID, &data.Actor, &data.RequestID, &data.Operation, &data.EntityType, &data.EntityID, &before, &after, &diff, &data.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		data.Before, data.After, data.Diff = before, after, diff
+		payload = append(payload, *data)
+	}
+
+	return payload, rows.Err()
+}
+
+func nullableJSON(raw []byte) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+var entryColumns = []string{"id", "actor", "request_id", "operation", "entity_type", "entity_id", "before", "after", "diff", "created_at"}
+
+func Test_Insert_ShouldWriteEntryThroughTransaction(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	id := uuid.New()
+	ctx := WithRequestID(WithActor(context.Background(), "admin"), "request-1")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO audit_log \\(actor, request_id, operation, entity_type, entity_id, before, after, diff\\)").
+		WithArgs("admin", "request-1", Delete, ItemEntityType, id, `{"name":"Lens"}`, nil, `{"name":{"from":"Lens","to":null}}`).
+		WillReturnResult(sqlmock.NewResult(42, 1))
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Insert(ctx, tx, Delete, ItemEntityType, id, map[string]string{"name": "Lens"}, nil)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when inserting an audit entry", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_AuditRepository_GetEntries_ShouldFilterBySinceAndActor(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	since := time.Now().Add(-time.Hour)
+	entityID := uuid.New()
+
+	mock.ExpectQuery("SELECT (.+) FROM audit_log WHERE \\(\\$1::timestamptz IS NULL OR created_at >= \\$1\\) AND \\(\\$2 = '' OR actor = \\$2\\) ORDER BY id DESC LIMIT \\$3 OFFSET \\$4").
+		WithArgs(since, "admin", 10, 0).
+		WillReturnRows(
+			sqlmock.NewRows(entryColumns).
+				AddRow(1, "admin", "request-1", "create", ItemEntityType, entityID, nil, []byte(`{"name":"Lens"}`), []byte(`{}`), time.Now()),
+		).
+		RowsWillBeClosed()
+
+	sut := NewRepository(dbConn)
+
+	result, err := sut.GetEntries(context.Background(), Filter{Since: since, Actor: "admin"}, 0, 10)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when fetching audit entries", err)
+	}
+
+	if len(result) != 1 || result[0].EntityID != entityID || result[0].Before != nil || string(result[0].After) != `{"name":"Lens"}` {
+		t.Fatalf("Unexpected entries were given, '%+v'.", result)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
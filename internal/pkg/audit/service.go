@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+)
+
+// Service ..
+type Service interface {
+	GetEntries(ctx context.Context, filter Filter, page int64, pageSize int64) ([]Entry, error)
+	GetEntriesByEntity(ctx context.Context, entityType string, id uuid.UUID, page int64, pageSize int64) ([]Entry, error)
+}
+
+// NewService ..
+func NewService(repository Repository) Service {
+	return &service{
+		Repository: repository,
+	}
+}
+
+type service struct {
+	Repository Repository
+}
+
+// GetEntries ..
+func (s *service) GetEntries(ctx context.Context, filter Filter, page int64, pageSize int64) ([]Entry, error) {
+	return s.Repository.GetEntries(ctx, filter, page, pageSize)
+}
+
+// GetEntriesByEntity ..
+func (s *service) GetEntriesByEntity(ctx context.Context, entityType string, id uuid.UUID, page int64, pageSize int64) ([]Entry, error) {
+	return s.Repository.GetEntriesByEntity(ctx, entityType, id, page, pageSize)
+}
+
+// NewEntry builds the entry for the given mutation, attributing it to the actor and request id
+// carried by the context.
+func NewEntry(ctx context.Context, operation Operation, entityType string, id uuid.UUID, before interface{}, after interface{}) (Entry, error) {
+	beforeJSON, err := marshalNullable(before)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	afterJSON, err := marshalNullable(after)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	changes, err := Diff(before, after)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	diffJSON, err := json.Marshal(changes)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	return Entry{
+		Actor:      ActorFromContext(ctx),
+		RequestID:  RequestIDFromContext(ctx),
+		Operation:  operation,
+		EntityType: entityType,
+		EntityID:   id,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		Diff:       diffJSON,
+	}, nil
+}
+
+func marshalNullable(value interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
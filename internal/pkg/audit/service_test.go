@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func Test_NewEntry_ShouldAttributeEntryToContextActorAndRequest(t *testing.T) {
+	ctx := WithRequestID(WithActor(context.Background(), "merchandiser@example.com"), "request-1")
+
+	id := uuid.New()
+	before := product{ID: id, Name: "Lens", Price: 120, Manufacturer: "Canon"}
+	after := product{ID: id, Name: "Lens Cap", Price: 120, Manufacturer: "Canon"}
+
+	result, err := NewEntry(ctx, Update, ItemEntityType, id, before, after)
+	if err != nil {
+		t.Fatalf("Should not have failed!")
+	}
+
+	if result.Actor != "merchandiser@example.com" || result.RequestID != "request-1" {
+		t.Errorf("Unexpected attribution: %s, %s", result.Actor, result.RequestID)
+	}
+
+	if result.Operation != Update || result.EntityID != id {
+		t.Errorf("Unexpected entry: %+v", result)
+	}
+
+	var diff map[string]Change
+	if err := json.Unmarshal(result.Diff, &diff); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := diff["name"]; !ok || len(diff) != 1 {
+		t.Errorf("Expected only the name to be in the diff. Got %s", result.Diff)
+	}
+}
+
+func Test_NewEntry_WhenNoActorIsGiven_ShouldRecordAnonymousActor(t *testing.T) {
+	result, err := NewEntry(context.Background(), Delete, ItemEntityType, uuid.New(), product{}, nil)
+	if err != nil {
+		t.Fatalf("Should not have failed!")
+	}
+
+	if result.Actor != AnonymousActor {
+		t.Errorf("Expected actor %s. Got %s", AnonymousActor, result.Actor)
+	}
+
+	if result.After != nil {
+		t.Errorf("Expected no after state for a delete. Got %s", result.After)
+	}
+}
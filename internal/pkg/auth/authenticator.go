@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrUnauthenticated wraps every reason a credential is rejected.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// Authenticator verifies credentials. Rejected credentials are reported by errors wrapping
+// ErrUnauthenticated; any other error means the credential couldn't be checked.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (Principal, error)
+}
+
+// SigningMethods are the JWT algorithms tokens may be signed with.
+var SigningMethods = []string{"RS256", "ES256", "HS256"}
+
+// NewJWTAuthenticator returns an Authenticator for JWT bearer tokens signed by a key in keys. When
+// issuer or audience are set, tokens must carry a matching iss or aud claim.
+func NewJWTAuthenticator(keys *KeySet, issuer string, audience string) Authenticator {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(SigningMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+
+	return &jwtAuthenticator{Keys: keys, Parser: jwt.NewParser(options...)}
+}
+
+type jwtAuthenticator struct {
+	Keys   *KeySet
+	Parser *jwt.Parser
+}
+
+func (a *jwtAuthenticator) Authenticate(ctx context.Context, token string) (Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := a.Parser.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		id, _ := token.Header["kid"].(string)
+		return a.Keys.Key(ctx, id, token.Method.Alg())
+	})
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return Principal{}, fmt.Errorf("%w: token has no subject", ErrUnauthenticated)
+	}
+
+	issuer, _ := claims.GetIssuer()
+
+	return Principal{Subject: subject, Issuer: issuer, Scopes: scopes(claims), Roles: roles(claims)}, nil
+}
+
+// scopes reads the space separated "scope" claim (RFC 8693) or, failing that, an "scp" list.
+func scopes(claims jwt.MapClaims) []string {
+	if scope, ok := claims["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+
+	return stringList(claims["scp"])
+}
+
+// roles reads the "roles" claim, either a list or a space separated string.
+func roles(claims jwt.MapClaims) []string {
+	if role, ok := claims["roles"].(string); ok {
+		return strings.Fields(role)
+	}
+
+	return stringList(claims["roles"])
+}
+
+func stringList(claim interface{}) []string {
+	var result []string
+	if list, ok := claim.([]interface{}); ok {
+		for _, value := range list {
+			if s, ok := value.(string); ok {
+				result = append(result, s)
+			}
+		}
+	}
+	return result
+}
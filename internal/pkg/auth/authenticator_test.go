@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type testKeys struct {
+	rsa    *rsa.PrivateKey
+	ecdsa  *ecdsa.PrivateKey
+	secret []byte
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testKeys{rsa: rsaKey, ecdsa: ecdsaKey, secret: []byte("a-shared-secret-of-32-bytes-long")}
+}
+
+func encode(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func (k testKeys) jwks() []byte {
+	data, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa-1", "use": "sig", "alg": "RS256", "n": encode(k.rsa.N), "e": encode(big.NewInt(int64(k.rsa.E)))},
+			{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": encode(k.ecdsa.X), "y": encode(k.ecdsa.Y)},
+			{"kty": "oct", "kid": "hmac-1", "alg": "HS256", "k": base64.RawURLEncoding.EncodeToString(k.secret)},
+			{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": encode(k.rsa.N), "e": "AQAB"},
+		},
+	})
+	return data
+}
+
+func writeKeySet(t *testing.T, data []byte) string {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func mint(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "merchandiser@example.com",
+		"iss":   "https://auth.example.com",
+		"aud":   "shopping-cart-service",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "items:read items:write",
+		"roles": []interface{}{"merchandiser"},
+	}
+}
+
+func newTestAuthenticator(t *testing.T, keys testKeys) Authenticator {
+	return NewJWTAuthenticator(NewKeySet(writeKeySet(t, keys.jwks()), DefaultKeySetTTL), "https://auth.example.com", "shopping-cart-service")
+}
+
+func Test_JWTAuthenticator_Authenticate_WhenTokenIsValid_ShouldReturnPrincipal(t *testing.T) {
+	keys := newTestKeys(t)
+	sut := newTestAuthenticator(t, keys)
+
+	tests := []struct {
+		name   string
+		method jwt.SigningMethod
+		kid    string
+		key    interface{}
+	}{
+		{"RS256", jwt.SigningMethodRS256, "rsa-1", keys.rsa},
+		{"ES256", jwt.SigningMethodES256, "ec-1", keys.ecdsa},
+		{"HS256", jwt.SigningMethodHS256, "hmac-1", keys.secret},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			principal, err := sut.Authenticate(context.Background(), mint(t, test.method, test.kid, test.key, validClaims()))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if principal.Subject != "merchandiser@example.com" || principal.Issuer != "https://auth.example.com" {
+				t.Errorf("unexpected principal %+v", principal)
+			}
+			if !principal.HasScope("items:write") || principal.HasScope("admin") {
+				t.Errorf("unexpected scopes %v", principal.Scopes)
+			}
+			if len(principal.Roles) != 1 || principal.Roles[0] != "merchandiser" {
+				t.Errorf("unexpected roles %v", principal.Roles)
+			}
+		})
+	}
+}
+
+func Test_JWTAuthenticator_Authenticate_WhenTokenIsInvalid_ShouldReturnUnauthenticated(t *testing.T) {
+	keys := newTestKeys(t)
+	sut := newTestAuthenticator(t, keys)
+	otherKeys := newTestKeys(t)
+
+	claims := func(change func(jwt.MapClaims)) jwt.MapClaims {
+		c := validClaims()
+		change(c)
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"expired", mint(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, claims(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }))},
+		{"without expiry", mint(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, claims(func(c jwt.MapClaims) { delete(c, "exp") }))},
+		{"wrong issuer", mint(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, claims(func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }))},
+		{"wrong audience", mint(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, claims(func(c jwt.MapClaims) { c["aud"] = "another-service" }))},
+		{"without subject", mint(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, claims(func(c jwt.MapClaims) { delete(c, "sub") }))},
+		{"signed by another key", mint(t, jwt.SigningMethodRS256, "rsa-1", otherKeys.rsa, validClaims())},
+		{"unknown key id", mint(t, jwt.SigningMethodRS256, "rsa-2", keys.rsa, validClaims())},
+		{"encryption key", mint(t, jwt.SigningMethodRS256, "enc-1", keys.rsa, validClaims())},
+		{"algorithm not allowed for key", mint(t, jwt.SigningMethodRS384, "rsa-1", keys.rsa, validClaims())},
+		{"algorithm not allowed", mint(t, jwt.SigningMethodHS512, "hmac-1", keys.secret, validClaims())},
+		{"unsigned", mint(t, jwt.SigningMethodNone, "rsa-1", jwt.UnsafeAllowNoneSignatureType, validClaims())},
+		{"malformed", "not.a.token"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := sut.Authenticate(context.Background(), test.token)
+			if !errors.Is(err, ErrUnauthenticated) {
+				t.Errorf("expected ErrUnauthenticated, got %v", err)
+			}
+		})
+	}
+}
+
+func Test_JWTAuthenticator_Authenticate_WhenHMACTokenUsesRSAPublicKey_ShouldReturnUnauthenticated(t *testing.T) {
+	keys := newTestKeys(t)
+	sut := newTestAuthenticator(t, keys)
+
+	// The classic algorithm confusion attack: sign HS256 with the RSA public key as the secret.
+	publicKey, _ := json.Marshal(keys.rsa.PublicKey)
+	token := mint(t, jwt.SigningMethodHS256, "rsa-1", publicKey, validClaims())
+
+	if _, err := sut.Authenticate(context.Background(), token); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("expected ErrUnauthenticated, got %v", err)
+	}
+}
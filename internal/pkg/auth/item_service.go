@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+// NewItemService wraps an item.Service so that every call is checked against policy for the
+// principal in its context, whichever entry point (HTTP, GraphQL or gRPC) it came through. Every
+// method is wrapped explicitly so a method added to item.Service can't skip the check.
+func NewItemService(next item.Service, policy *Policy) item.Service {
+	return &itemService{Service: next, Policy: policy}
+}
+
+type itemService struct {
+	Service item.Service
+	Policy  *Policy
+}
+
+// GetItems ..
+func (s *itemService) GetItems(ctx context.Context, page int64, pageSize int64) ([]item.Item, error) {
+	if err := s.Policy.Authorize(ctx, ReadItems); err != nil {
+		return nil, err
+	}
+	return s.Service.GetItems(ctx, page, pageSize)
+}
+
+// GetItemByID ..
+func (s *itemService) GetItemByID(ctx context.Context, id uuid.UUID) (item.Item, error) {
+	if err := s.Policy.Authorize(ctx, ReadItems); err != nil {
+		return item.Item{}, err
+	}
+	return s.Service.GetItemByID(ctx, id)
+}
+
+// FindItems ..
+func (s *itemService) FindItems(ctx context.Context, filter item.Filter, page int64, pageSize int64) ([]item.Item, error) {
+	if err := s.Policy.Authorize(ctx, ReadItems); err != nil {
+		return nil, err
+	}
+	return s.Service.FindItems(ctx, filter, page, pageSize)
+}
+
+// GetItemsByIDs ..
+func (s *itemService) GetItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]item.Item, error) {
+	if err := s.Policy.Authorize(ctx, ReadItems); err != nil {
+		return nil, err
+	}
+	return s.Service.GetItemsByIDs(ctx, ids)
+}
+
+// AddItem ..
+func (s *itemService) AddItem(ctx context.Context, dto *item.ItemDTO) (item.Item, error) {
+	if err := s.Policy.Authorize(ctx, CreateItems); err != nil {
+		return item.Item{}, err
+	}
+	return s.Service.AddItem(ctx, dto)
+}
+
+// UpdateItem ..
+func (s *itemService) UpdateItem(ctx context.Context, updated *item.Item) (item.Item, item.ServiceError) {
+	if err := s.Policy.Authorize(ctx, UpdateItems); err != nil {
+		return item.Item{}, item.CreateServiceError(err.Error(), item.Forbidden)
+	}
+	return s.Service.UpdateItem(ctx, updated)
+}
+
+// RemoveItem ..
+func (s *itemService) RemoveItem(ctx context.Context, id uuid.UUID) (uuid.UUID, item.ServiceError) {
+	if err := s.Policy.Authorize(ctx, DeleteItems); err != nil {
+		return uuid.Nil, item.CreateServiceError(err.Error(), item.Forbidden)
+	}
+	return s.Service.RemoveItem(ctx, id)
+}
+
+// GetPriceHistory ..
+func (s *itemService) GetPriceHistory(ctx context.Context, id uuid.UUID, page int64, pageSize int64) ([]item.PriceChange, item.ServiceError) {
+	if err := s.Policy.Authorize(ctx, ReadItems); err != nil {
+		return nil, item.CreateServiceError(err.Error(), item.Forbidden)
+	}
+	return s.Service.GetPriceHistory(ctx, id, page, pageSize)
+}
+
+// GetPriceHistoryByItemIDs ..
+func (s *itemService) GetPriceHistoryByItemIDs(ctx context.Context, ids []uuid.UUID) ([]item.PriceChange, error) {
+	if err := s.Policy.Authorize(ctx, ReadItems); err != nil {
+		return nil, err
+	}
+	return s.Service.GetPriceHistoryByItemIDs(ctx, ids)
+}
+
+// GetScheduledPrices ..
+func (s *itemService) GetScheduledPrices(ctx context.Context, id uuid.UUID) ([]item.ScheduledPrice, item.ServiceError) {
+	if err := s.Policy.Authorize(ctx, ReadItems); err != nil {
+		return nil, item.CreateServiceError(err.Error(), item.Forbidden)
+	}
+	return s.Service.GetScheduledPrices(ctx, id)
+}
+
+// GetScheduledPricesByItemIDs ..
+func (s *itemService) GetScheduledPricesByItemIDs(ctx context.Context, ids []uuid.UUID) ([]item.ScheduledPrice, error) {
+	if err := s.Policy.Authorize(ctx, ReadItems); err != nil {
+		return nil, err
+	}
+	return s.Service.GetScheduledPricesByItemIDs(ctx, ids)
+}
+
+// SchedulePrice ..
+func (s *itemService) SchedulePrice(ctx context.Context, id uuid.UUID, price *item.ScheduledPriceDTO) (item.ScheduledPrice, item.ServiceError) {
+	if err := s.Policy.Authorize(ctx, UpdateItems); err != nil {
+		return item.ScheduledPrice{}, item.CreateServiceError(err.Error(), item.Forbidden)
+	}
+	return s.Service.SchedulePrice(ctx, id, price)
+}
+
+// ApplyScheduledPrices is run by the price scheduler rather than on behalf of a caller, which is
+// given the undecorated service; anything else needs UpdateItems.
+func (s *itemService) ApplyScheduledPrices(ctx context.Context, now time.Time) ([]item.ScheduledPrice, error) {
+	if err := s.Policy.Authorize(ctx, UpdateItems); err != nil {
+		return nil, err
+	}
+	return s.Service.ApplyScheduledPrices(ctx, now)
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+func Test_ItemService_WhenRoleIsNotGranted_ShouldNotCallNext(t *testing.T) {
+	policy, _ := LoadPolicy("")
+	next := &item.ServiceMock{}
+	sut := NewItemService(next, policy)
+	ctx := WithPrincipal(context.Background(), Principal{Subject: "shopper@example.com", Roles: []string{"shopper"}})
+
+	if _, err := sut.AddItem(ctx, &item.ItemDTO{}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if _, err := sut.RemoveItem(ctx, uuid.New()); err == nil || err.StatusCode() != item.Forbidden {
+		t.Errorf("expected a Forbidden service error, got %v", err)
+	}
+	if len(next.AddItemCalls()) != 0 || len(next.RemoveItemCalls()) != 0 {
+		t.Error("expected the wrapped service not to be called")
+	}
+}
+
+func Test_ItemService_WhenRoleIsGranted_ShouldCallNext(t *testing.T) {
+	policy, _ := LoadPolicy("")
+	id := uuid.New()
+	next := &item.ServiceMock{
+		RemoveItemFunc: func(ctx context.Context, itemID uuid.UUID) (uuid.UUID, item.ServiceError) {
+			return itemID, nil
+		},
+	}
+	sut := NewItemService(next, policy)
+	ctx := WithPrincipal(context.Background(), Principal{Subject: "admin@example.com", Roles: []string{"admin"}})
+
+	removed, err := sut.RemoveItem(ctx, id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed != id || len(next.RemoveItemCalls()) != 1 {
+		t.Errorf("expected %s to be removed, got %s", id, removed)
+	}
+}
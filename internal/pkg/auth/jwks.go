@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultKeySetTTL is how long a fetched key set is used before it is fetched again.
+	DefaultKeySetTTL = 15 * time.Minute
+
+	// minRefreshInterval limits how often a token signed with an unknown key can trigger a fetch,
+	// so rotated keys are picked up without letting bad tokens hammer the key set's source.
+	minRefreshInterval = 30 * time.Second
+)
+
+// ErrKeyNotFound ..
+var ErrKeyNotFound = errors.New("signing key not found")
+
+// NewKeySet returns a key set loaded from source, a JWKS file path or an http(s) URL. Keys are
+// cached for ttl and fetched again early when a token names a key id the cache doesn't have.
+func NewKeySet(source string, ttl time.Duration) *KeySet {
+	return &KeySet{
+		Source: source,
+		TTL:    ttl,
+		Client: &http.Client{Timeout: 5 * time.Second},
+		now:    time.Now,
+	}
+}
+
+// KeySet ..
+type KeySet struct {
+	Source string
+	TTL    time.Duration
+	Client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]jsonWebKey
+	fetchedAt time.Time
+	now       func() time.Time
+}
+
+type jsonWebKey struct {
+	algorithm string
+	key       interface{}
+}
+
+// Key returns the verification key with the given id for algorithm. An empty id matches the only
+// key in the set.
+func (k *KeySet) Key(ctx context.Context, id string, algorithm string) (interface{}, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := k.now()
+	if k.keys == nil || now.Sub(k.fetchedAt) >= k.TTL {
+		if err := k.refresh(ctx, now); err != nil && k.keys == nil {
+			return nil, err
+		}
+	}
+
+	key, ok := k.lookup(id)
+	if !ok && now.Sub(k.fetchedAt) >= minRefreshInterval {
+		if err := k.refresh(ctx, now); err != nil {
+			return nil, err
+		}
+		key, ok = k.lookup(id)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, id)
+	}
+
+	if key.algorithm != "" && key.algorithm != algorithm {
+		return nil, fmt.Errorf("key %q is for %s, not %s", id, key.algorithm, algorithm)
+	}
+
+	return key.key, nil
+}
+
+func (k *KeySet) lookup(id string) (jsonWebKey, bool) {
+	if id == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, true
+		}
+	}
+
+	key, ok := k.keys[id]
+	return key, ok
+}
+
+// refresh replaces the cached keys. When the source can't be read the previous keys, if any, are
+// kept so an outage of the key set's host doesn't lock everyone out.
+func (k *KeySet) refresh(ctx context.Context, now time.Time) error {
+	k.fetchedAt = now
+
+	data, err := k.read(ctx)
+	if err == nil {
+		var keys map[string]jsonWebKey
+		keys, err = parseKeySet(data)
+		if err == nil {
+			k.keys = keys
+			return nil
+		}
+	}
+
+	slog.ErrorContext(ctx, "failed to load JWKS", "source", k.Source, "error", err)
+	return err
+}
+
+func (k *KeySet) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(k.Source, "http://") && !strings.HasPrefix(k.Source, "https://") {
+		return os.ReadFile(k.Source)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "GET", k.Source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := k.Client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(response.Body, 1<<20))
+}
+
+// parseKeySet reads the signing keys out of a JSON Web Key Set (RFC 7517). Encryption keys and
+// key types other than RSA, EC and oct are skipped.
+func parseKeySet(data []byte) (map[string]jsonWebKey, error) {
+	var set struct {
+		Keys []struct {
+			KeyType   string `json:"kty"`
+			KeyID     string `json:"kid"`
+			Use       string `json:"use"`
+			Algorithm string `json:"alg"`
+			N         string `json:"n"`
+			E         string `json:"e"`
+			Curve     string `json:"crv"`
+			X         string `json:"x"`
+			Y         string `json:"y"`
+			K         string `json:"k"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]jsonWebKey)
+	for _, raw := range set.Keys {
+		if raw.Use != "" && raw.Use != "sig" {
+			continue
+		}
+
+		var key interface{}
+		var err error
+		switch raw.KeyType {
+		case "RSA":
+			key, err = rsaKey(raw.N, raw.E)
+		case "EC":
+			key, err = ecdsaKey(raw.Curve, raw.X, raw.Y)
+		case "oct":
+			key, err = secretKey(raw.K)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", raw.KeyID, err)
+		}
+
+		keys[raw.KeyID] = jsonWebKey{algorithm: raw.Algorithm, key: key}
+	}
+
+	return keys, nil
+}
+
+func rsaKey(n string, e string) (*rsa.PublicKey, error) {
+	modulus, err := decodeSegment(n)
+	if err != nil {
+		return nil, err
+	}
+
+	exponent, err := decodeSegment(e)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(modulus) == 0 || len(exponent) == 0 || len(exponent) > 4 {
+		return nil, errors.New("invalid RSA key")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}, nil
+}
+
+func ecdsaKey(curveName string, x string, y string) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch curveName {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", curveName)
+	}
+
+	xBytes, err := decodeSegment(x)
+	if err != nil {
+		return nil, err
+	}
+
+	yBytes, err := decodeSegment(y)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(xBytes),
+		Y:     new(big.Int).SetBytes(yBytes),
+	}, nil
+}
+
+func secretKey(k string) ([]byte, error) {
+	secret, err := decodeSegment(k)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(secret) == 0 {
+		return nil, errors.New("empty secret")
+	}
+
+	return secret, nil
+}
+
+func decodeSegment(segment string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+}
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_KeySet_Key_WhenSourceIsURL_ShouldCacheKeys(t *testing.T) {
+	keys := newTestKeys(t)
+
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		w.Write(keys.jwks())
+	}))
+	defer server.Close()
+
+	sut := NewKeySet(server.URL, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if _, err := sut.Key(context.Background(), "rsa-1", "RS256"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if fetches != 1 {
+		t.Errorf("expected the key set to be fetched once, got %d", fetches)
+	}
+}
+
+func Test_KeySet_Key_WhenTTLExpires_ShouldFetchAgain(t *testing.T) {
+	keys := newTestKeys(t)
+
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		w.Write(keys.jwks())
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	sut := NewKeySet(server.URL, time.Minute)
+	sut.now = func() time.Time { return now }
+
+	sut.Key(context.Background(), "rsa-1", "RS256")
+	now = now.Add(2 * time.Minute)
+	sut.Key(context.Background(), "rsa-1", "RS256")
+
+	if fetches != 2 {
+		t.Errorf("expected the key set to be fetched twice, got %d", fetches)
+	}
+}
+
+func Test_KeySet_Key_WhenKeysAreRotated_ShouldPickUpNewKey(t *testing.T) {
+	oldKeys := newTestKeys(t)
+	newKeys := newTestKeys(t)
+	path := writeKeySet(t, oldKeys.jwks())
+
+	now := time.Now()
+	sut := NewKeySet(path, time.Hour)
+	sut.now = func() time.Time { return now }
+
+	oldKey, err := sut.Key(context.Background(), "rsa-1", "RS256")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rotated, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa-2", "alg": "RS256", "n": encode(newKeys.rsa.N), "e": encode(big.NewInt(int64(newKeys.rsa.E)))},
+		},
+	})
+	if err := os.WriteFile(path, rotated, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sut.Key(context.Background(), "rsa-2", "RS256"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("expected unknown keys not to trigger a fetch within the refresh interval, got %v", err)
+	}
+
+	now = now.Add(minRefreshInterval)
+	newKey, err := sut.Key(context.Background(), "rsa-2", "RS256")
+	if err != nil {
+		t.Fatalf("expected the rotated key to be found, got %v", err)
+	}
+	if newKey.(*rsa.PublicKey).Equal(oldKey) {
+		t.Errorf("expected a different key after rotation")
+	}
+}
+
+func Test_KeySet_Key_WhenSourceBecomesUnavailable_ShouldKeepCachedKeys(t *testing.T) {
+	keys := newTestKeys(t)
+
+	available := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(keys.jwks())
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	sut := NewKeySet(server.URL, time.Minute)
+	sut.now = func() time.Time { return now }
+
+	sut.Key(context.Background(), "ec-1", "ES256")
+	available = false
+	now = now.Add(2 * time.Minute)
+
+	if _, err := sut.Key(context.Background(), "ec-1", "ES256"); err != nil {
+		t.Errorf("expected the cached key to be used, got %v", err)
+	}
+}
+
+func Test_KeySet_Key_WhenKeyIDIsEmptyAndSetHasOneKey_ShouldReturnIt(t *testing.T) {
+	sut := NewKeySet(writeKeySet(t, []byte(`{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`)), time.Minute)
+
+	key, err := sut.Key(context.Background(), "", "HS256")
+	if err != nil || string(key.([]byte)) != "secret" {
+		t.Errorf("unexpected key %v (%v)", key, err)
+	}
+}
+
+func Test_KeySet_Key_WhenSourceIsInvalid_ShouldReturnError(t *testing.T) {
+	tests := map[string]string{
+		"missing file":   "/does/not/exist.json",
+		"invalid json":   writeKeySet(t, []byte(`{"keys": `)),
+		"invalid key":    writeKeySet(t, []byte(`{"keys": [{"kty": "RSA", "kid": "rsa-1", "n": "", "e": ""}]}`)),
+		"empty secret":   writeKeySet(t, []byte(`{"keys": [{"kty": "oct", "kid": "hmac-1", "k": ""}]}`)),
+		"unknown curve":  writeKeySet(t, []byte(`{"keys": [{"kty": "EC", "kid": "ec-1", "crv": "P-192", "x": "AA", "y": "AA"}]}`)),
+		"bad base64 key": writeKeySet(t, []byte(`{"keys": [{"kty": "oct", "kid": "hmac-1", "k": "!!"}]}`)),
+	}
+
+	for name, source := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewKeySet(source, time.Minute).Key(context.Background(), "rsa-1", "RS256"); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/audit"
+)
+
+// Principal is the caller a request was authenticated as.
+type Principal struct {
+	Subject string   `json:"subject"`
+	Issuer  string   `json:"issuer"`
+	Scopes  []string `json:"scopes"`
+	Roles   []string `json:"roles"`
+}
+
+// HasScope ..
+func (principal Principal) HasScope(scope string) bool {
+	for _, s := range principal.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey string
+
+const principalKey contextKey = "auth.principal"
+
+// WithPrincipal attaches the principal to the context and names its subject as the actor of any
+// audit entries recorded under it.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	ctx = audit.WithActor(ctx, principal.Subject)
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// PrincipalFromContext returns the principal attached by WithPrincipal, if any.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey).(Principal)
+	return principal, ok
+}
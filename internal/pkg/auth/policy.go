@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Permission ..
+type Permission string
+
+const (
+	// ReadItems ..
+	ReadItems Permission = "items:read"
+
+	// CreateItems ..
+	CreateItems Permission = "items:create"
+
+	// UpdateItems covers editing items and scheduling their prices.
+	UpdateItems Permission = "items:update"
+
+	// DeleteItems ..
+	DeleteItems Permission = "items:delete"
+
+	// ManageAPIKeys covers creating, listing and revoking API keys.
+	ManageAPIKeys Permission = "api-keys:manage"
+
+	// ManageWebhooks covers creating, listing and removing webhook subscriptions and reading their
+	// deliveries.
+	ManageWebhooks Permission = "webhooks:manage"
+
+	// ReadAuditLog covers the audit log and each item's history.
+	ReadAuditLog Permission = "audit:read"
+)
+
+// Permissions lists every permission a policy may grant.
+var Permissions = []Permission{ReadItems, CreateItems, UpdateItems, DeleteItems, ManageAPIKeys, ManageWebhooks, ReadAuditLog}
+
+// ErrForbidden is returned when the principal's roles don't grant a permission.
+var ErrForbidden = errors.New("forbidden")
+
+// DefaultPolicy grants shoppers read access, merchandisers create and update, and admins delete,
+// manage API keys and webhooks, and read the audit log.
+//
+//go:embed policy.json
+var DefaultPolicy []byte
+
+// Policy maps role names onto the permissions they grant.
+type Policy struct {
+	Roles map[string][]Permission `json:"roles"`
+}
+
+// LoadPolicy reads a policy file in the format of DefaultPolicy. An empty path loads
+// DefaultPolicy.
+func LoadPolicy(path string) (*Policy, error) {
+	if path == "" {
+		return ParsePolicy(DefaultPolicy)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePolicy(data)
+}
+
+// ParsePolicy rejects policies naming permissions that don't exist, so typos fail at startup
+// rather than silently denying access.
+func ParsePolicy(data []byte) (*Policy, error) {
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, err
+	}
+
+	for role, granted := range policy.Roles {
+		for _, permission := range granted {
+			if !IsPermission(permission) {
+				return nil, fmt.Errorf("role %q grants unknown permission %q", role, permission)
+			}
+		}
+	}
+
+	return &policy, nil
+}
+
+// Allows reports whether any of the principal's roles grants permission, or whether the principal
+// holds the permission directly as a scope, as API keys do.
+func (p *Policy) Allows(principal Principal, permission Permission) bool {
+	if principal.HasScope(string(permission)) {
+		return true
+	}
+
+	for _, role := range principal.Roles {
+		for _, granted := range p.Roles[role] {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Authorize checks permission against the principal attached to ctx.
+func (p *Policy) Authorize(ctx context.Context, permission Permission) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("%w: %s requires an authenticated principal", ErrForbidden, permission)
+	}
+
+	if !p.Allows(principal, permission) {
+		return fmt.Errorf("%w: %s is not granted to %s", ErrForbidden, permission, principal.Subject)
+	}
+
+	return nil
+}
+
+// IsPermission reports whether permission is one of Permissions.
+func IsPermission(permission Permission) bool {
+	for _, known := range Permissions {
+		if known == permission {
+			return true
+		}
+	}
+	return false
+}
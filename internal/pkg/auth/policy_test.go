@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Policy_Allows_WhenUsingDefaultPolicy_ShouldGrantPermissionsByRole(t *testing.T) {
+	sut, err := LoadPolicy("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		role       string
+		permission Permission
+		allowed    bool
+	}{
+		{"shopper", ReadItems, true},
+		{"shopper", CreateItems, false},
+		{"shopper", DeleteItems, false},
+		{"merchandiser", CreateItems, true},
+		{"merchandiser", UpdateItems, true},
+		{"merchandiser", DeleteItems, false},
+		{"admin", DeleteItems, true},
+		{"merchandiser", ManageWebhooks, false},
+		{"admin", ManageWebhooks, true},
+		{"merchandiser", ReadAuditLog, false},
+		{"admin", ReadAuditLog, true},
+		{"guest", ReadItems, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.role+" "+string(test.permission), func(t *testing.T) {
+			if allowed := sut.Allows(Principal{Roles: []string{test.role}}, test.permission); allowed != test.allowed {
+				t.Errorf("expected %t, got %t", test.allowed, allowed)
+			}
+		})
+	}
+}
+
+func Test_Policy_Authorize_WhenPrincipalIsMissing_ShouldReturnForbidden(t *testing.T) {
+	sut, _ := LoadPolicy("")
+
+	if err := sut.Authorize(context.Background(), ReadItems); !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func Test_LoadPolicy_WhenFileGrantsUnknownPermission_ShouldReturnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(`{"roles": {"shopper": ["items:purchase"]}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadPolicy(path); err == nil {
+		t.Error("expected an error for an unknown permission")
+	}
+}
+
+func Test_LoadPolicy_WhenFileIsValid_ShouldUseItsRoles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(`{"roles": {"auditor": ["items:read"]}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sut, err := LoadPolicy(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !sut.Allows(Principal{Roles: []string{"auditor"}}, ReadItems) || sut.Allows(Principal{Roles: []string{"admin"}}, ReadItems) {
+		t.Errorf("unexpected roles %v", sut.Roles)
+	}
+}
+
+func Test_Policy_Allows_WhenPrincipalHoldsPermissionAsScope_ShouldGrantIt(t *testing.T) {
+	sut, _ := LoadPolicy("")
+	principal := Principal{Subject: "api-key:erp", Scopes: []string{string(ReadItems)}}
+
+	if !sut.Allows(principal, ReadItems) || sut.Allows(principal, UpdateItems) {
+		t.Errorf("expected only %s to be granted to %+v", ReadItems, principal)
+	}
+}
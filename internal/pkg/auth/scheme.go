@@ -0,0 +1,55 @@
+package auth
+
+import "strings"
+
+// Scheme is a way of presenting a credential, along with the Authenticator that verifies it.
+// Several schemes may be accepted side by side; each request is authenticated by the first whose
+// credential it carries.
+type Scheme struct {
+	// Name is the Authorization header scheme, such as Bearer.
+	Name string
+
+	// Header optionally names a header carrying the bare credential, such as X-API-Key.
+	Header string
+
+	Authenticator Authenticator
+}
+
+// BearerScheme accepts "Authorization: Bearer <token>" (RFC 6750).
+func BearerScheme(authenticator Authenticator) Scheme {
+	return Scheme{Name: "Bearer", Authenticator: authenticator}
+}
+
+// APIKeyScheme accepts "Authorization: ApiKey <key>" or "X-API-Key: <key>".
+func APIKeyScheme(authenticator Authenticator) Scheme {
+	return Scheme{Name: "ApiKey", Header: "X-API-Key", Authenticator: authenticator}
+}
+
+// Credential finds the scheme's credential among the header values returned by lookup.
+func (s Scheme) Credential(lookup func(header string) []string) (string, bool) {
+	for _, value := range lookup("Authorization") {
+		if credential, ok := ParseAuthorization(value, s.Name); ok {
+			return credential, true
+		}
+	}
+
+	if s.Header != "" {
+		for _, value := range lookup(s.Header) {
+			if credential := strings.TrimSpace(value); credential != "" {
+				return credential, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// ParseAuthorization extracts the credential from an Authorization header value using scheme,
+// which is matched case-insensitively.
+func ParseAuthorization(header string, scheme string) (string, bool) {
+	name, credential, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(name, scheme) || strings.TrimSpace(credential) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(credential), true
+}
@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/event"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/metrics"
+)
+
+// resubscribeDelay paces resubscribing to the broker once it drops or closes a subscription.
+const resubscribeDelay = time.Second
+
+// NewItemRepository wraps an item.Repository so that items looked up by id are kept in memory,
+// up to size of them for at most ttl each, evicting the least recently used first. Items changed
+// through it are dropped straight away, and those changed by other replicas once their event is
+// published to broker, while Run is running.
+func NewItemRepository(next item.Repository, broker *event.Broker, size int, ttl time.Duration) *ItemRepository {
+	return &ItemRepository{
+		Repository: next,
+		Broker:     broker,
+		items:      expirable.NewLRU[uuid.UUID, item.Item](size, nil, ttl),
+	}
+}
+
+// ItemRepository is a read-through cache of items by id. Concurrent misses for the same item share
+// a single lookup.
+type ItemRepository struct {
+	item.Repository
+	Broker *event.Broker
+
+	items *expirable.LRU[uuid.UUID, item.Item]
+	loads singleflight.Group
+
+	// generation counts invalidations, so that a lookup started before one doesn't cache what it
+	// read, which may be out of date.
+	mu         sync.Mutex
+	generation uint64
+}
+
+// GetItemByID ..
+func (r *ItemRepository) GetItemByID(ctx context.Context, id uuid.UUID) (item.Item, error) {
+	if cached, ok := r.items.Get(id); ok {
+		metrics.ItemCacheLookups.WithLabelValues("hit").Inc()
+		return cached, nil
+	}
+	metrics.ItemCacheLookups.WithLabelValues("miss").Inc()
+
+	r.mu.Lock()
+	generation := r.generation
+	r.mu.Unlock()
+
+	// The lookup is shared, so it isn't cancelled along with the caller that started it.
+	loaded := r.loads.DoChan(id.String()+"/"+strconv.FormatUint(generation, 10), func() (any, error) {
+		found, err := r.Repository.GetItemByID(context.WithoutCancel(ctx), id)
+		if err != nil {
+			return item.Item{}, err
+		}
+
+		r.mu.Lock()
+		if r.generation == generation {
+			r.items.Add(id, found)
+		}
+		r.mu.Unlock()
+
+		return found, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return item.Item{}, ctx.Err()
+	case result := <-loaded:
+		return result.Val.(item.Item), result.Err
+	}
+}
+
+// UpdateItem ..
+func (r *ItemRepository) UpdateItem(ctx context.Context, updated *item.Item) (item.Item, error) {
+	result, err := r.Repository.UpdateItem(ctx, updated)
+	r.invalidate("local", updated.ID)
+	return result, err
+}
+
+// RemoveItem ..
+func (r *ItemRepository) RemoveItem(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	result, err := r.Repository.RemoveItem(ctx, id)
+	r.invalidate("local", id)
+	return result, err
+}
+
+// ApplyScheduledPrices ..
+func (r *ItemRepository) ApplyScheduledPrices(ctx context.Context, now time.Time) ([]item.ScheduledPrice, error) {
+	result, err := r.Repository.ApplyScheduledPrices(ctx, now)
+
+	ids := make([]uuid.UUID, len(result))
+	for i, scheduledPrice := range result {
+		ids[i] = scheduledPrice.ItemID
+	}
+	r.invalidate("local", ids...)
+
+	return result, err
+}
+
+// Run blocks, dropping the items that events published to the broker say have changed until the
+// context is cancelled. Whenever the broker drops the subscription, events may have been missed,
+// so every item is dropped.
+func (r *ItemRepository) Run(ctx context.Context) {
+	for {
+		events, unsubscribe := r.Broker.Subscribe()
+		r.purge()
+		r.drain(ctx, events)
+		unsubscribe()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+func (r *ItemRepository) drain(ctx context.Context, events <-chan event.Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case published, ok := <-events:
+			if !ok {
+				return
+			}
+			r.invalidate("remote", published.ItemID)
+		}
+	}
+}
+
+func (r *ItemRepository) invalidate(source string, ids ...uuid.UUID) {
+	r.mu.Lock()
+	r.generation++
+	for _, id := range ids {
+		r.items.Remove(id)
+	}
+	r.mu.Unlock()
+
+	metrics.ItemCacheInvalidations.WithLabelValues(source).Add(float64(len(ids)))
+}
+
+func (r *ItemRepository) purge() {
+	r.mu.Lock()
+	r.generation++
+	r.items.Purge()
+	r.mu.Unlock()
+}
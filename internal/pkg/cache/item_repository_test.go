@@ -0,0 +1,252 @@
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/icrowley/fake"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/event"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/metrics"
+)
+
+func newItem() item.Item {
+	return item.Item{ID: uuid.New(), Name: fake.ProductName(), Price: 99, Manufacturer: fake.Brand()}
+}
+
+func Test_CacheItemRepository_GetItemByID_WhenCached_ShouldNotQueryAgain(t *testing.T) {
+	cached := newItem()
+	mockRepository := &item.RepositoryMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (item.Item, error) {
+			return cached, nil
+		},
+	}
+	sut := NewItemRepository(mockRepository, event.NewBroker(), 10, time.Minute)
+
+	hits := testutil.ToFloat64(metrics.ItemCacheLookups.WithLabelValues("hit"))
+	misses := testutil.ToFloat64(metrics.ItemCacheLookups.WithLabelValues("miss"))
+
+	for i := 0; i < 3; i++ {
+		result, err := sut.GetItemByID(context.Background(), cached.ID)
+		if err != nil || result != cached {
+			t.Fatalf("Expected %+v. Got %+v, %v", cached, result, err)
+		}
+	}
+
+	if calls := len(mockRepository.GetItemByIDCalls()); calls != 1 {
+		t.Errorf("Expected a single query. Got %d", calls)
+	}
+	if got := testutil.ToFloat64(metrics.ItemCacheLookups.WithLabelValues("hit")) - hits; got != 2 {
+		t.Errorf("Expected 2 hits to be counted. Got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.ItemCacheLookups.WithLabelValues("miss")) - misses; got != 1 {
+		t.Errorf("Expected 1 miss to be counted. Got %v", got)
+	}
+}
+
+func Test_CacheItemRepository_GetItemByID_WhenMissesAreConcurrent_ShouldQueryOnce(t *testing.T) {
+	cached := newItem()
+	release := make(chan struct{})
+	mockRepository := &item.RepositoryMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (item.Item, error) {
+			<-release
+			return cached, nil
+		},
+	}
+	sut := NewItemRepository(mockRepository, event.NewBroker(), 10, time.Minute)
+
+	var lookups sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		lookups.Add(1)
+		go func() {
+			defer lookups.Done()
+			if result, err := sut.GetItemByID(context.Background(), cached.ID); err != nil || result != cached {
+				t.Errorf("Expected %+v. Got %+v, %v", cached, result, err)
+			}
+		}()
+	}
+	// Give every lookup the chance to join the first before it's let through.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	lookups.Wait()
+
+	if calls := len(mockRepository.GetItemByIDCalls()); calls != 1 {
+		t.Errorf("Expected the concurrent misses to share a query. Got %d", calls)
+	}
+}
+
+func Test_CacheItemRepository_GetItemByID_WhenLookupFails_ShouldNotCacheIt(t *testing.T) {
+	cached := newItem()
+	found := false
+	mockRepository := &item.RepositoryMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (item.Item, error) {
+			if !found {
+				return item.Item{}, sql.ErrNoRows
+			}
+			return cached, nil
+		},
+	}
+	sut := NewItemRepository(mockRepository, event.NewBroker(), 10, time.Minute)
+
+	if _, err := sut.GetItemByID(context.Background(), cached.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("Expected sql.ErrNoRows. Got %v", err)
+	}
+
+	found = true
+	if result, err := sut.GetItemByID(context.Background(), cached.ID); err != nil || result != cached {
+		t.Errorf("Expected %+v. Got %+v, %v", cached, result, err)
+	}
+}
+
+func Test_CacheItemRepository_GetItemByID_WhenTTLHasPassed_ShouldQueryAgain(t *testing.T) {
+	cached := newItem()
+	mockRepository := &item.RepositoryMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (item.Item, error) {
+			return cached, nil
+		},
+	}
+	sut := NewItemRepository(mockRepository, event.NewBroker(), 10, 10*time.Millisecond)
+
+	_, _ = sut.GetItemByID(context.Background(), cached.ID)
+	time.Sleep(30 * time.Millisecond)
+	_, _ = sut.GetItemByID(context.Background(), cached.ID)
+
+	if calls := len(mockRepository.GetItemByIDCalls()); calls != 2 {
+		t.Errorf("Expected the expired item to be queried again. Got %d queries", calls)
+	}
+}
+
+func Test_CacheItemRepository_UpdateItem_ShouldInvalidateTheItem(t *testing.T) {
+	cached := newItem()
+	mockRepository := &item.RepositoryMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (item.Item, error) {
+			return cached, nil
+		},
+		UpdateItemFunc: func(ctx context.Context, updated *item.Item) (item.Item, error) {
+			cached = *updated
+			return cached, nil
+		},
+		RemoveItemFunc: func(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+			return id, nil
+		},
+	}
+	sut := NewItemRepository(mockRepository, event.NewBroker(), 10, time.Minute)
+	ctx := context.Background()
+
+	_, _ = sut.GetItemByID(ctx, cached.ID)
+	updated := cached
+	updated.Price = 150
+	_, _ = sut.UpdateItem(ctx, &updated)
+
+	result, err := sut.GetItemByID(ctx, cached.ID)
+	if err != nil || result.Price != 150 {
+		t.Errorf("Expected the updated item. Got %+v, %v", result, err)
+	}
+
+	_, _ = sut.RemoveItem(ctx, cached.ID)
+	_, _ = sut.GetItemByID(ctx, cached.ID)
+
+	if calls := len(mockRepository.GetItemByIDCalls()); calls != 3 {
+		t.Errorf("Expected a query after each change. Got %d queries", calls)
+	}
+}
+
+func Test_CacheItemRepository_ApplyScheduledPrices_ShouldInvalidateRepricedItems(t *testing.T) {
+	cached := newItem()
+	mockRepository := &item.RepositoryMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (item.Item, error) {
+			return cached, nil
+		},
+		ApplyScheduledPricesFunc: func(ctx context.Context, now time.Time) ([]item.ScheduledPrice, error) {
+			cached.Price = 80
+			return []item.ScheduledPrice{{ID: uuid.New(), ItemID: cached.ID, Price: 80, EffectiveAt: now}}, nil
+		},
+	}
+	sut := NewItemRepository(mockRepository, event.NewBroker(), 10, time.Minute)
+	ctx := context.Background()
+
+	_, _ = sut.GetItemByID(ctx, cached.ID)
+	_, _ = sut.ApplyScheduledPrices(ctx, time.Now())
+
+	if result, err := sut.GetItemByID(ctx, cached.ID); err != nil || result.Price != 80 {
+		t.Errorf("Expected the scheduled price. Got %+v, %v", result, err)
+	}
+}
+
+func Test_CacheItemRepository_GetItemByID_WhenInvalidatedWhileQuerying_ShouldNotCacheIt(t *testing.T) {
+	cached := newItem()
+	querying := make(chan struct{})
+	release := make(chan struct{})
+	first := true
+	mockRepository := &item.RepositoryMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (item.Item, error) {
+			if first {
+				first = false
+				close(querying)
+				<-release
+			}
+			return cached, nil
+		},
+		RemoveItemFunc: func(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+			return id, nil
+		},
+	}
+	sut := NewItemRepository(mockRepository, event.NewBroker(), 10, time.Minute)
+	ctx := context.Background()
+
+	looked := make(chan struct{})
+	go func() {
+		_, _ = sut.GetItemByID(ctx, cached.ID)
+		close(looked)
+	}()
+	<-querying
+	_, _ = sut.RemoveItem(ctx, cached.ID)
+	close(release)
+	<-looked
+
+	_, _ = sut.GetItemByID(ctx, cached.ID)
+
+	if calls := len(mockRepository.GetItemByIDCalls()); calls != 2 {
+		t.Errorf("Expected what was read before the change not to be cached. Got %d queries", calls)
+	}
+}
+
+func Test_CacheItemRepository_Run_WhenAnotherReplicaChangesAnItem_ShouldInvalidateIt(t *testing.T) {
+	cached := newItem()
+	mockRepository := &item.RepositoryMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (item.Item, error) {
+			return cached, nil
+		},
+	}
+	broker := event.NewBroker()
+	sut := NewItemRepository(mockRepository, broker, 10, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sut.Run(ctx)
+
+	// Events about other items show when Run has subscribed.
+	invalidations := testutil.ToFloat64(metrics.ItemCacheInvalidations.WithLabelValues("remote"))
+	deadline := time.Now().Add(time.Second)
+	for testutil.ToFloat64(metrics.ItemCacheInvalidations.WithLabelValues("remote")) == invalidations && time.Now().Before(deadline) {
+		broker.Publish(event.Event{Type: event.ItemUpdated, ItemID: uuid.New()})
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	_, _ = sut.GetItemByID(ctx, cached.ID)
+	broker.Publish(event.Event{Type: event.ItemUpdated, ItemID: cached.ID})
+	for sut.items.Contains(cached.ID) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if sut.items.Contains(cached.ID) {
+		t.Error("Expected the item to be invalidated by the event")
+	}
+}
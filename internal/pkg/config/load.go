@@ -0,0 +1,211 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/logging"
+)
+
+const (
+	// FileEnv names the environment variable holding the path of the configuration file, unless
+	// the --config flag is given.
+	FileEnv = "CONFIG_FILE"
+
+	// SecretFileSuffix is appended to the environment variable of a secret setting to name the
+	// variable holding the path of a file to read it from, such as a mounted Kubernetes secret.
+	SecretFileSuffix = "_FILE"
+)
+
+// Load returns the configuration given, in increasing order of precedence, by Default, the YAML or
+// TOML file named by --config or CONFIG_FILE, the environment as seen by lookupEnv, and the flags
+// in args, which are defined on flags. Empty environment variables are ignored. Secret settings
+// may instead be read from the file named by their environment variable suffixed with _FILE,
+// such as DATABASE_URL_FILE. The configuration is validated before it's returned.
+func Load(flags *flag.FlagSet, args []string, lookupEnv func(string) (string, bool)) (Config, error) {
+	config := Default()
+	settings := settingsOf(&config)
+
+	file := flags.String("config", "", "YAML or TOML configuration file. ("+FileEnv+")")
+	for _, s := range settings {
+		flags.Var(&flagValue{isBool: s.value.Kind() == reflect.Bool}, s.flag, s.usage+" ("+s.env+")")
+	}
+	if err := flags.Parse(args); err != nil {
+		return config, err
+	}
+
+	path := *file
+	if path == "" {
+		path, _ = lookupEnv(FileEnv)
+	}
+	if path != "" {
+		if err := loadFile(path, &config); err != nil {
+			return config, err
+		}
+	}
+
+	var errs []error
+	for _, s := range settings {
+		if value, ok := lookupEnv(s.env); ok && value != "" {
+			errs = append(errs, s.set(s.env, value))
+		} else if secretPath, ok := lookupEnv(s.env + SecretFileSuffix); ok && secretPath != "" && s.secret {
+			secret, err := os.ReadFile(secretPath)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s%s: %w", s.env, SecretFileSuffix, err))
+				continue
+			}
+			errs = append(errs, s.set(s.env+SecretFileSuffix, strings.TrimSpace(string(secret))))
+		}
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if s.flag == f.Name {
+				errs = append(errs, s.set("--"+f.Name, f.Value.String()))
+			}
+		}
+	})
+
+	if err := errors.Join(errs...); err != nil {
+		return config, err
+	}
+
+	return config, config.Validate()
+}
+
+// Print writes config to w as YAML, in the format Load reads, with its secret settings redacted.
+func Print(w io.Writer, config Config) error {
+	for _, s := range settingsOf(&config) {
+		if s.secret && !s.value.IsZero() {
+			s.value.SetString(logging.Redacted)
+		}
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func loadFile(path string, config *Config) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		decoder := yaml.NewDecoder(file)
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && err != io.EOF {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	case ".toml":
+		metadata, err := toml.DecodeFile(path, config)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("%s: unknown settings %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("%s: configuration files must be .yaml, .yml or .toml", path)
+	}
+
+	return nil
+}
+
+// setting is a configurable field of Config.
+type setting struct {
+	value  reflect.Value
+	env    string
+	flag   string
+	usage  string
+	secret bool
+}
+
+// settingsOf returns the settings of config, in the order they're declared.
+func settingsOf(config *Config) []setting {
+	var settings []setting
+
+	sections := reflect.ValueOf(config).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		section := sections.Field(i)
+		for j := 0; j < section.NumField(); j++ {
+			field := section.Type().Field(j)
+			settings = append(settings, setting{
+				value:  section.Field(j),
+				env:    field.Tag.Get("env"),
+				flag:   field.Tag.Get("flag"),
+				usage:  field.Tag.Get("usage"),
+				secret: field.Tag.Get("secret") == "true",
+			})
+		}
+	}
+
+	return settings
+}
+
+// set parses raw, given by source, into the setting.
+func (s setting) set(source string, raw string) error {
+	switch {
+	case s.value.Type() == reflect.TypeOf(time.Duration(0)):
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%s must be a duration such as 5s, not %q", source, raw)
+		}
+		s.value.SetInt(int64(duration))
+	case s.value.Kind() == reflect.Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false, not %q", source, raw)
+		}
+		s.value.SetBool(value)
+	case s.value.Kind() == reflect.Int:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%s must be a whole number, not %q", source, raw)
+		}
+		s.value.SetInt(int64(value))
+	default:
+		s.value.SetString(raw)
+	}
+
+	return nil
+}
+
+// flagValue holds a flag's raw value until the sources it overrides have been loaded.
+type flagValue struct {
+	raw    string
+	isBool bool
+}
+
+func (f *flagValue) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.raw
+}
+
+func (f *flagValue) Set(raw string) error {
+	f.raw = raw
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.isBool
+}
@@ -0,0 +1,72 @@
+package event
+
+import "sync"
+
+const subscriberBufferSize = 64
+
+// NewBroker ..
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[chan Event]struct{})}
+}
+
+// Broker fans events out to every subscriber within this process. A subscriber that falls too far
+// behind is dropped and its channel closed; it is expected to reconnect and catch up from the
+// event table.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+	closed      bool
+}
+
+// Subscribe returns a channel of events and a function that cancels the subscription.
+func (b *Broker) Subscribe() (<-chan Event, func()) {
+	events := make(chan Event, subscriberBufferSize)
+
+	b.mu.Lock()
+	if b.closed {
+		close(events)
+	} else {
+		b.subscribers[events] = struct{}{}
+	}
+	b.mu.Unlock()
+
+	return events, func() { b.remove(events) }
+}
+
+// Publish ..
+func (b *Broker) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			delete(b.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+}
+
+// Close closes every subscriber's channel, and the channel of any later subscriber, so that their
+// streams end.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for subscriber := range b.subscribers {
+		delete(b.subscribers, subscriber)
+		close(subscriber)
+	}
+}
+
+func (b *Broker) remove(events chan Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[events]; ok {
+		delete(b.subscribers, events)
+		close(events)
+	}
+}
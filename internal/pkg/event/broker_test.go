@@ -0,0 +1,74 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func Test_Broker_Publish_ShouldDeliverEventToEverySubscriber(t *testing.T) {
+	sut := NewBroker()
+
+	first, unsubscribeFirst := sut.Subscribe()
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := sut.Subscribe()
+	defer unsubscribeSecond()
+
+	published := Event{ID: 1, Type: ItemCreated, ItemID: uuid.New()}
+	sut.Publish(published)
+
+	for _, subscriber := range []<-chan Event{first, second} {
+		if received := <-subscriber; received.ID != published.ID {
+			t.Errorf("Expected event %d. Got %d", published.ID, received.ID)
+		}
+	}
+}
+
+func Test_Broker_Publish_WhenSubscriberFallsBehind_ShouldDropSubscriber(t *testing.T) {
+	sut := NewBroker()
+
+	events, unsubscribe := sut.Subscribe()
+	defer unsubscribe()
+
+	for i := 0; i <= subscriberBufferSize; i++ {
+		sut.Publish(Event{ID: int64(i + 1), Type: ItemUpdated})
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+
+	if received != subscriberBufferSize {
+		t.Errorf("Expected %d buffered events before the subscriber was dropped. Got %d", subscriberBufferSize, received)
+	}
+}
+
+func Test_Broker_Unsubscribe_ShouldCloseChannel(t *testing.T) {
+	sut := NewBroker()
+
+	events, unsubscribe := sut.Subscribe()
+	unsubscribe()
+	unsubscribe()
+
+	if _, ok := <-events; ok {
+		t.Errorf("Expected the subscription channel to be closed")
+	}
+}
+
+func Test_Broker_Close_ShouldCloseEverySubscriptionAndLaterOnes(t *testing.T) {
+	sut := NewBroker()
+
+	events, unsubscribe := sut.Subscribe()
+	sut.Close()
+	unsubscribe()
+
+	later, _ := sut.Subscribe()
+
+	if _, ok := <-events; ok {
+		t.Errorf("Expected the subscription channel to be closed")
+	}
+	if _, ok := <-later; ok {
+		t.Errorf("Expected a subscription after Close to be closed")
+	}
+}
@@ -0,0 +1,147 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Channel is the Postgres notification channel item events are announced on as they are added.
+const Channel = "item_events"
+
+const (
+	minReconnectInterval = 10 * time.Second
+	maxReconnectInterval = time.Minute
+	pingInterval         = 90 * time.Second
+	catchUpBatchSize     = 100
+)
+
+// NewListener ..
+func NewListener(dbSource string, repository Repository, broker *Broker) *Listener {
+	return &Listener{
+		DbSource:   dbSource,
+		Repository: repository,
+		Broker:     broker,
+		Now:        time.Now,
+		published:  NewSeen(),
+	}
+}
+
+// Listener relays Postgres notifications for item changes to a Broker, so every replica sees
+// every change regardless of which replica made it.
+type Listener struct {
+	DbSource   string
+	Repository Repository
+	Broker     *Broker
+	Now        func() time.Time
+
+	published *Seen
+}
+
+// Run blocks, relaying notifications until the context is cancelled. Events recorded from when it
+// starts are relayed, including those recorded before it is first listening or while it has lost
+// its connection.
+func (l *Listener) Run(ctx context.Context) {
+	listener := pq.NewListener(l.DbSource, minReconnectInterval, maxReconnectInterval, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.ErrorContext(ctx, "item event listener failed", "error", err)
+		}
+	})
+	defer listener.Close()
+
+	// Listen blocks until the connection is established, so closing the listener is what stops it.
+	stop := context.AfterFunc(ctx, func() { listener.Close() })
+	defer stop()
+
+	if l.published.watermark.IsZero() {
+		l.published.watermark = l.Now()
+	}
+
+	if !l.listen(ctx, listener) {
+		return
+	}
+	l.catchUp(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification := <-listener.Notify:
+			if notification == nil {
+				// The connection was re-established, so notifications may have been missed.
+				l.catchUp(ctx)
+				continue
+			}
+			l.relay(notification.Extra)
+		case <-time.After(pingInterval):
+			go listener.Ping()
+		}
+	}
+}
+
+// listen starts listening on Channel, retrying with backoff while Postgres refuses, and reports
+// whether it is listening before the context is cancelled.
+func (l *Listener) listen(ctx context.Context, listener *pq.Listener) bool {
+	backoff := minReconnectInterval
+	for {
+		err := listener.Listen(Channel)
+		if err == nil || errors.Is(err, pq.ErrChannelAlreadyOpen) {
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+
+		slog.ErrorContext(ctx, "unable to listen for item events", "channel", Channel, "retry_in", backoff, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxReconnectInterval)
+	}
+}
+
+func (l *Listener) relay(payload string) {
+	var notification notification
+	if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+		slog.Error("unable to decode item event", "error", err)
+		return
+	}
+
+	event := notification.Event
+	event.RecordedAt = notification.RecordedAt
+	l.publish(event)
+}
+
+func (l *Listener) catchUp(ctx context.Context) {
+	recordedAt, id := l.published.CatchUpFrom(), int64(0)
+	for {
+		events, err := l.Repository.GetEventsRecordedSince(ctx, recordedAt, id, catchUpBatchSize)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to catch up on item events", "since", recordedAt, "error", err)
+			return
+		}
+
+		for _, event := range events {
+			l.publish(event)
+		}
+
+		if len(events) < catchUpBatchSize {
+			return
+		}
+		last := events[len(events)-1]
+		recordedAt, id = last.RecordedAt, last.ID
+	}
+}
+
+func (l *Listener) publish(event Event) {
+	if l.published.Add(event) {
+		l.Broker.Publish(event)
+	}
+}
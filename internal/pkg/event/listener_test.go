@@ -0,0 +1,114 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func Test_Listener_Relay_ShouldPublishDecodedNotification(t *testing.T) {
+	broker := NewBroker()
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	itemID := uuid.New()
+	sut := NewListener("", &RepositoryMock{}, broker)
+
+	sut.relay(`{"id": 7, "type": "item.updated", "item_id": "` + itemID.String() + `", "data": {"price": 150}, "created_at": "2024-01-01T00:00:00.123456+00:00", "recorded_at": "2024-01-01T00:00:01.5+00:00"}`)
+
+	received := <-events
+	if received.ID != 7 || received.Type != ItemUpdated || received.ItemID != itemID {
+		t.Errorf("Unexpected event was published: %+v", received)
+	}
+
+	recordedAt := time.Date(2024, time.January, 1, 0, 0, 1, 500000000, time.UTC)
+	if !sut.published.watermark.Equal(recordedAt) {
+		t.Errorf("Expected the watermark to be %s. Got %s", recordedAt, sut.published.watermark)
+	}
+}
+
+func Test_Listener_CatchUp_ShouldReadBackOverTheOverlapAndSkipPublishedEvents(t *testing.T) {
+	broker := NewBroker()
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	watermark := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var recordedAtCalled time.Time
+	mockRepository := &RepositoryMock{
+		GetEventsRecordedSinceFunc: func(ctx context.Context, recordedAt time.Time, id int64, limit int64) ([]Event, error) {
+			recordedAtCalled = recordedAt
+			// Event 9 committed after event 10 was relayed, although it was recorded first.
+			return []Event{
+				{ID: 9, Type: ItemDeleted, RecordedAt: watermark.Add(-time.Second)},
+				{ID: 10, Type: ItemCreated, RecordedAt: watermark},
+				{ID: 11, Type: ItemUpdated, RecordedAt: watermark.Add(time.Second)},
+			}, nil
+		},
+	}
+
+	sut := NewListener("", mockRepository, broker)
+	sut.publish(Event{ID: 10, Type: ItemCreated, RecordedAt: watermark})
+	<-events
+
+	sut.catchUp(context.Background())
+
+	if expected := watermark.Add(-CatchUpOverlap); !recordedAtCalled.Equal(expected) {
+		t.Errorf("Expected to catch up from %s. Got %s", expected, recordedAtCalled)
+	}
+
+	for _, expected := range []int64{9, 11} {
+		if received := <-events; received.ID != expected {
+			t.Errorf("Expected event %d. Got %d", expected, received.ID)
+		}
+	}
+
+	if !sut.published.watermark.Equal(watermark.Add(time.Second)) {
+		t.Errorf("Expected the watermark to advance to the newest event. Got %s", sut.published.watermark)
+	}
+}
+
+func Test_Listener_CatchUp_WhenNoEventWasSeen_ShouldCatchUpFromWhenItStarted(t *testing.T) {
+	startedAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var recordedAtCalled time.Time
+	mockRepository := &RepositoryMock{
+		GetEventsRecordedSinceFunc: func(ctx context.Context, recordedAt time.Time, id int64, limit int64) ([]Event, error) {
+			recordedAtCalled = recordedAt
+			return nil, nil
+		},
+	}
+
+	sut := NewListener("", mockRepository, NewBroker())
+	sut.Now = func() time.Time { return startedAt }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sut.Run(ctx)
+
+	sut.catchUp(context.Background())
+
+	if expected := startedAt.Add(-CatchUpOverlap); !recordedAtCalled.Equal(expected) {
+		t.Errorf("Expected to catch up from %s. Got %s", expected, recordedAtCalled)
+	}
+}
+
+func Test_Listener_Publish_ShouldForgetEventsBeforeTheOverlap(t *testing.T) {
+	broker := NewBroker()
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	recordedAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	sut := NewListener("", &RepositoryMock{}, broker)
+
+	sut.publish(Event{ID: 1, RecordedAt: recordedAt})
+	<-events
+	sut.publish(Event{ID: 2, RecordedAt: recordedAt.Add(2 * CatchUpOverlap)})
+	<-events
+
+	if _, ok := sut.published.ids[1]; ok || len(sut.published.ids) != 1 {
+		t.Errorf("Expected only event 2 to be remembered. Got %v", sut.published.ids)
+	}
+}
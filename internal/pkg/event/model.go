@@ -0,0 +1,39 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Type ..
+type Type string
+
+const (
+	// ItemCreated ..
+	ItemCreated Type = "item.created"
+
+	// ItemUpdated ..
+	ItemUpdated Type = "item.updated"
+
+	// ItemDeleted ..
+	ItemDeleted Type = "item.deleted"
+)
+
+// Event is an item change. RecordedAt is when it was recorded as an event, by the database's
+// clock, which listeners catch up from; it isn't part of the event as it's published.
+type Event struct {
+	ID         int64           `json:"id"`
+	Type       Type            `json:"type"`
+	ItemID     uuid.UUID       `json:"item_id"`
+	Data       json.RawMessage `json:"data"`
+	CreatedAt  time.Time       `json:"created_at"`
+	RecordedAt time.Time       `json:"-"`
+}
+
+// notification is what Channel is notified with: the event along with when it was recorded.
+type notification struct {
+	Event
+	RecordedAt time.Time `json:"recorded_at"`
+}
@@ -0,0 +1,50 @@
+package event
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// NewPruner ..
+func NewPruner(repository Repository, retention time.Duration, interval time.Duration) *Pruner {
+	return &Pruner{Repository: repository, Retention: retention, Interval: interval, Now: time.Now}
+}
+
+// Pruner periodically deletes item events once they are older than Retention, after which they
+// can no longer be replayed to event streams.
+type Pruner struct {
+	Repository Repository
+	Retention  time.Duration
+	Interval   time.Duration
+	Now        func() time.Time
+}
+
+// Run blocks, pruning item events every interval until the context is cancelled.
+func (p *Pruner) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.Tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick ..
+func (p *Pruner) Tick(ctx context.Context) {
+	pruned, err := p.Repository.PruneEvents(ctx, p.Now().Add(-p.Retention))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to prune item events", "error", err)
+		return
+	}
+
+	if pruned > 0 {
+		slog.InfoContext(ctx, "pruned item events", "count", pruned)
+	}
+}
@@ -0,0 +1,27 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_Pruner_Tick_ShouldPruneEventsOlderThanRetention(t *testing.T) {
+	now := time.Date(2024, time.January, 8, 0, 0, 0, 0, time.UTC)
+
+	mockRepository := &RepositoryMock{
+		PruneEventsFunc: func(ctx context.Context, before time.Time) (int64, error) {
+			return 2, nil
+		},
+	}
+
+	sut := NewPruner(mockRepository, 7*24*time.Hour, time.Hour)
+	sut.Now = func() time.Time { return now }
+
+	sut.Tick(context.Background())
+
+	calls := mockRepository.PruneEventsCalls()
+	if len(calls) != 1 || !calls[0].Before.Equal(now.Add(-7*24*time.Hour)) {
+		t.Errorf("Expected events recorded before %s to be pruned. Got %+v", now.Add(-7*24*time.Hour), calls)
+	}
+}
@@ -0,0 +1,49 @@
+package event
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/outbox"
+)
+
+// FromMessage returns the item event an outbox message describes. Its id is the message's, so
+// the event keeps the same id however many times the message is relayed.
+func FromMessage(message outbox.Message) (Event, error) {
+	itemID, err := uuid.Parse(message.Key)
+	if err != nil {
+		return Event{}, fmt.Errorf("outbox message %d is not keyed by an item id: %w", message.ID, err)
+	}
+
+	return Event{
+		ID:        message.ID,
+		Type:      Type(message.Topic),
+		ItemID:    itemID,
+		Data:      message.Payload,
+		CreatedAt: message.CreatedAt,
+	}, nil
+}
+
+// NewOutboxPublisher ..
+func NewOutboxPublisher(repository Repository) *OutboxPublisher {
+	return &OutboxPublisher{Repository: repository}
+}
+
+// OutboxPublisher records each relayed outbox message as an item event, which every replica's
+// Listener is notified of.
+type OutboxPublisher struct {
+	Repository Repository
+}
+
+// Publish ..
+func (p *OutboxPublisher) Publish(ctx context.Context, message outbox.Message) error {
+	event, err := FromMessage(message)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.Repository.AddEvent(ctx, event)
+	return err
+}
@@ -0,0 +1,40 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/outbox"
+)
+
+func Test_OutboxPublisher_Publish_ShouldAddTheMessageAsAnEvent(t *testing.T) {
+	itemID := uuid.New()
+	message := outbox.Message{ID: 42, Topic: "item.deleted", Key: itemID.String(), Payload: []byte(`{"id":"` + itemID.String() + `"}`), CreatedAt: time.Now()}
+	mockRepository := &RepositoryMock{
+		AddEventFunc: func(ctx context.Context, event Event) (bool, error) {
+			return true, nil
+		},
+	}
+	sut := NewOutboxPublisher(mockRepository)
+
+	if err := sut.Publish(context.Background(), message); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	calls := mockRepository.AddEventCalls()
+	if len(calls) != 1 {
+		t.Fatalf("Expected a single event to be added. Got %d", len(calls))
+	}
+	if added := calls[0].Event; added.ID != 42 || added.Type != ItemDeleted || added.ItemID != itemID || string(added.Data) != string(message.Payload) {
+		t.Errorf("Unexpected event %+v", added)
+	}
+}
+
+func Test_FromMessage_WhenKeyIsNotAnItemID_ShouldReturnError(t *testing.T) {
+	if _, err := FromMessage(outbox.Message{ID: 1, Topic: "item.created", Key: "not-a-uuid"}); err == nil {
+		t.Error("Expected an error")
+	}
+}
@@ -0,0 +1,119 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Repository ..
+type Repository interface {
+	GetEvent(ctx context.Context, id int64) (Event, error)
+	GetEventsRecordedSince(ctx context.Context, recordedAt time.Time, id int64, limit int64) ([]Event, error)
+	AddEvent(ctx context.Context, event Event) (bool, error)
+	PruneEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+// NewRepository ..
+func NewRepository(DBConn *sql.DB) Repository {
+	return &repository{DBConn: DBConn}
+}
+
+// repository ..
+type repository struct {
+	DBConn *sql.DB
+}
+
+// GetEvent ..
+func (r *repository) GetEvent(ctx context.Context, id int64) (Event, error) {
+	var event Event
+	var data []byte
+	row := r.DBConn.QueryRowContext(ctx, "SELECT id, type, item_id, data, created_at, recorded_at FROM item_event WHERE id = $1", id)
+	err := row.Scan(&event.ID, &event.Type, &event.ItemID, &data, &event.CreatedAt, &event.RecordedAt)
+	if err != nil {
+		return Event{}, err
+	}
+	event.Data = data
+
+	return event, nil
+}
+
+// GetEventsRecordedSince returns the events recorded after the event with the given recorded_at
+// and id, in the order they were recorded.
+func (r *repository) GetEventsRecordedSince(ctx context.Context, recordedAt time.Time, id int64, limit int64) ([]Event, error) {
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT id, type, item_id, data, created_at, recorded_at FROM item_event WHERE (recorded_at, id) > ($1, $2) ORDER BY recorded_at, id LIMIT $3", recordedAt, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+// AddEvent records event and notifies Channel of it in the same transaction, reporting whether it
+// was new. An event that was already recorded is left alone and not notified again, so an event
+// may be added more than once.
+func (r *repository) AddEvent(ctx context.Context, event Event) (bool, error) {
+	tx, err := r.DBConn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	insertStm := "INSERT INTO item_event (id, type, item_id, data, created_at) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (id) DO NOTHING RETURNING recorded_at"
+	err = tx.QueryRowContext(ctx, insertStm, event.ID, string(event.Type), event.ItemID, string(event.Data), event.CreatedAt).Scan(&event.RecordedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return false, nil
+	} else if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	payload, err := json.Marshal(notification{Event: event, RecordedAt: event.RecordedAt})
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, "SELECT pg_notify($1, $2)", Channel, string(payload))
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, nil
+}
+
+// PruneEvents deletes the events recorded before before, returning how many there were.
+func (r *repository) PruneEvents(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.DBConn.ExecContext(ctx, "DELETE FROM item_event WHERE recorded_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func scanEvents(rows *sql.Rows) ([]Event, error) {
+	payload := make([]Event, 0)
+	for rows.Next() {
+		var data []byte
+		event := new(Event)
+		err := rows.Scan(&event.ID, &event.Type, &event.ItemID, &data, &event.CreatedAt, &event.RecordedAt)
+		if err != nil {
+			return nil, err
+		}
+		event.Data = data
+		payload = append(payload, *event)
+	}
+
+	return payload, rows.Err()
+}
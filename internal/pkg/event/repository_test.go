@@ -0,0 +1,168 @@
+package event
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+var eventColumns = []string{"id", "type", "item_id", "data", "created_at", "recorded_at"}
+
+func Test_EventRepository_GetEvent_ShouldReturnTheEventWithID(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	itemID := uuid.New()
+
+	mock.ExpectQuery("SELECT id, type, item_id, data, created_at, recorded_at FROM item_event WHERE id = \\$1").
+		WithArgs(6).
+		WillReturnRows(
+			sqlmock.NewRows(eventColumns).
+				AddRow(6, "item.created", itemID, []byte(`{"name":"Lens"}`), time.Now(), time.Now()),
+		).
+		RowsWillBeClosed()
+
+	sut := NewRepository(dbConn)
+
+	result, err := sut.GetEvent(context.Background(), 6)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when fetching an event", err)
+	}
+
+	if result.ID != 6 || result.Type != ItemCreated || result.ItemID != itemID || string(result.Data) != `{"name":"Lens"}` {
+		t.Fatalf("Unexpected event was given, '%+v'.", result)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_EventRepository_GetEventsRecordedSince_ShouldReturnEventsInTheOrderTheyWereRecorded(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	since := time.Now().Add(-time.Minute)
+	recordedAt := time.Now()
+
+	mock.ExpectQuery("SELECT id, type, item_id, data, created_at, recorded_at FROM item_event WHERE \\(recorded_at, id\\) > \\(\\$1, \\$2\\) ORDER BY recorded_at, id LIMIT \\$3").
+		WithArgs(since, 0, 100).
+		WillReturnRows(
+			sqlmock.NewRows(eventColumns).
+				AddRow(9, "item.deleted", uuid.New(), []byte(`{}`), time.Now(), recordedAt),
+		).
+		RowsWillBeClosed()
+
+	sut := NewRepository(dbConn)
+
+	result, err := sut.GetEventsRecordedSince(context.Background(), since, 0, 100)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when fetching events", err)
+	}
+
+	if len(result) != 1 || result[0].ID != 9 || !result[0].RecordedAt.Equal(recordedAt) {
+		t.Fatalf("Unexpected events were given, '%+v'.", result)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_EventRepository_AddEvent_WhenEventIsNew_ShouldRecordAndNotifyIt(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	event := Event{ID: 7, Type: ItemUpdated, ItemID: uuid.New(), Data: []byte(`{"name":"Lens"}`), CreatedAt: time.Now()}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO item_event \\(id, type, item_id, data, created_at\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5\\) ON CONFLICT \\(id\\) DO NOTHING RETURNING recorded_at").
+		WithArgs(event.ID, string(event.Type), event.ItemID, string(event.Data), event.CreatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"recorded_at"}).AddRow(time.Date(2024, time.January, 1, 0, 0, 1, 0, time.UTC)))
+	mock.ExpectExec("SELECT pg_notify\\(\\$1, \\$2\\)").
+		WithArgs(Channel, notificationWith(`"recorded_at":"2024-01-01T00:00:01Z"`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	sut := NewRepository(dbConn)
+
+	added, err := sut.AddEvent(context.Background(), event)
+	if err != nil || !added {
+		t.Fatalf("Expected the event to be added. Got %t, %v", added, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_EventRepository_AddEvent_WhenEventWasAlreadyAdded_ShouldNotNotifyAgain(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO item_event").
+		WillReturnRows(sqlmock.NewRows([]string{"recorded_at"}))
+	mock.ExpectRollback()
+
+	sut := NewRepository(dbConn)
+
+	added, err := sut.AddEvent(context.Background(), Event{ID: 7, Type: ItemUpdated, ItemID: uuid.New(), Data: []byte(`{}`)})
+	if err != nil || added {
+		t.Fatalf("Expected the event not to be added again. Got %t, %v", added, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_EventRepository_PruneEvents_ShouldDeleteEventsRecordedBefore(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	before := time.Now().Add(-7 * 24 * time.Hour)
+
+	mock.ExpectExec("DELETE FROM item_event WHERE recorded_at < \\$1").
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	sut := NewRepository(dbConn)
+
+	pruned, err := sut.PruneEvents(context.Background(), before)
+	if err != nil || pruned != 3 {
+		t.Fatalf("Expected 3 events to be pruned. Got %d, %v", pruned, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+type notificationWith string
+
+// Match ..
+func (n notificationWith) Match(value driver.Value) bool {
+	payload, ok := value.(string)
+	return ok && strings.Contains(payload, string(n))
+}
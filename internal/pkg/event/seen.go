@@ -0,0 +1,55 @@
+package event
+
+import "time"
+
+// CatchUpOverlap is how long before the newest event seen catching up starts from. Events are
+// recorded before they commit, so one recorded earlier may still commit later; reading back over
+// the overlap picks those up, and events already seen are skipped.
+const CatchUpOverlap = time.Minute
+
+// NewSeen ..
+func NewSeen() *Seen {
+	return &Seen{ids: make(map[int64]time.Time)}
+}
+
+// Seen tracks the events read while catching up on, and then following, the event table, so that
+// each is only handled once however many times it is read.
+type Seen struct {
+	// watermark is when the newest event seen was recorded, and ids holds the ids of those recorded
+	// within CatchUpOverlap of it.
+	watermark time.Time
+	ids       map[int64]time.Time
+}
+
+// Add reports whether event hasn't been seen before, remembering it if so.
+func (s *Seen) Add(event Event) bool {
+	if _, ok := s.ids[event.ID]; ok {
+		return false
+	}
+	s.ids[event.ID] = event.RecordedAt
+
+	if event.RecordedAt.After(s.watermark) {
+		s.watermark = event.RecordedAt
+		s.forget()
+	}
+	return true
+}
+
+// CatchUpFrom returns when events should be read back from to catch up on any that have been
+// missed, or the zero time if no event has been seen.
+func (s *Seen) CatchUpFrom() time.Time {
+	if s.watermark.IsZero() {
+		return time.Time{}
+	}
+	return s.watermark.Add(-CatchUpOverlap)
+}
+
+// forget drops the ids of events recorded before the overlap, which catching up no longer reads.
+func (s *Seen) forget() {
+	horizon := s.watermark.Add(-CatchUpOverlap)
+	for id, recordedAt := range s.ids {
+		if recordedAt.Before(horizon) {
+			delete(s.ids, id)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// DatabaseCheck passes while db can be pinged.
+func DatabaseCheck(db *sql.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// MigrationsCheck passes once every one of versions has been applied to db, as recorded in the
+// schema_migrations table.
+func MigrationsCheck(db *sql.DB, versions []string) CheckFunc {
+	return func(ctx context.Context) error {
+		rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		applied := make(map[string]bool)
+		for rows.Next() {
+			var version string
+			if err := rows.Scan(&version); err != nil {
+				return err
+			}
+			applied[version] = true
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		var pending []string
+		for _, version := range versions {
+			if !applied[version] {
+				pending = append(pending, version)
+			}
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("migrations not applied: %s", strings.Join(pending, ", "))
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,123 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// StatusUp is the status of a passing check, and of a report whose checks all pass.
+	StatusUp = "up"
+
+	// StatusDown is the status of a failing check, and of a report with any failing check.
+	StatusDown = "down"
+
+	// ShutdownCheckName names the check that fails once graceful shutdown has begun.
+	ShutdownCheckName = "shutdown"
+)
+
+// CheckFunc checks a dependency of the service, returning why it's unusable, if it is.
+type CheckFunc func(ctx context.Context) error
+
+// Result ..
+type Result struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report ..
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+// Up is whether every check in the report passed.
+func (r Report) Up() bool {
+	return r.Status == StatusUp
+}
+
+type check struct {
+	name  string
+	check CheckFunc
+}
+
+// New returns a Health whose checks are each given timeout to finish.
+func New(timeout time.Duration) *Health {
+	return &Health{Timeout: timeout}
+}
+
+// Health runs the checks that decide whether the service is ready to serve requests.
+type Health struct {
+	Timeout      time.Duration
+	checks       []check
+	shuttingDown atomic.Bool
+}
+
+// Register adds a check named name. Checks should be registered before Check is first called.
+func (h *Health) Register(name string, checkFunc CheckFunc) {
+	h.checks = append(h.checks, check{name: name, check: checkFunc})
+}
+
+// Shutdown marks the service as shutting down, failing every later report so that it's taken out
+// of rotation while it drains.
+func (h *Health) Shutdown() {
+	h.shuttingDown.Store(true)
+}
+
+// Check runs every registered check concurrently and reports their results in the order they
+// were registered, followed by whether the service is shutting down.
+func (h *Health) Check(ctx context.Context) Report {
+	results := make([]Result, len(h.checks), len(h.checks)+1)
+
+	var wg sync.WaitGroup
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func(i int, c check) {
+			defer wg.Done()
+			results[i] = h.run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	shutdown := Result{Name: ShutdownCheckName, Status: StatusUp}
+	if h.shuttingDown.Load() {
+		shutdown.Status = StatusDown
+		shutdown.Error = "the service is shutting down"
+	}
+	results = append(results, shutdown)
+
+	report := Report{Status: StatusUp, Checks: results}
+	for _, result := range results {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+func (h *Health) run(ctx context.Context, c check) Result {
+	ctx, cancel := context.WithTimeout(ctx, h.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.check(ctx)
+	result := Result{
+		Name:      c.name,
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,99 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func Test_Health_Check_WhenEveryCheckPasses_ShouldReportUp(t *testing.T) {
+	sut := New(time.Second)
+	sut.Register("database", func(ctx context.Context) error { return nil })
+
+	report := sut.Check(context.Background())
+
+	if !report.Up() || len(report.Checks) != 2 {
+		t.Fatalf("Expected the report to be up with 2 checks. Got %+v", report)
+	}
+	if report.Checks[0].Name != "database" || report.Checks[1].Name != ShutdownCheckName {
+		t.Errorf("Expected the registered check and then the shutdown check. Got %+v", report.Checks)
+	}
+}
+
+func Test_Health_Check_WhenACheckFails_ShouldReportDownWithItsError(t *testing.T) {
+	sut := New(time.Second)
+	sut.Register("database", func(ctx context.Context) error { return errors.New("connection refused") })
+	sut.Register("cache", func(ctx context.Context) error { return nil })
+
+	report := sut.Check(context.Background())
+
+	if report.Up() {
+		t.Fatalf("Expected the report to be down. Got %+v", report)
+	}
+	if result := report.Checks[0]; result.Status != StatusDown || result.Error != "connection refused" {
+		t.Errorf("Expected the database check to be down with its error. Got %+v", result)
+	}
+	if result := report.Checks[1]; result.Status != StatusUp {
+		t.Errorf("Expected the cache check to be up. Got %+v", result)
+	}
+}
+
+func Test_Health_Check_WhenACheckTimesOut_ShouldReportItDown(t *testing.T) {
+	sut := New(10 * time.Millisecond)
+	sut.Register("database", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := sut.Check(context.Background())
+
+	if result := report.Checks[0]; result.Status != StatusDown || result.Error != context.DeadlineExceeded.Error() {
+		t.Errorf("Expected the database check to time out. Got %+v", result)
+	}
+}
+
+func Test_Health_Check_WhenShuttingDown_ShouldReportDown(t *testing.T) {
+	sut := New(time.Second)
+	sut.Shutdown()
+
+	report := sut.Check(context.Background())
+
+	if report.Up() || report.Checks[0].Name != ShutdownCheckName || report.Checks[0].Status != StatusDown {
+		t.Errorf("Expected the shutdown check to be down. Got %+v", report)
+	}
+}
+
+func Test_MigrationsCheck_WhenMigrationsArePending_ShouldFail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT version FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("20190626153002"))
+
+	err = MigrationsCheck(db, []string{"20190626153002", "20261019100000"})(context.Background())
+
+	if err == nil || err.Error() != "migrations not applied: 20261019100000" {
+		t.Errorf("Expected the pending migration to be reported. Got %v", err)
+	}
+}
+
+func Test_MigrationsCheck_WhenEveryMigrationIsApplied_ShouldPass(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT version FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("20190626153002").AddRow("20261019100000"))
+
+	if err := MigrationsCheck(db, []string{"20190626153002", "20261019100000"})(context.Background()); err != nil {
+		t.Errorf("Error '%s' was not expected when every migration is applied", err)
+	}
+}
@@ -0,0 +1,386 @@
+package item
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	driver "github.com/tjmaynes/shopping-cart-service-go/internal/driver"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/config"
+)
+
+// backends returns every Repository implementation. Postgres is only included when DATABASE_URL
+// names a migrated database, which may already hold items, so each case only asserts on the
+// items it adds, telling them apart by a manufacturer of its own.
+func backends(t *testing.T) map[string]func(t *testing.T) Repository {
+	all := map[string]func(t *testing.T) Repository{
+		"memory": func(t *testing.T) Repository {
+			return NewMemoryRepository()
+		},
+		"sqlite": func(t *testing.T) Repository {
+			dbConn, err := driver.ConnectSQLite("sqlite::memory:")
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a SQLite database", err)
+			}
+			t.Cleanup(func() { dbConn.Close() })
+			return NewSQLiteRepository(dbConn)
+		},
+	}
+
+	if url := os.Getenv("DATABASE_URL"); url != "" && driver.SchemeOf(url) == driver.SchemePostgres {
+		all["postgres"] = func(t *testing.T) Repository {
+			cfg := config.Default().Database
+			cfg.URL = url
+			dbConn, err := driver.ConnectDB(cfg)
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when connecting to the database", err)
+			}
+			t.Cleanup(func() { dbConn.Close() })
+			return NewRepository(dbConn)
+		}
+	} else {
+		t.Log("DATABASE_URL isn't set, skipping the postgres backend")
+	}
+
+	return all
+}
+
+var conformanceCases = map[string]func(t *testing.T, r Repository){
+	"AddItem_ShouldBeFoundByID":                                 testAddItemShouldBeFoundByID,
+	"GetItemByID_WhenItemDoesNotExist_ShouldReturnErrNoRows":    testGetItemByIDWhenItemDoesNotExist,
+	"FindItems_ShouldFilterAndPageItemsInIDOrder":               testFindItemsShouldFilterAndPage,
+	"GetItemsByIDs_ShouldSkipUnknownIDs":                        testGetItemsByIDsShouldSkipUnknownIDs,
+	"UpdateItem_WhenPriceChanges_ShouldRecordPriceHistory":      testUpdateItemWhenPriceChanges,
+	"UpdateItem_WhenItemDoesNotExist_ShouldReturnErrNoRows":     testUpdateItemWhenItemDoesNotExist,
+	"RemoveItem_ShouldRemoveItemAndItsPrices":                   testRemoveItemShouldRemoveItemAndItsPrices,
+	"AddScheduledPrice_WhenItemDoesNotExist_ShouldReturnError":  testAddScheduledPriceWhenItemDoesNotExist,
+	"ApplyScheduledPrices_ShouldApplyDuePricesOnlyOnce":         testApplyScheduledPricesShouldApplyDuePricesOnlyOnce,
+	"GetPriceHistoryByItemIDs_ShouldReturnHistoryOfEveryItem":   testGetPriceHistoryByItemIDs,
+	"GetScheduledPricesByItemIDs_ShouldReturnPendingPricesOnly": testGetScheduledPricesByItemIDs,
+}
+
+func Test_Repository_EveryBackend_ShouldConform(t *testing.T) {
+	for backend, newRepository := range backends(t) {
+		t.Run(backend, func(t *testing.T) {
+			for name, test := range conformanceCases {
+				t.Run(name, func(t *testing.T) {
+					test(t, newRepository(t))
+				})
+			}
+		})
+	}
+}
+
+func testAddItemShouldBeFoundByID(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	added, err := r.AddItem(ctx, "Apple", 99, newManufacturer())
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when adding an item", err)
+	}
+
+	found, err := r.GetItemByID(ctx, added.ID)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when getting the item", err)
+	}
+	if found != added {
+		t.Errorf("Expected %+v. Got %+v", added, found)
+	}
+
+	history, err := r.GetPriceHistory(ctx, added.ID, 0, 10)
+	if err != nil || len(history) != 1 || history[0].Price != 99 {
+		t.Errorf("Expected the initial price to be recorded. Got %+v, %v", history, err)
+	}
+}
+
+func testGetItemByIDWhenItemDoesNotExist(t *testing.T, r Repository) {
+	_, err := r.GetItemByID(context.Background(), uuid.New())
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows. Got %v", err)
+	}
+}
+
+func testFindItemsShouldFilterAndPage(t *testing.T, r Repository) {
+	ctx := context.Background()
+	manufacturer := newManufacturer()
+	mustAddItem(t, r, "Red Apple", 100, manufacturer)
+	mustAddItem(t, r, "green apple", 200, manufacturer)
+	mustAddItem(t, r, "Pear", 300, manufacturer)
+	mustAddItem(t, r, "Apple", 100, newManufacturer())
+
+	for _, tc := range []struct {
+		filter   Filter
+		expected int
+	}{
+		{Filter{Manufacturer: manufacturer}, 3},
+		{Filter{Manufacturer: manufacturer, Name: "APPLE"}, 2},
+		{Filter{Manufacturer: manufacturer, MinPrice: 150}, 2},
+		{Filter{Manufacturer: manufacturer, MaxPrice: 150}, 1},
+		{Filter{Manufacturer: manufacturer, MinPrice: 150, MaxPrice: 250}, 1},
+	} {
+		items, err := r.FindItems(ctx, tc.filter, 0, 10)
+		if err != nil {
+			t.Fatalf("Error '%s' was not expected when finding items", err)
+		}
+		if len(items) != tc.expected {
+			t.Errorf("Expected %d items for %+v. Got %+v", tc.expected, tc.filter, items)
+		}
+		for i := 1; i < len(items); i++ {
+			if bytes.Compare(items[i-1].ID[:], items[i].ID[:]) >= 0 {
+				t.Errorf("Expected items in id order. Got %+v", items)
+			}
+		}
+	}
+
+	firstPage, err := r.FindItems(ctx, Filter{Manufacturer: manufacturer}, 0, 2)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when finding items", err)
+	}
+	secondPage, err := r.FindItems(ctx, Filter{Manufacturer: manufacturer}, 1, 2)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when finding items", err)
+	}
+	if len(firstPage) != 2 || len(secondPage) != 1 || secondPage[0] == firstPage[0] || secondPage[0] == firstPage[1] {
+		t.Errorf("Expected the items to be split across pages. Got %+v and %+v", firstPage, secondPage)
+	}
+}
+
+func testGetItemsByIDsShouldSkipUnknownIDs(t *testing.T, r Repository) {
+	manufacturer := newManufacturer()
+	apple := mustAddItem(t, r, "Apple", 100, manufacturer)
+	pear := mustAddItem(t, r, "Pear", 200, manufacturer)
+
+	items, err := r.GetItemsByIDs(context.Background(), []uuid.UUID{pear.ID, uuid.New(), apple.ID})
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when getting items", err)
+	}
+
+	found := make(map[Item]bool)
+	for _, item := range items {
+		found[item] = true
+	}
+	if len(items) != 2 || !found[apple] || !found[pear] {
+		t.Errorf("Expected %+v and %+v. Got %+v", apple, pear, items)
+	}
+}
+
+func testUpdateItemWhenPriceChanges(t *testing.T, r Repository) {
+	ctx := context.Background()
+	item := mustAddItem(t, r, "Apple", 100, newManufacturer())
+
+	item.Name = "Green Apple"
+	if _, err := r.UpdateItem(ctx, &item); err != nil {
+		t.Fatalf("Error '%s' was not expected when updating the item", err)
+	}
+	item.Price = 150
+	updated, err := r.UpdateItem(ctx, &item)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when updating the item", err)
+	}
+
+	found, err := r.GetItemByID(ctx, item.ID)
+	if err != nil || found != item || updated != item {
+		t.Errorf("Expected %+v. Got %+v and %+v, %v", item, updated, found, err)
+	}
+
+	history, err := r.GetPriceHistory(ctx, item.ID, 0, 10)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when getting the price history", err)
+	}
+	if len(history) != 2 || history[0].Price != 150 || history[1].Price != 100 {
+		t.Errorf("Expected only price changes, newest first. Got %+v", history)
+	}
+
+	page, err := r.GetPriceHistory(ctx, item.ID, 1, 1)
+	if err != nil || len(page) != 1 || page[0].Price != 100 {
+		t.Errorf("Expected the oldest price on the second page. Got %+v, %v", page, err)
+	}
+}
+
+func testUpdateItemWhenItemDoesNotExist(t *testing.T, r Repository) {
+	_, err := r.UpdateItem(context.Background(), &Item{ID: uuid.New(), Name: "Apple", Price: 100, Manufacturer: newManufacturer()})
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows. Got %v", err)
+	}
+}
+
+func testRemoveItemShouldRemoveItemAndItsPrices(t *testing.T, r Repository) {
+	ctx := context.Background()
+	item := mustAddItem(t, r, "Apple", 100, newManufacturer())
+	if _, err := r.AddScheduledPrice(ctx, item.ID, 50, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Error '%s' was not expected when scheduling a price", err)
+	}
+
+	id, err := r.RemoveItem(ctx, item.ID)
+	if err != nil || id != item.ID {
+		t.Fatalf("Expected %s to be removed. Got %s, %v", item.ID, id, err)
+	}
+
+	if _, err := r.GetItemByID(ctx, item.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows. Got %v", err)
+	}
+	if history, err := r.GetPriceHistory(ctx, item.ID, 0, 10); err != nil || len(history) != 0 {
+		t.Errorf("Expected no price history. Got %+v, %v", history, err)
+	}
+	if scheduled, err := r.GetScheduledPrices(ctx, item.ID); err != nil || len(scheduled) != 0 {
+		t.Errorf("Expected no scheduled prices. Got %+v, %v", scheduled, err)
+	}
+}
+
+func testAddScheduledPriceWhenItemDoesNotExist(t *testing.T, r Repository) {
+	_, err := r.AddScheduledPrice(context.Background(), uuid.New(), 100, time.Now().Add(time.Hour))
+
+	if err == nil {
+		t.Error("Expected an error when scheduling a price for an unknown item")
+	}
+}
+
+func testApplyScheduledPricesShouldApplyDuePricesOnlyOnce(t *testing.T, r Repository) {
+	ctx := context.Background()
+	item := mustAddItem(t, r, "Apple", 100, newManufacturer())
+	// Whole seconds survive every backend's precision.
+	soon := time.Now().Add(time.Hour).Truncate(time.Second)
+	later := soon.Add(time.Hour)
+
+	laterPrice, err := r.AddScheduledPrice(ctx, item.ID, 80, later)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when scheduling a price", err)
+	}
+	soonPrice, err := r.AddScheduledPrice(ctx, item.ID, 90, soon)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when scheduling a price", err)
+	}
+
+	pending, err := r.GetScheduledPrices(ctx, item.ID)
+	if err != nil || len(pending) != 2 || pending[0].ID != soonPrice.ID || pending[1].ID != laterPrice.ID {
+		t.Fatalf("Expected both prices, soonest first. Got %+v, %v", pending, err)
+	}
+
+	now := soon.Add(time.Minute)
+	applied, err := r.ApplyScheduledPrices(ctx, now)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when applying scheduled prices", err)
+	}
+	if appliedOf(applied, item.ID) != 1 {
+		t.Errorf("Expected only the due price to be applied. Got %+v", applied)
+	}
+	for _, scheduledPrice := range applied {
+		if scheduledPrice.ID == soonPrice.ID && (scheduledPrice.AppliedAt == nil || !scheduledPrice.AppliedAt.Equal(now)) {
+			t.Errorf("Expected it to be applied at %s. Got %+v", now, scheduledPrice)
+		}
+	}
+
+	found, err := r.GetItemByID(ctx, item.ID)
+	if err != nil || found.Price != 90 {
+		t.Errorf("Expected the price to be 90. Got %+v, %v", found, err)
+	}
+
+	history, err := r.GetPriceHistory(ctx, item.ID, 0, 10)
+	if err != nil || len(history) != 2 || history[0].Price != 90 || !history[0].EffectiveAt.Equal(soon) {
+		t.Errorf("Expected the applied price to be recorded as effective at %s. Got %+v, %v", soon, history, err)
+	}
+
+	pending, err = r.GetScheduledPrices(ctx, item.ID)
+	if err != nil || len(pending) != 1 || pending[0].ID != laterPrice.ID {
+		t.Errorf("Expected only the later price to be pending. Got %+v, %v", pending, err)
+	}
+
+	applied, err = r.ApplyScheduledPrices(ctx, now)
+	if err != nil || appliedOf(applied, item.ID) != 0 {
+		t.Errorf("Expected nothing more to be applied. Got %+v, %v", applied, err)
+	}
+}
+
+func testGetPriceHistoryByItemIDs(t *testing.T, r Repository) {
+	ctx := context.Background()
+	manufacturer := newManufacturer()
+	apple := mustAddItem(t, r, "Apple", 100, manufacturer)
+	pear := mustAddItem(t, r, "Pear", 200, manufacturer)
+	pear.Price = 250
+	if _, err := r.UpdateItem(ctx, &pear); err != nil {
+		t.Fatalf("Error '%s' was not expected when updating the item", err)
+	}
+
+	history, err := r.GetPriceHistoryByItemIDs(ctx, []uuid.UUID{apple.ID, pear.ID})
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when getting the price history", err)
+	}
+
+	counts := make(map[uuid.UUID]int)
+	for i, change := range history {
+		counts[change.ItemID]++
+		if i > 0 && change.EffectiveAt.After(history[i-1].EffectiveAt) {
+			t.Errorf("Expected the newest first. Got %+v", history)
+		}
+	}
+	if len(history) != 3 || counts[apple.ID] != 1 || counts[pear.ID] != 2 {
+		t.Errorf("Expected the history of both items. Got %+v", history)
+	}
+
+	if history, err := r.GetPriceHistoryByItemIDs(ctx, nil); err != nil || len(history) != 0 {
+		t.Errorf("Expected no price history for no items. Got %+v, %v", history, err)
+	}
+}
+
+func testGetScheduledPricesByItemIDs(t *testing.T, r Repository) {
+	ctx := context.Background()
+	manufacturer := newManufacturer()
+	apple := mustAddItem(t, r, "Apple", 100, manufacturer)
+	pear := mustAddItem(t, r, "Pear", 200, manufacturer)
+	soon := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	for _, item := range []Item{apple, pear} {
+		if _, err := r.AddScheduledPrice(ctx, item.ID, 50, soon); err != nil {
+			t.Fatalf("Error '%s' was not expected when scheduling a price", err)
+		}
+	}
+	if _, err := r.AddScheduledPrice(ctx, pear.ID, 60, soon.Add(time.Hour)); err != nil {
+		t.Fatalf("Error '%s' was not expected when scheduling a price", err)
+	}
+
+	scheduled, err := r.GetScheduledPricesByItemIDs(ctx, []uuid.UUID{apple.ID, pear.ID})
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when getting scheduled prices", err)
+	}
+	if len(scheduled) != 3 || scheduled[2].Price != 60 {
+		t.Errorf("Expected every pending price, soonest first. Got %+v", scheduled)
+	}
+	for _, scheduledPrice := range scheduled {
+		if scheduledPrice.AppliedAt != nil || !scheduledPrice.EffectiveAt.Equal(soon) && !scheduledPrice.EffectiveAt.Equal(soon.Add(time.Hour)) {
+			t.Errorf("Expected a pending price effective at %s. Got %+v", soon, scheduledPrice)
+		}
+	}
+}
+
+func mustAddItem(t *testing.T, r Repository, name string, price Decimal, manufacturer string) Item {
+	t.Helper()
+
+	item, err := r.AddItem(context.Background(), name, price, manufacturer)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when adding an item", err)
+	}
+	return item
+}
+
+// newManufacturer returns a manufacturer no other test uses.
+func newManufacturer() string {
+	return "conformance-" + uuid.NewString()
+}
+
+// appliedOf counts the scheduled prices in applied for the item id.
+func appliedOf(applied []ScheduledPrice, id uuid.UUID) int {
+	count := 0
+	for _, scheduledPrice := range applied {
+		if scheduledPrice.ItemID == id {
+			count++
+		}
+	}
+	return count
+}
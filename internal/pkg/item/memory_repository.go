@@ -0,0 +1,261 @@
+package item
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewMemoryRepository returns a Repository that keeps items in memory, for tests and demos. It's
+// safe for concurrent use, but its items are lost when the process exits and, as there's no
+// outbox to relay them, changes aren't published.
+func NewMemoryRepository() Repository {
+	return &memoryRepository{items: make(map[uuid.UUID]Item)}
+}
+
+type memoryRepository struct {
+	mu              sync.RWMutex
+	items           map[uuid.UUID]Item
+	priceHistory    []PriceChange
+	scheduledPrices []ScheduledPrice
+}
+
+// GetItems ..
+func (r *memoryRepository) GetItems(ctx context.Context, page int64, pageSize int64) ([]Item, error) {
+	return r.FindItems(ctx, Filter{}, page, pageSize)
+}
+
+// GetItemByID ..
+func (r *memoryRepository) GetItemByID(ctx context.Context, id uuid.UUID) (Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, ok := r.items[id]
+	if !ok {
+		return Item{}, sql.ErrNoRows
+	}
+
+	return item, nil
+}
+
+// FindItems ..
+func (r *memoryRepository) FindItems(ctx context.Context, filter Filter, page int64, pageSize int64) ([]Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	name := strings.ToLower(filter.Name)
+	matches := make([]Item, 0)
+	for _, item := range r.items {
+		if (filter.Manufacturer == "" || item.Manufacturer == filter.Manufacturer) &&
+			(name == "" || strings.Contains(strings.ToLower(item.Name), name)) &&
+			(filter.MinPrice == 0 || item.Price >= filter.MinPrice) &&
+			(filter.MaxPrice == 0 || item.Price <= filter.MaxPrice) {
+			matches = append(matches, item)
+		}
+	}
+	sortByID(matches)
+
+	return paginate(matches, page, pageSize), nil
+}
+
+// GetItemsByIDs returns the items matching ids. Unknown ids are skipped.
+func (r *memoryRepository) GetItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	payload := make([]Item, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if item, ok := r.items[id]; ok && !seen[id] {
+			seen[id] = true
+			payload = append(payload, item)
+		}
+	}
+
+	return payload, nil
+}
+
+// AddItem ..
+func (r *memoryRepository) AddItem(ctx context.Context, name string, price Decimal, manufacturer string) (Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item := Item{ID: uuid.New(), Name: name, Price: price, Manufacturer: manufacturer}
+	r.items[item.ID] = item
+	r.priceHistory = append(r.priceHistory, PriceChange{ItemID: item.ID, Price: price, EffectiveAt: time.Now()})
+
+	return item, nil
+}
+
+// UpdateItem ..
+func (r *memoryRepository) UpdateItem(ctx context.Context, item *Item) (Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.items[item.ID]
+	if !ok {
+		return Item{}, sql.ErrNoRows
+	}
+
+	r.items[item.ID] = *item
+	if previous.Price != item.Price {
+		r.priceHistory = append(r.priceHistory, PriceChange{ItemID: item.ID, Price: item.Price, EffectiveAt: time.Now()})
+	}
+
+	return *item, nil
+}
+
+// RemoveItem removes the item and, as the database's cascading deletes would, its prices.
+func (r *memoryRepository) RemoveItem(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.items, id)
+
+	priceHistory := r.priceHistory[:0]
+	for _, change := range r.priceHistory {
+		if change.ItemID != id {
+			priceHistory = append(priceHistory, change)
+		}
+	}
+	r.priceHistory = priceHistory
+
+	scheduledPrices := r.scheduledPrices[:0]
+	for _, scheduledPrice := range r.scheduledPrices {
+		if scheduledPrice.ItemID != id {
+			scheduledPrices = append(scheduledPrices, scheduledPrice)
+		}
+	}
+	r.scheduledPrices = scheduledPrices
+
+	return id, nil
+}
+
+// GetPriceHistory ..
+func (r *memoryRepository) GetPriceHistory(ctx context.Context, id uuid.UUID, page int64, pageSize int64) ([]PriceChange, error) {
+	history, err := r.GetPriceHistoryByItemIDs(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	return paginate(history, page, pageSize), nil
+}
+
+// GetPriceHistoryByItemIDs returns the full price history of every item in ids, newest first.
+func (r *memoryRepository) GetPriceHistoryByItemIDs(ctx context.Context, ids []uuid.UUID) ([]PriceChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := toSet(ids)
+	payload := make([]PriceChange, 0)
+	for i := len(r.priceHistory) - 1; i >= 0; i-- {
+		if change := r.priceHistory[i]; wanted[change.ItemID] {
+			payload = append(payload, change)
+		}
+	}
+	sort.SliceStable(payload, func(i, j int) bool {
+		return payload[i].EffectiveAt.After(payload[j].EffectiveAt)
+	})
+
+	return payload, nil
+}
+
+// GetScheduledPrices ..
+func (r *memoryRepository) GetScheduledPrices(ctx context.Context, id uuid.UUID) ([]ScheduledPrice, error) {
+	return r.GetScheduledPricesByItemIDs(ctx, []uuid.UUID{id})
+}
+
+// GetScheduledPricesByItemIDs returns the pending scheduled prices of every item in ids.
+func (r *memoryRepository) GetScheduledPricesByItemIDs(ctx context.Context, ids []uuid.UUID) ([]ScheduledPrice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := toSet(ids)
+	payload := make([]ScheduledPrice, 0)
+	for _, scheduledPrice := range r.scheduledPrices {
+		if wanted[scheduledPrice.ItemID] && scheduledPrice.AppliedAt == nil {
+			payload = append(payload, scheduledPrice)
+		}
+	}
+	sortByEffectiveAt(payload)
+
+	return payload, nil
+}
+
+// AddScheduledPrice ..
+func (r *memoryRepository) AddScheduledPrice(ctx context.Context, id uuid.UUID, price Decimal, effectiveAt time.Time) (ScheduledPrice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[id]; !ok {
+		return ScheduledPrice{}, sql.ErrNoRows
+	}
+
+	scheduledPrice := ScheduledPrice{ID: uuid.New(), ItemID: id, Price: price, EffectiveAt: effectiveAt}
+	r.scheduledPrices = append(r.scheduledPrices, scheduledPrice)
+
+	return scheduledPrice, nil
+}
+
+// ApplyScheduledPrices applies every pending scheduled price that is effective at now.
+func (r *memoryRepository) ApplyScheduledPrices(ctx context.Context, now time.Time) ([]ScheduledPrice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	payload := make([]ScheduledPrice, 0)
+	for i := range r.scheduledPrices {
+		scheduledPrice := &r.scheduledPrices[i]
+		if scheduledPrice.AppliedAt != nil || scheduledPrice.EffectiveAt.After(now) {
+			continue
+		}
+
+		item := r.items[scheduledPrice.ItemID]
+		item.Price = scheduledPrice.Price
+		r.items[item.ID] = item
+		r.priceHistory = append(r.priceHistory, PriceChange{ItemID: item.ID, Price: scheduledPrice.Price, EffectiveAt: scheduledPrice.EffectiveAt})
+
+		appliedAt := now
+		scheduledPrice.AppliedAt = &appliedAt
+		payload = append(payload, *scheduledPrice)
+	}
+	sortByEffectiveAt(payload)
+
+	return payload, nil
+}
+
+// sortByID orders items as Postgres orders uuids, by their bytes.
+func sortByID(items []Item) {
+	sort.Slice(items, func(i, j int) bool {
+		return bytes.Compare(items[i].ID[:], items[j].ID[:]) < 0
+	})
+}
+
+func sortByEffectiveAt(scheduledPrices []ScheduledPrice) {
+	sort.SliceStable(scheduledPrices, func(i, j int) bool {
+		return scheduledPrices[i].EffectiveAt.Before(scheduledPrices[j].EffectiveAt)
+	})
+}
+
+func paginate[T any](all []T, page int64, pageSize int64) []T {
+	start := page * pageSize
+	if start < 0 || start >= int64(len(all)) {
+		return make([]T, 0)
+	}
+	end := min(start+pageSize, int64(len(all)))
+
+	return all[start:end]
+}
+
+func toSet(ids []uuid.UUID) map[uuid.UUID]bool {
+	set := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
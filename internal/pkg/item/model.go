@@ -1,6 +1,10 @@
 package item
 
 import (
+	"encoding/json"
+	"strconv"
+	"time"
+
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/google/uuid"
 )
@@ -8,6 +12,33 @@ import (
 // Decimal ..
 type Decimal int64
 
+// UnmarshalJSON accepts a number or, for clients written before JSON bodies took numeric prices,
+// a string holding one.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var raw string
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		*d = Decimal(value)
+		return nil
+	}
+
+	var value int64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*d = Decimal(value)
+	return nil
+}
+
 // Item ..
 type Item struct {
 	ID           uuid.UUID `json:"id"`
@@ -28,6 +59,14 @@ func (item Item) Validate() error {
 	)
 }
 
+// Filter narrows FindItems results. Zero-valued fields are ignored.
+type Filter struct {
+	Manufacturer string
+	Name         string
+	MinPrice     Decimal
+	MaxPrice     Decimal
+}
+
 // ItemDTO ..
 type ItemDTO struct {
 	Name         string  `json:"name"`
@@ -46,3 +85,35 @@ func (item ItemDTO) Validate() error {
 		validation.Field(&item.Manufacturer, validation.Required),
 	)
 }
+
+// PriceChange ..
+type PriceChange struct {
+	ItemID      uuid.UUID `json:"item_id"`
+	Price       Decimal   `json:"price"`
+	EffectiveAt time.Time `json:"effective_at"`
+}
+
+// ScheduledPrice ..
+type ScheduledPrice struct {
+	ID          uuid.UUID  `json:"id"`
+	ItemID      uuid.UUID  `json:"item_id"`
+	Price       Decimal    `json:"price"`
+	EffectiveAt time.Time  `json:"effective_at"`
+	AppliedAt   *time.Time `json:"applied_at"`
+}
+
+// ScheduledPriceDTO ..
+type ScheduledPriceDTO struct {
+	Price       Decimal   `json:"price"`
+	EffectiveAt time.Time `json:"effective_at"`
+}
+
+// Validate ..
+func (price ScheduledPriceDTO) Validate() error {
+	return validation.ValidateStruct(&price,
+		// Price should be greater than 0
+		validation.Field(&price.Price, validation.Required, validation.Min(99)),
+		// EffectiveAt cannot be blank
+		validation.Field(&price.EffectiveAt, validation.Required),
+	)
+}
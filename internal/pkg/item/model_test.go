@@ -1,6 +1,7 @@
 package item
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/google/uuid"
 	"testing"
@@ -28,3 +29,21 @@ func Test_Item_Validate_WhenGivenBadItems_ShouldReturnErrors(t *testing.T) {
 		t.Errorf("Expected %s, Received %s", expectedErrors, err)
 	}
 }
+
+func Test_Decimal_UnmarshalJSON_WhenGivenNumberOrString_ShouldParse(t *testing.T) {
+	for _, input := range []string{`199`, `"199"`} {
+		var price Decimal
+		if err := json.Unmarshal([]byte(input), &price); err != nil || price != 199 {
+			t.Errorf("Expected 199 from %s, Received %d (%v)", input, price, err)
+		}
+	}
+}
+
+func Test_Decimal_UnmarshalJSON_WhenGivenInvalidPrice_ShouldReturnError(t *testing.T) {
+	for _, input := range []string{`"abc"`, `1.5`, `""`} {
+		var price Decimal
+		if err := json.Unmarshal([]byte(input), &price); err == nil {
+			t.Errorf("Expected an error from %s", input)
+		}
+	}
+}
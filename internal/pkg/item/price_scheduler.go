@@ -0,0 +1,54 @@
+package item
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/audit"
+)
+
+// PriceSchedulerActor is recorded as the actor of the price changes the scheduler applies.
+const PriceSchedulerActor = "price-scheduler"
+
+// NewPriceScheduler ..
+func NewPriceScheduler(service Service, interval time.Duration) *PriceScheduler {
+	return &PriceScheduler{Service: service, Interval: interval, Now: time.Now}
+}
+
+// PriceScheduler periodically applies scheduled prices once their effective time has passed.
+type PriceScheduler struct {
+	Service  Service
+	Interval time.Duration
+	Now      func() time.Time
+}
+
+// Run blocks, applying due scheduled prices every interval until the context is cancelled.
+func (p *PriceScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.Tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick ..
+func (p *PriceScheduler) Tick(ctx context.Context) {
+	ctx = audit.WithActor(ctx, PriceSchedulerActor)
+	applied, err := p.Service.ApplyScheduledPrices(ctx, p.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to apply scheduled prices", "error", err)
+		return
+	}
+
+	if len(applied) > 0 {
+		slog.InfoContext(ctx, "applied scheduled prices", "count", len(applied))
+	}
+}
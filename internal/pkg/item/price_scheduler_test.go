@@ -0,0 +1,67 @@
+package item
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/audit"
+)
+
+func Test_PriceScheduler_Tick_ShouldApplyScheduledPricesAtCurrentTime(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var nowCalled time.Time
+	var actorCalled string
+
+	mockRepository := &RepositoryMock{
+		ApplyScheduledPricesFunc: func(ctx context.Context, now time.Time) ([]ScheduledPrice, error) {
+			nowCalled = now
+			actorCalled = audit.ActorFromContext(ctx)
+			return []ScheduledPrice{{ID: uuid.New(), ItemID: uuid.New(), Price: 150, EffectiveAt: now}}, nil
+		},
+	}
+
+	sut := NewPriceScheduler(NewService(mockRepository), time.Minute)
+	sut.Now = func() time.Time { return now }
+
+	sut.Tick(context.Background())
+
+	if !nowCalled.Equal(now) {
+		t.Errorf("Expected scheduled prices to be applied at %s. Got %s", now, nowCalled)
+	}
+
+	if actorCalled != PriceSchedulerActor {
+		t.Errorf("Expected scheduled prices to be attributed to %s. Got %s", PriceSchedulerActor, actorCalled)
+	}
+
+	callsToSend := len(mockRepository.ApplyScheduledPricesCalls())
+	if callsToSend != 1 {
+		t.Errorf("Send was called %d times", callsToSend)
+	}
+}
+
+func Test_PriceScheduler_Run_WhenContextIsCancelled_ShouldReturn(t *testing.T) {
+	mockRepository := &RepositoryMock{
+		ApplyScheduledPricesFunc: func(ctx context.Context, now time.Time) ([]ScheduledPrice, error) {
+			return nil, nil
+		},
+	}
+
+	sut := NewPriceScheduler(NewService(mockRepository), time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		sut.Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected price scheduler to stop after its context was cancelled")
+	}
+}
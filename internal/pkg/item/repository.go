@@ -3,16 +3,41 @@ package item
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/audit"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/outbox"
+)
+
+const (
+	// ItemCreatedTopic ..
+	ItemCreatedTopic = "item.created"
+
+	// ItemUpdatedTopic ..
+	ItemUpdatedTopic = "item.updated"
+
+	// ItemDeletedTopic ..
+	ItemDeletedTopic = "item.deleted"
 )
 
 // Repository ..
 type Repository interface {
 	GetItems(ctx context.Context, page int64, pageSize int64) ([]Item, error)
 	GetItemByID(ctx context.Context, id uuid.UUID) (Item, error)
+	FindItems(ctx context.Context, filter Filter, page int64, pageSize int64) ([]Item, error)
+	GetItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]Item, error)
 	AddItem(ctx context.Context, name string, price Decimal, manufacturer string) (Item, error)
 	UpdateItem(ctx context.Context, item *Item) (Item, error)
 	RemoveItem(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	GetPriceHistory(ctx context.Context, id uuid.UUID, page int64, pageSize int64) ([]PriceChange, error)
+	GetPriceHistoryByItemIDs(ctx context.Context, ids []uuid.UUID) ([]PriceChange, error)
+	GetScheduledPrices(ctx context.Context, id uuid.UUID) ([]ScheduledPrice, error)
+	GetScheduledPricesByItemIDs(ctx context.Context, ids []uuid.UUID) ([]ScheduledPrice, error)
+	AddScheduledPrice(ctx context.Context, id uuid.UUID, price Decimal, effectiveAt time.Time) (ScheduledPrice, error)
+	ApplyScheduledPrices(ctx context.Context, now time.Time) ([]ScheduledPrice, error)
 }
 
 // NewRepository ..
@@ -61,21 +86,78 @@ func (r *repository) GetItemByID(ctx context.Context, id uuid.UUID) (Item, error
 	return item, nil
 }
 
+// FindItems ..
+func (r *repository) FindItems(ctx context.Context, filter Filter, page int64, pageSize int64) ([]Item, error) {
+	limit := pageSize
+	offset := page * pageSize
+
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT id, name, price, manufacturer FROM item WHERE ($1 = '' OR manufacturer = $1) AND ($2 = '' OR name ILIKE '%' || $2 || '%') AND ($3 = 0 OR price >= $3) AND ($4 = 0 OR price <= $4) ORDER BY id LIMIT $5 OFFSET $6", filter.Manufacturer, filter.Name, filter.MinPrice, filter.MaxPrice, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanItems(rows)
+}
+
+// GetItemsByIDs returns the items matching ids in a single query. Unknown ids are skipped.
+func (r *repository) GetItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]Item, error) {
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT id, name, price, manufacturer FROM item WHERE id = ANY ($1)", pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanItems(rows)
+}
+
 // AddItem ..
 func (r *repository) AddItem(ctx context.Context, name string, price Decimal, manufacturer string) (Item, error) {
+	tx, err := r.DBConn.BeginTx(ctx, nil)
+	if err != nil {
+		return Item{}, err
+	}
+
 	var insertedID uuid.UUID
 	insertStm := "INSERT INTO item (name, price, manufacturer) VALUES ($1, $2, $3) RETURNING ID"
-	err := r.DBConn.QueryRowContext(ctx, insertStm, name, price, manufacturer).Scan(&insertedID)
+	err = tx.QueryRowContext(ctx, insertStm, name, price, manufacturer).Scan(&insertedID)
 	if err != nil {
+		tx.Rollback()
 		return Item{}, err
 	}
 
-	return Item{
+	item := Item{
 		ID:           insertedID,
 		Name:         name,
 		Price:        price,
 		Manufacturer: manufacturer,
-	}, nil
+	}
+
+	err = recordPriceChange(ctx, tx, insertedID, price)
+	if err != nil {
+		tx.Rollback()
+		return Item{}, err
+	}
+
+	err = outbox.Insert(ctx, tx, ItemCreatedTopic, insertedID.String(), item)
+	if err != nil {
+		tx.Rollback()
+		return Item{}, err
+	}
+
+	err = audit.Insert(ctx, tx, audit.Create, audit.ItemEntityType, insertedID, nil, item)
+	if err != nil {
+		tx.Rollback()
+		return Item{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return Item{}, err
+	}
+
+	return item, nil
 }
 
 // UpdateItem ..
@@ -85,12 +167,40 @@ func (r *repository) UpdateItem(ctx context.Context, item *Item) (Item, error) {
 		return Item{}, err
 	}
 
+	previous := Item{ID: item.ID}
+	err = tx.QueryRowContext(ctx, "SELECT name, price, manufacturer FROM item WHERE id = $1 FOR UPDATE", item.ID).
+		Scan(&previous.Name, &previous.Price, &previous.Manufacturer)
+	if err != nil {
+		tx.Rollback()
+		return Item{}, err
+	}
+
 	_, err = tx.ExecContext(ctx, "UPDATE item SET name = $1, price = $2, manufacturer = $3 WHERE id = $4", item.Name, item.Price, item.Manufacturer, item.ID)
 	if err != nil {
 		tx.Rollback()
 		return Item{}, err
 	}
 
+	if previous.Price != item.Price {
+		err = recordPriceChange(ctx, tx, item.ID, item.Price)
+		if err != nil {
+			tx.Rollback()
+			return Item{}, err
+		}
+	}
+
+	err = outbox.Insert(ctx, tx, ItemUpdatedTopic, item.ID.String(), item)
+	if err != nil {
+		tx.Rollback()
+		return Item{}, err
+	}
+
+	err = audit.Insert(ctx, tx, audit.Update, audit.ItemEntityType, item.ID, previous, *item)
+	if err != nil {
+		tx.Rollback()
+		return Item{}, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -100,18 +210,268 @@ func (r *repository) UpdateItem(ctx context.Context, item *Item) (Item, error) {
 	return *item, nil
 }
 
-// RemoveItem ..
+// RemoveItem deletes the item, publishing what it was. Removing an item that doesn't exist
+// publishes nothing.
 func (r *repository) RemoveItem(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
-	stmt, err := r.DBConn.PrepareContext(ctx, "DELETE FROM item WHERE id = $1")
+	tx, err := r.DBConn.BeginTx(ctx, nil)
+	if err != nil {
+		return id, err
+	}
+
+	removed := Item{ID: id}
+	row := tx.QueryRowContext(ctx, "DELETE FROM item WHERE id = $1 RETURNING name, price, manufacturer", id)
+	err = row.Scan(&removed.Name, &removed.Price, &removed.Manufacturer)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return id, nil
+	} else if err != nil {
+		tx.Rollback()
+		return id, err
+	}
+
+	err = outbox.Insert(ctx, tx, ItemDeletedTopic, id.String(), removed)
 	if err != nil {
+		tx.Rollback()
 		return id, err
 	}
-	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, id)
+	err = audit.Insert(ctx, tx, audit.Delete, audit.ItemEntityType, id, removed, nil)
 	if err != nil {
+		tx.Rollback()
+		return id, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
 		return id, err
 	}
 
 	return id, nil
 }
+
+// GetPriceHistory ..
+func (r *repository) GetPriceHistory(ctx context.Context, id uuid.UUID, page int64, pageSize int64) ([]PriceChange, error) {
+	limit := pageSize
+	offset := page * pageSize
+
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT item_id, price, effective_at FROM price_history WHERE item_id = $1 ORDER BY effective_at DESC LIMIT $2 OFFSET $3", id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payload := make([]PriceChange, 0)
+	for rows.Next() {
+		data := new(PriceChange)
+		err := rows.Scan(&data.ItemID, &data.Price, &data.EffectiveAt)
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, *data)
+	}
+
+	return payload, nil
+}
+
+// GetPriceHistoryByItemIDs returns the full price history of every item in ids, newest first.
+func (r *repository) GetPriceHistoryByItemIDs(ctx context.Context, ids []uuid.UUID) ([]PriceChange, error) {
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT item_id, price, effective_at FROM price_history WHERE item_id = ANY ($1) ORDER BY effective_at DESC", pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payload := make([]PriceChange, 0)
+	for rows.Next() {
+		data := new(PriceChange)
+		err := rows.Scan(&data.ItemID, &data.Price, &data.EffectiveAt)
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, *data)
+	}
+
+	return payload, nil
+}
+
+// GetScheduledPrices ..
+func (r *repository) GetScheduledPrices(ctx context.Context, id uuid.UUID) ([]ScheduledPrice, error) {
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT id, item_id, price, effective_at, applied_at FROM scheduled_price WHERE item_id = $1 AND applied_at IS NULL ORDER BY effective_at", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payload := make([]ScheduledPrice, 0)
+	for rows.Next() {
+		data := new(ScheduledPrice)
+		err := rows.Scan(&data.ID, &data.ItemID, &data.Price, &data.EffectiveAt, &data.AppliedAt)
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, *data)
+	}
+
+	return payload, nil
+}
+
+// GetScheduledPricesByItemIDs returns the pending scheduled prices of every item in ids.
+func (r *repository) GetScheduledPricesByItemIDs(ctx context.Context, ids []uuid.UUID) ([]ScheduledPrice, error) {
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT id, item_id, price, effective_at, applied_at FROM scheduled_price WHERE item_id = ANY ($1) AND applied_at IS NULL ORDER BY effective_at", pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payload := make([]ScheduledPrice, 0)
+	for rows.Next() {
+		data := new(ScheduledPrice)
+		err := rows.Scan(&data.ID, &data.ItemID, &data.Price, &data.EffectiveAt, &data.AppliedAt)
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, *data)
+	}
+
+	return payload, nil
+}
+
+// AddScheduledPrice ..
+func (r *repository) AddScheduledPrice(ctx context.Context, id uuid.UUID, price Decimal, effectiveAt time.Time) (ScheduledPrice, error) {
+	tx, err := r.DBConn.BeginTx(ctx, nil)
+	if err != nil {
+		return ScheduledPrice{}, err
+	}
+
+	var insertedID uuid.UUID
+	insertStm := "INSERT INTO scheduled_price (item_id, price, effective_at) VALUES ($1, $2, $3) RETURNING id"
+	err = tx.QueryRowContext(ctx, insertStm, id, price, effectiveAt).Scan(&insertedID)
+	if err != nil {
+		tx.Rollback()
+		return ScheduledPrice{}, err
+	}
+
+	scheduledPrice := ScheduledPrice{
+		ID:          insertedID,
+		ItemID:      id,
+		Price:       price,
+		EffectiveAt: effectiveAt,
+	}
+
+	err = audit.Insert(ctx, tx, audit.Create, audit.ScheduledPriceEntityType, insertedID, nil, scheduledPrice)
+	if err != nil {
+		tx.Rollback()
+		return ScheduledPrice{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return ScheduledPrice{}, err
+	}
+
+	return scheduledPrice, nil
+}
+
+// ApplyScheduledPrices applies every pending scheduled price that is effective at now. Rows are
+// locked with SKIP LOCKED so concurrent replicas never apply the same scheduled price twice.
+func (r *repository) ApplyScheduledPrices(ctx context.Context, now time.Time) ([]ScheduledPrice, error) {
+	tx, err := r.DBConn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT id, item_id, price, effective_at FROM scheduled_price WHERE applied_at IS NULL AND effective_at <= $1 ORDER BY effective_at FOR UPDATE SKIP LOCKED", now)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	payload := make([]ScheduledPrice, 0)
+	for rows.Next() {
+		data := new(ScheduledPrice)
+		err := rows.Scan(&data.ID, &data.ItemID, &data.Price, &data.EffectiveAt)
+		if err != nil {
+			rows.Close()
+			tx.Rollback()
+			return nil, err
+		}
+		payload = append(payload, *data)
+	}
+	rows.Close()
+
+	for i := range payload {
+		scheduledPrice := &payload[i]
+
+		previous := Item{ID: scheduledPrice.ItemID}
+		err = tx.QueryRowContext(ctx, "SELECT name, price, manufacturer FROM item WHERE id = $1 FOR UPDATE", scheduledPrice.ItemID).
+			Scan(&previous.Name, &previous.Price, &previous.Manufacturer)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		var updated Item
+		err = tx.QueryRowContext(ctx, "UPDATE item SET price = $1 WHERE id = $2 RETURNING id, name, price, manufacturer", scheduledPrice.Price, scheduledPrice.ItemID).
+			Scan(&updated.ID, &updated.Name, &updated.Price, &updated.Manufacturer)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO price_history (item_id, price, effective_at) VALUES ($1, $2, $3)", scheduledPrice.ItemID, scheduledPrice.Price, scheduledPrice.EffectiveAt)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE scheduled_price SET applied_at = $1 WHERE id = $2", now, scheduledPrice.ID)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		err = outbox.Insert(ctx, tx, ItemUpdatedTopic, updated.ID.String(), updated)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		err = audit.Insert(ctx, tx, audit.Update, audit.ItemEntityType, updated.ID, previous, updated)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		appliedAt := now
+		scheduledPrice.AppliedAt = &appliedAt
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+func recordPriceChange(ctx context.Context, tx *sql.Tx, id uuid.UUID, price Decimal) error {
+	_, err := tx.ExecContext(ctx, "INSERT INTO price_history (item_id, price) VALUES ($1, $2)", id, price)
+	return err
+}
+
+func scanItems(rows *sql.Rows) ([]Item, error) {
+	payload := make([]Item, 0)
+	for rows.Next() {
+		data := new(Item)
+		err := rows.Scan(&data.ID, &data.Name, &data.Price, &data.Manufacturer)
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, *data)
+	}
+
+	return payload, nil
+}
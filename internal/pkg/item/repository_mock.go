@@ -7,6 +7,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"sync"
+	"time"
 )
 
 // Ensure, that RepositoryMock does implement Repository.
@@ -22,12 +23,36 @@ var _ Repository = &RepositoryMock{}
 //			AddItemFunc: func(ctx context.Context, name string, price Decimal, manufacturer string) (Item, error) {
 //				panic("mock out the AddItem method")
 //			},
+//			AddScheduledPriceFunc: func(ctx context.Context, id uuid.UUID, price Decimal, effectiveAt time.Time) (ScheduledPrice, error) {
+//				panic("mock out the AddScheduledPrice method")
+//			},
+//			ApplyScheduledPricesFunc: func(ctx context.Context, now time.Time) ([]ScheduledPrice, error) {
+//				panic("mock out the ApplyScheduledPrices method")
+//			},
+//			FindItemsFunc: func(ctx context.Context, filter Filter, page int64, pageSize int64) ([]Item, error) {
+//				panic("mock out the FindItems method")
+//			},
 //			GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (Item, error) {
 //				panic("mock out the GetItemByID method")
 //			},
 //			GetItemsFunc: func(ctx context.Context, page int64, pageSize int64) ([]Item, error) {
 //				panic("mock out the GetItems method")
 //			},
+//			GetItemsByIDsFunc: func(ctx context.Context, ids []uuid.UUID) ([]Item, error) {
+//				panic("mock out the GetItemsByIDs method")
+//			},
+//			GetPriceHistoryFunc: func(ctx context.Context, id uuid.UUID, page int64, pageSize int64) ([]PriceChange, error) {
+//				panic("mock out the GetPriceHistory method")
+//			},
+//			GetPriceHistoryByItemIDsFunc: func(ctx context.Context, ids []uuid.UUID) ([]PriceChange, error) {
+//				panic("mock out the GetPriceHistoryByItemIDs method")
+//			},
+//			GetScheduledPricesFunc: func(ctx context.Context, id uuid.UUID) ([]ScheduledPrice, error) {
+//				panic("mock out the GetScheduledPrices method")
+//			},
+//			GetScheduledPricesByItemIDsFunc: func(ctx context.Context, ids []uuid.UUID) ([]ScheduledPrice, error) {
+//				panic("mock out the GetScheduledPricesByItemIDs method")
+//			},
 //			RemoveItemFunc: func(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
 //				panic("mock out the RemoveItem method")
 //			},
@@ -44,12 +69,36 @@ type RepositoryMock struct {
 	// AddItemFunc mocks the AddItem method.
 	AddItemFunc func(ctx context.Context, name string, price Decimal, manufacturer string) (Item, error)
 
+	// AddScheduledPriceFunc mocks the AddScheduledPrice method.
+	AddScheduledPriceFunc func(ctx context.Context, id uuid.UUID, price Decimal, effectiveAt time.Time) (ScheduledPrice, error)
+
+	// ApplyScheduledPricesFunc mocks the ApplyScheduledPrices method.
+	ApplyScheduledPricesFunc func(ctx context.Context, now time.Time) ([]ScheduledPrice, error)
+
+	// FindItemsFunc mocks the FindItems method.
+	FindItemsFunc func(ctx context.Context, filter Filter, page int64, pageSize int64) ([]Item, error)
+
 	// GetItemByIDFunc mocks the GetItemByID method.
 	GetItemByIDFunc func(ctx context.Context, id uuid.UUID) (Item, error)
 
 	// GetItemsFunc mocks the GetItems method.
 	GetItemsFunc func(ctx context.Context, page int64, pageSize int64) ([]Item, error)
 
+	// GetItemsByIDsFunc mocks the GetItemsByIDs method.
+	GetItemsByIDsFunc func(ctx context.Context, ids []uuid.UUID) ([]Item, error)
+
+	// GetPriceHistoryFunc mocks the GetPriceHistory method.
+	GetPriceHistoryFunc func(ctx context.Context, id uuid.UUID, page int64, pageSize int64) ([]PriceChange, error)
+
+	// GetPriceHistoryByItemIDsFunc mocks the GetPriceHistoryByItemIDs method.
+	GetPriceHistoryByItemIDsFunc func(ctx context.Context, ids []uuid.UUID) ([]PriceChange, error)
+
+	// GetScheduledPricesFunc mocks the GetScheduledPrices method.
+	GetScheduledPricesFunc func(ctx context.Context, id uuid.UUID) ([]ScheduledPrice, error)
+
+	// GetScheduledPricesByItemIDsFunc mocks the GetScheduledPricesByItemIDs method.
+	GetScheduledPricesByItemIDsFunc func(ctx context.Context, ids []uuid.UUID) ([]ScheduledPrice, error)
+
 	// RemoveItemFunc mocks the RemoveItem method.
 	RemoveItemFunc func(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
 
@@ -69,6 +118,35 @@ type RepositoryMock struct {
 			// Manufacturer is the manufacturer argument value.
 			Manufacturer string
 		}
+		// AddScheduledPrice holds details about calls to the AddScheduledPrice method.
+		AddScheduledPrice []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Price is the price argument value.
+			Price Decimal
+			// EffectiveAt is the effectiveAt argument value.
+			EffectiveAt time.Time
+		}
+		// ApplyScheduledPrices holds details about calls to the ApplyScheduledPrices method.
+		ApplyScheduledPrices []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// FindItems holds details about calls to the FindItems method.
+		FindItems []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter Filter
+			// Page is the page argument value.
+			Page int64
+			// PageSize is the pageSize argument value.
+			PageSize int64
+		}
 		// GetItemByID holds details about calls to the GetItemByID method.
 		GetItemByID []struct {
 			// Ctx is the ctx argument value.
@@ -85,6 +163,45 @@ type RepositoryMock struct {
 			// PageSize is the pageSize argument value.
 			PageSize int64
 		}
+		// GetItemsByIDs holds details about calls to the GetItemsByIDs method.
+		GetItemsByIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Ids is the ids argument value.
+			Ids []uuid.UUID
+		}
+		// GetPriceHistory holds details about calls to the GetPriceHistory method.
+		GetPriceHistory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Page is the page argument value.
+			Page int64
+			// PageSize is the pageSize argument value.
+			PageSize int64
+		}
+		// GetPriceHistoryByItemIDs holds details about calls to the GetPriceHistoryByItemIDs method.
+		GetPriceHistoryByItemIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Ids is the ids argument value.
+			Ids []uuid.UUID
+		}
+		// GetScheduledPrices holds details about calls to the GetScheduledPrices method.
+		GetScheduledPrices []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// GetScheduledPricesByItemIDs holds details about calls to the GetScheduledPricesByItemIDs method.
+		GetScheduledPricesByItemIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Ids is the ids argument value.
+			Ids []uuid.UUID
+		}
 		// RemoveItem holds details about calls to the RemoveItem method.
 		RemoveItem []struct {
 			// Ctx is the ctx argument value.
@@ -100,11 +217,19 @@ type RepositoryMock struct {
 			Item *Item
 		}
 	}
-	lockAddItem     sync.RWMutex
-	lockGetItemByID sync.RWMutex
-	lockGetItems    sync.RWMutex
-	lockRemoveItem  sync.RWMutex
-	lockUpdateItem  sync.RWMutex
+	lockAddItem                     sync.RWMutex
+	lockAddScheduledPrice           sync.RWMutex
+	lockApplyScheduledPrices        sync.RWMutex
+	lockFindItems                   sync.RWMutex
+	lockGetItemByID                 sync.RWMutex
+	lockGetItems                    sync.RWMutex
+	lockGetItemsByIDs               sync.RWMutex
+	lockGetPriceHistory             sync.RWMutex
+	lockGetPriceHistoryByItemIDs    sync.RWMutex
+	lockGetScheduledPrices          sync.RWMutex
+	lockGetScheduledPricesByItemIDs sync.RWMutex
+	lockRemoveItem                  sync.RWMutex
+	lockUpdateItem                  sync.RWMutex
 }
 
 // AddItem calls AddItemFunc.
@@ -151,6 +276,130 @@ func (mock *RepositoryMock) AddItemCalls() []struct {
 	return calls
 }
 
+// AddScheduledPrice calls AddScheduledPriceFunc.
+func (mock *RepositoryMock) AddScheduledPrice(ctx context.Context, id uuid.UUID, price Decimal, effectiveAt time.Time) (ScheduledPrice, error) {
+	if mock.AddScheduledPriceFunc == nil {
+		panic("RepositoryMock.AddScheduledPriceFunc: method is nil but Repository.AddScheduledPrice was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ID          uuid.UUID
+		Price       Decimal
+		EffectiveAt time.Time
+	}{
+		Ctx:         ctx,
+		ID:          id,
+		Price:       price,
+		EffectiveAt: effectiveAt,
+	}
+	mock.lockAddScheduledPrice.Lock()
+	mock.calls.AddScheduledPrice = append(mock.calls.AddScheduledPrice, callInfo)
+	mock.lockAddScheduledPrice.Unlock()
+	return mock.AddScheduledPriceFunc(ctx, id, price, effectiveAt)
+}
+
+// AddScheduledPriceCalls gets all the calls that were made to AddScheduledPrice.
+// Check the length with:
+//
+//	len(mockedRepository.AddScheduledPriceCalls())
+func (mock *RepositoryMock) AddScheduledPriceCalls() []struct {
+	Ctx         context.Context
+	ID          uuid.UUID
+	Price       Decimal
+	EffectiveAt time.Time
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ID          uuid.UUID
+		Price       Decimal
+		EffectiveAt time.Time
+	}
+	mock.lockAddScheduledPrice.RLock()
+	calls = mock.calls.AddScheduledPrice
+	mock.lockAddScheduledPrice.RUnlock()
+	return calls
+}
+
+// ApplyScheduledPrices calls ApplyScheduledPricesFunc.
+func (mock *RepositoryMock) ApplyScheduledPrices(ctx context.Context, now time.Time) ([]ScheduledPrice, error) {
+	if mock.ApplyScheduledPricesFunc == nil {
+		panic("RepositoryMock.ApplyScheduledPricesFunc: method is nil but Repository.ApplyScheduledPrices was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Now time.Time
+	}{
+		Ctx: ctx,
+		Now: now,
+	}
+	mock.lockApplyScheduledPrices.Lock()
+	mock.calls.ApplyScheduledPrices = append(mock.calls.ApplyScheduledPrices, callInfo)
+	mock.lockApplyScheduledPrices.Unlock()
+	return mock.ApplyScheduledPricesFunc(ctx, now)
+}
+
+// ApplyScheduledPricesCalls gets all the calls that were made to ApplyScheduledPrices.
+// Check the length with:
+//
+//	len(mockedRepository.ApplyScheduledPricesCalls())
+func (mock *RepositoryMock) ApplyScheduledPricesCalls() []struct {
+	Ctx context.Context
+	Now time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		Now time.Time
+	}
+	mock.lockApplyScheduledPrices.RLock()
+	calls = mock.calls.ApplyScheduledPrices
+	mock.lockApplyScheduledPrices.RUnlock()
+	return calls
+}
+
+// FindItems calls FindItemsFunc.
+func (mock *RepositoryMock) FindItems(ctx context.Context, filter Filter, page int64, pageSize int64) ([]Item, error) {
+	if mock.FindItemsFunc == nil {
+		panic("RepositoryMock.FindItemsFunc: method is nil but Repository.FindItems was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Filter   Filter
+		Page     int64
+		PageSize int64
+	}{
+		Ctx:      ctx,
+		Filter:   filter,
+		Page:     page,
+		PageSize: pageSize,
+	}
+	mock.lockFindItems.Lock()
+	mock.calls.FindItems = append(mock.calls.FindItems, callInfo)
+	mock.lockFindItems.Unlock()
+	return mock.FindItemsFunc(ctx, filter, page, pageSize)
+}
+
+// FindItemsCalls gets all the calls that were made to FindItems.
+// Check the length with:
+//
+//	len(mockedRepository.FindItemsCalls())
+func (mock *RepositoryMock) FindItemsCalls() []struct {
+	Ctx      context.Context
+	Filter   Filter
+	Page     int64
+	PageSize int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Filter   Filter
+		Page     int64
+		PageSize int64
+	}
+	mock.lockFindItems.RLock()
+	calls = mock.calls.FindItems
+	mock.lockFindItems.RUnlock()
+	return calls
+}
+
 // GetItemByID calls GetItemByIDFunc.
 func (mock *RepositoryMock) GetItemByID(ctx context.Context, id uuid.UUID) (Item, error) {
 	if mock.GetItemByIDFunc == nil {
@@ -227,6 +476,194 @@ func (mock *RepositoryMock) GetItemsCalls() []struct {
 	return calls
 }
 
+// GetItemsByIDs calls GetItemsByIDsFunc.
+func (mock *RepositoryMock) GetItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]Item, error) {
+	if mock.GetItemsByIDsFunc == nil {
+		panic("RepositoryMock.GetItemsByIDsFunc: method is nil but Repository.GetItemsByIDs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Ids []uuid.UUID
+	}{
+		Ctx: ctx,
+		Ids: ids,
+	}
+	mock.lockGetItemsByIDs.Lock()
+	mock.calls.GetItemsByIDs = append(mock.calls.GetItemsByIDs, callInfo)
+	mock.lockGetItemsByIDs.Unlock()
+	return mock.GetItemsByIDsFunc(ctx, ids)
+}
+
+// GetItemsByIDsCalls gets all the calls that were made to GetItemsByIDs.
+// Check the length with:
+//
+//	len(mockedRepository.GetItemsByIDsCalls())
+func (mock *RepositoryMock) GetItemsByIDsCalls() []struct {
+	Ctx context.Context
+	Ids []uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Ids []uuid.UUID
+	}
+	mock.lockGetItemsByIDs.RLock()
+	calls = mock.calls.GetItemsByIDs
+	mock.lockGetItemsByIDs.RUnlock()
+	return calls
+}
+
+// GetPriceHistory calls GetPriceHistoryFunc.
+func (mock *RepositoryMock) GetPriceHistory(ctx context.Context, id uuid.UUID, page int64, pageSize int64) ([]PriceChange, error) {
+	if mock.GetPriceHistoryFunc == nil {
+		panic("RepositoryMock.GetPriceHistoryFunc: method is nil but Repository.GetPriceHistory was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ID       uuid.UUID
+		Page     int64
+		PageSize int64
+	}{
+		Ctx:      ctx,
+		ID:       id,
+		Page:     page,
+		PageSize: pageSize,
+	}
+	mock.lockGetPriceHistory.Lock()
+	mock.calls.GetPriceHistory = append(mock.calls.GetPriceHistory, callInfo)
+	mock.lockGetPriceHistory.Unlock()
+	return mock.GetPriceHistoryFunc(ctx, id, page, pageSize)
+}
+
+// GetPriceHistoryCalls gets all the calls that were made to GetPriceHistory.
+// Check the length with:
+//
+//	len(mockedRepository.GetPriceHistoryCalls())
+func (mock *RepositoryMock) GetPriceHistoryCalls() []struct {
+	Ctx      context.Context
+	ID       uuid.UUID
+	Page     int64
+	PageSize int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ID       uuid.UUID
+		Page     int64
+		PageSize int64
+	}
+	mock.lockGetPriceHistory.RLock()
+	calls = mock.calls.GetPriceHistory
+	mock.lockGetPriceHistory.RUnlock()
+	return calls
+}
+
+// GetPriceHistoryByItemIDs calls GetPriceHistoryByItemIDsFunc.
+func (mock *RepositoryMock) GetPriceHistoryByItemIDs(ctx context.Context, ids []uuid.UUID) ([]PriceChange, error) {
+	if mock.GetPriceHistoryByItemIDsFunc == nil {
+		panic("RepositoryMock.GetPriceHistoryByItemIDsFunc: method is nil but Repository.GetPriceHistoryByItemIDs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Ids []uuid.UUID
+	}{
+		Ctx: ctx,
+		Ids: ids,
+	}
+	mock.lockGetPriceHistoryByItemIDs.Lock()
+	mock.calls.GetPriceHistoryByItemIDs = append(mock.calls.GetPriceHistoryByItemIDs, callInfo)
+	mock.lockGetPriceHistoryByItemIDs.Unlock()
+	return mock.GetPriceHistoryByItemIDsFunc(ctx, ids)
+}
+
+// GetPriceHistoryByItemIDsCalls gets all the calls that were made to GetPriceHistoryByItemIDs.
+// Check the length with:
+//
+//	len(mockedRepository.GetPriceHistoryByItemIDsCalls())
+func (mock *RepositoryMock) GetPriceHistoryByItemIDsCalls() []struct {
+	Ctx context.Context
+	Ids []uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Ids []uuid.UUID
+	}
+	mock.lockGetPriceHistoryByItemIDs.RLock()
+	calls = mock.calls.GetPriceHistoryByItemIDs
+	mock.lockGetPriceHistoryByItemIDs.RUnlock()
+	return calls
+}
+
+// GetScheduledPrices calls GetScheduledPricesFunc.
+func (mock *RepositoryMock) GetScheduledPrices(ctx context.Context, id uuid.UUID) ([]ScheduledPrice, error) {
+	if mock.GetScheduledPricesFunc == nil {
+		panic("RepositoryMock.GetScheduledPricesFunc: method is nil but Repository.GetScheduledPrices was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetScheduledPrices.Lock()
+	mock.calls.GetScheduledPrices = append(mock.calls.GetScheduledPrices, callInfo)
+	mock.lockGetScheduledPrices.Unlock()
+	return mock.GetScheduledPricesFunc(ctx, id)
+}
+
+// GetScheduledPricesCalls gets all the calls that were made to GetScheduledPrices.
+// Check the length with:
+//
+//	len(mockedRepository.GetScheduledPricesCalls())
+func (mock *RepositoryMock) GetScheduledPricesCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockGetScheduledPrices.RLock()
+	calls = mock.calls.GetScheduledPrices
+	mock.lockGetScheduledPrices.RUnlock()
+	return calls
+}
+
+// GetScheduledPricesByItemIDs calls GetScheduledPricesByItemIDsFunc.
+func (mock *RepositoryMock) GetScheduledPricesByItemIDs(ctx context.Context, ids []uuid.UUID) ([]ScheduledPrice, error) {
+	if mock.GetScheduledPricesByItemIDsFunc == nil {
+		panic("RepositoryMock.GetScheduledPricesByItemIDsFunc: method is nil but Repository.GetScheduledPricesByItemIDs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Ids []uuid.UUID
+	}{
+		Ctx: ctx,
+		Ids: ids,
+	}
+	mock.lockGetScheduledPricesByItemIDs.Lock()
+	mock.calls.GetScheduledPricesByItemIDs = append(mock.calls.GetScheduledPricesByItemIDs, callInfo)
+	mock.lockGetScheduledPricesByItemIDs.Unlock()
+	return mock.GetScheduledPricesByItemIDsFunc(ctx, ids)
+}
+
+// GetScheduledPricesByItemIDsCalls gets all the calls that were made to GetScheduledPricesByItemIDs.
+// Check the length with:
+//
+//	len(mockedRepository.GetScheduledPricesByItemIDsCalls())
+func (mock *RepositoryMock) GetScheduledPricesByItemIDsCalls() []struct {
+	Ctx context.Context
+	Ids []uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Ids []uuid.UUID
+	}
+	mock.lockGetScheduledPricesByItemIDs.RLock()
+	calls = mock.calls.GetScheduledPricesByItemIDs
+	mock.lockGetScheduledPricesByItemIDs.RUnlock()
+	return calls
+}
+
 // RemoveItem calls RemoveItemFunc.
 func (mock *RepositoryMock) RemoveItem(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
 	if mock.RemoveItemFunc == nil {
@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/icrowley/fake"
+	"github.com/lib/pq"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/audit"
 )
 
 func Test_ItemRepository_GetItems_ShouldReturnItems(t *testing.T) {
@@ -176,6 +179,81 @@ func Test_ItemRepository_GetItemByID_WhenErrorOccurs_ShouldReturnError(t *testin
 	}
 }
 
+func Test_ItemRepository_FindItems_ShouldFilterItems(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	const pageSize = 5
+	const page = 1
+
+	filter := Filter{Manufacturer: fake.Brand(), Name: "apple", MinPrice: 100}
+	expectedItem := Item{ID: uuid.New(), Name: fake.ProductName(), Price: 150, Manufacturer: filter.Manufacturer}
+
+	mock.ExpectQuery("SELECT id, name, price, manufacturer FROM item WHERE \\(\\$1 = '' OR manufacturer = \\$1\\) AND \\(\\$2 = '' OR name ILIKE '%' \\|\\| \\$2 \\|\\| '%'\\) AND \\(\\$3 = 0 OR price >= \\$3\\) AND \\(\\$4 = 0 OR price <= \\$4\\) ORDER BY id LIMIT \\$5 OFFSET \\$6").
+		WithArgs(filter.Manufacturer, filter.Name, filter.MinPrice, filter.MaxPrice, pageSize, page*pageSize).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "price", "manufacturer"}).
+				FromCSVString(convertObjectToCSV(expectedItem)),
+		).
+		RowsWillBeClosed()
+
+	sut := NewRepository(dbConn)
+	ctx := context.Background()
+
+	result, err := sut.FindItems(ctx, filter, page, pageSize)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when finding items", err)
+	}
+
+	if len(result) != 1 || result[0] != expectedItem {
+		t.Fatalf("Unexpected items were given, '%+v'. Expected '%+v'.", result, expectedItem)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_ItemRepository_GetItemsByIDs_ShouldReturnItemsInOneQuery(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	item1 := Item{ID: uuid.New(), Name: fake.ProductName(), Price: 23, Manufacturer: fake.Brand()}
+	item2 := Item{ID: uuid.New(), Name: fake.ProductName(), Price: 4, Manufacturer: fake.Brand()}
+	ids := []uuid.UUID{item1.ID, item2.ID}
+
+	mock.ExpectQuery("SELECT id, name, price, manufacturer FROM item WHERE id = ANY \\(\\$1\\)").
+		WithArgs(pq.Array(ids)).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "price", "manufacturer"}).
+				FromCSVString(convertObjectToCSV(item1)).
+				FromCSVString(convertObjectToCSV(item2)),
+		).
+		RowsWillBeClosed()
+
+	sut := NewRepository(dbConn)
+	ctx := context.Background()
+
+	result, err := sut.GetItemsByIDs(ctx, ids)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when fetching items", err)
+	}
+
+	if len(result) != len(ids) {
+		t.Fatalf("Unexpected number of items were given, '%d'. Expected '%d'.", len(result), len(ids))
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func Test_ItemRepository_AddItem_ShouldReturnInsertedItem(t *testing.T) {
 	dbConn, mock, err := sqlmock.New()
 	if err != nil {
@@ -187,9 +265,20 @@ func Test_ItemRepository_AddItem_ShouldReturnInsertedItem(t *testing.T) {
 	expectedId := uuid.New()
 	expectedItem := Item{ID: expectedId, Name: fake.ProductName(), Price: 23, Manufacturer: fake.Brand()}
 
+	mock.ExpectBegin()
 	mock.ExpectQuery("INSERT INTO item \\(name, price, manufacturer\\) VALUES \\(\\$1, \\$2, \\$3\\)").
 		WithArgs(expectedItem.Name, expectedItem.Price, expectedItem.Manufacturer).
 		WillReturnRows(sqlmock.NewRows(columns).FromCSVString(expectedId.String()))
+	mock.ExpectExec("INSERT INTO price_history \\(item_id, price\\) VALUES \\(\\$1, \\$2\\)").
+		WithArgs(expectedId, expectedItem.Price).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox \\(topic, key, payload\\) VALUES \\(\\$1, \\$2, \\$3\\)").
+		WithArgs(ItemCreatedTopic, expectedId.String(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(audit.AnonymousActor, "", audit.Create, audit.ItemEntityType, expectedId, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	sut := NewRepository(dbConn)
 	ctx := context.Background()
@@ -218,9 +307,11 @@ func Test_ItemRepository_AddItem_WhenErrorOccurs_ShouldReturnError(t *testing.T)
 	expectedItem := Item{ID: uuid.New(), Name: fake.ProductName(), Price: 23, Manufacturer: fake.Brand()}
 	expectedError := createError()
 
+	mock.ExpectBegin()
 	mock.ExpectQuery("INSERT INTO item \\(name, price, manufacturer\\) VALUES \\(\\$1, \\$2, \\$3\\)").
 		WithArgs(expectedItem.Name, expectedItem.Price, expectedItem.Manufacturer).
 		WillReturnError(expectedError)
+	mock.ExpectRollback()
 
 	sut := NewRepository(dbConn)
 	ctx := context.Background()
@@ -244,9 +335,18 @@ func Test_ItemRepository_UpdateItem_ShouldUpdateSpecificItem(t *testing.T) {
 	expectedItem := Item{ID: uuid.New(), Name: fake.ProductName(), Price: 23, Manufacturer: fake.Brand()}
 
 	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT name, price, manufacturer FROM item WHERE id = \\$1 FOR UPDATE").
+		WithArgs(expectedItem.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "price", "manufacturer"}).AddRow("Lens", expectedItem.Price, "Canon"))
 	mock.ExpectExec("UPDATE item SET name = \\$1, price = \\$2, manufacturer = \\$3 WHERE id = \\$4").
 		WithArgs(expectedItem.Name, expectedItem.Price, expectedItem.Manufacturer, expectedItem.ID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox \\(topic, key, payload\\) VALUES \\(\\$1, \\$2, \\$3\\)").
+		WithArgs(ItemUpdatedTopic, expectedItem.ID.String(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(audit.AnonymousActor, "", audit.Update, audit.ItemEntityType, expectedItem.ID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
 	sut := NewRepository(dbConn)
@@ -266,6 +366,49 @@ func Test_ItemRepository_UpdateItem_ShouldUpdateSpecificItem(t *testing.T) {
 	}
 }
 
+func Test_ItemRepository_UpdateItem_WhenPriceChanges_ShouldRecordPriceHistory(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+	expectedItem := Item{ID: uuid.New(), Name: fake.ProductName(), Price: 150, Manufacturer: fake.Brand()}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT name, price, manufacturer FROM item WHERE id = \\$1 FOR UPDATE").
+		WithArgs(expectedItem.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "price", "manufacturer"}).AddRow(expectedItem.Name, 120, expectedItem.Manufacturer))
+	mock.ExpectExec("UPDATE item SET name = \\$1, price = \\$2, manufacturer = \\$3 WHERE id = \\$4").
+		WithArgs(expectedItem.Name, expectedItem.Price, expectedItem.Manufacturer, expectedItem.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO price_history \\(item_id, price\\) VALUES \\(\\$1, \\$2\\)").
+		WithArgs(expectedItem.ID, expectedItem.Price).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox \\(topic, key, payload\\) VALUES \\(\\$1, \\$2, \\$3\\)").
+		WithArgs(ItemUpdatedTopic, expectedItem.ID.String(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(audit.AnonymousActor, "", audit.Update, audit.ItemEntityType, expectedItem.ID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	sut := NewRepository(dbConn)
+	ctx := context.Background()
+
+	result, err := sut.UpdateItem(ctx, &expectedItem)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when updating item", err)
+	}
+
+	if result != expectedItem {
+		t.Fatalf("Unexpected item was given, '%+v'. Expected '%+v'.", result, expectedItem)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func Test_ItemRepository_UpdateItem_WhenErrorOccurs_ShouldReturnError(t *testing.T) {
 	dbConn, mock, err := sqlmock.New()
 	if err != nil {
@@ -277,6 +420,9 @@ func Test_ItemRepository_UpdateItem_WhenErrorOccurs_ShouldReturnError(t *testing
 	expectedError := createError()
 
 	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT name, price, manufacturer FROM item WHERE id = \\$1 FOR UPDATE").
+		WithArgs(expectedItem.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "price", "manufacturer"}).AddRow("Lens", expectedItem.Price, "Canon"))
 	mock.ExpectExec("UPDATE item SET name = \\$1, price = \\$2, manufacturer = \\$3 WHERE id = \\$4").
 		WithArgs(expectedItem.Name, expectedItem.Price, expectedItem.Manufacturer, expectedItem.ID).
 		WillReturnError(expectedError)
@@ -295,6 +441,43 @@ func Test_ItemRepository_UpdateItem_WhenErrorOccurs_ShouldReturnError(t *testing
 	}
 }
 
+func Test_ItemRepository_UpdateItem_WhenAuditEntryCannotBeRecorded_ShouldRollback(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	expectedItem := Item{ID: uuid.New(), Name: fake.ProductName(), Price: 23, Manufacturer: fake.Brand()}
+	expectedError := createError()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT name, price, manufacturer FROM item WHERE id = \\$1 FOR UPDATE").
+		WithArgs(expectedItem.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "price", "manufacturer"}).AddRow("Lens", expectedItem.Price, "Canon"))
+	mock.ExpectExec("UPDATE item SET name = \\$1, price = \\$2, manufacturer = \\$3 WHERE id = \\$4").
+		WithArgs(expectedItem.Name, expectedItem.Price, expectedItem.Manufacturer, expectedItem.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox \\(topic, key, payload\\) VALUES \\(\\$1, \\$2, \\$3\\)").
+		WithArgs(ItemUpdatedTopic, expectedItem.ID.String(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO audit_log").
+		WillReturnError(expectedError)
+	mock.ExpectRollback()
+
+	sut := NewRepository(dbConn)
+	ctx := context.Background()
+
+	_, err = sut.UpdateItem(ctx, &expectedItem)
+	if !errors.Is(expectedError, err) {
+		t.Fatalf("Expected failure '%s', but received '%s' when simulating failure while auditing an update", expectedError, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func Test_ItemRepository_RemoveItem_ShouldReturnID(t *testing.T) {
 	dbConn, mock, err := sqlmock.New()
 	if err != nil {
@@ -304,10 +487,17 @@ func Test_ItemRepository_RemoveItem_ShouldReturnID(t *testing.T) {
 
 	expectedItemID := uuid.New()
 
-	mock.ExpectPrepare("DELETE FROM item WHERE id = \\$1").
-		ExpectExec().
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM item WHERE id = \\$1 RETURNING name, price, manufacturer").
 		WithArgs(expectedItemID).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+		WillReturnRows(sqlmock.NewRows([]string{"name", "price", "manufacturer"}).AddRow("Lens", 120000, "Canon"))
+	mock.ExpectExec("INSERT INTO outbox \\(topic, key, payload\\) VALUES \\(\\$1, \\$2, \\$3\\)").
+		WithArgs(ItemDeletedTopic, expectedItemID.String(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(audit.AnonymousActor, "", audit.Delete, audit.ItemEntityType, expectedItemID, sqlmock.AnyArg(), nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	sut := NewRepository(dbConn)
 	ctx := context.Background()
@@ -326,6 +516,33 @@ func Test_ItemRepository_RemoveItem_ShouldReturnID(t *testing.T) {
 	}
 }
 
+func Test_ItemRepository_RemoveItem_WhenItemDoesNotExist_ShouldNotWriteToOutbox(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	expectedItemID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM item WHERE id = \\$1 RETURNING name, price, manufacturer").
+		WithArgs(expectedItemID).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "price", "manufacturer"}))
+	mock.ExpectRollback()
+
+	sut := NewRepository(dbConn)
+
+	result, err := sut.RemoveItem(context.Background(), expectedItemID)
+	if err != nil || result != expectedItemID {
+		t.Fatalf("Expected the id and no error. Got %s, %v", result, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func Test_ItemRepository_RemoveItem_WhenErrorOccurs_ShouldReturnError(t *testing.T) {
 	dbConn, mock, err := sqlmock.New()
 	if err != nil {
@@ -336,10 +553,11 @@ func Test_ItemRepository_RemoveItem_WhenErrorOccurs_ShouldReturnError(t *testing
 	expectedItemID := uuid.New()
 	expectedError := createError()
 
-	mock.ExpectPrepare("DELETE FROM item WHERE id = \\$1").
-		ExpectExec().
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM item WHERE id = \\$1 RETURNING name, price, manufacturer").
 		WithArgs(expectedItemID).
 		WillReturnError(expectedError)
+	mock.ExpectRollback()
 
 	sut := NewRepository(dbConn)
 	ctx := context.Background()
@@ -358,6 +576,215 @@ func Test_ItemRepository_RemoveItem_WhenErrorOccurs_ShouldReturnError(t *testing
 	}
 }
 
+func Test_ItemRepository_GetPriceHistory_ShouldReturnPriceChanges(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	const pageSize = 5
+	const page = 0
+
+	itemID := uuid.New()
+	now := time.Now().UTC()
+
+	mock.ExpectQuery("SELECT item_id, price, effective_at FROM price_history WHERE item_id = \\$1 ORDER BY effective_at DESC LIMIT \\$2 OFFSET \\$3").
+		WithArgs(itemID, pageSize, page*pageSize).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"item_id", "price", "effective_at"}).
+				AddRow(itemID, 150, now).
+				AddRow(itemID, 120, now.Add(-24*time.Hour)),
+		).
+		RowsWillBeClosed()
+
+	sut := NewRepository(dbConn)
+	ctx := context.Background()
+
+	result, err := sut.GetPriceHistory(ctx, itemID, page, pageSize)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when fetching price history", err)
+	}
+
+	expected := []PriceChange{
+		{ItemID: itemID, Price: 150, EffectiveAt: now},
+		{ItemID: itemID, Price: 120, EffectiveAt: now.Add(-24 * time.Hour)},
+	}
+	if len(result) != len(expected) || result[0] != expected[0] || result[1] != expected[1] {
+		t.Fatalf("Unexpected price history was given, '%+v'. Expected '%+v'.", result, expected)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_ItemRepository_GetPriceHistoryByItemIDs_ShouldReturnPriceChangesInOneQuery(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	now := time.Now().UTC()
+
+	mock.ExpectQuery("SELECT item_id, price, effective_at FROM price_history WHERE item_id = ANY \\(\\$1\\) ORDER BY effective_at DESC").
+		WithArgs(pq.Array(ids)).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"item_id", "price", "effective_at"}).
+				AddRow(ids[0], 150, now).
+				AddRow(ids[1], 120, now.Add(-24*time.Hour)),
+		).
+		RowsWillBeClosed()
+
+	sut := NewRepository(dbConn)
+	ctx := context.Background()
+
+	result, err := sut.GetPriceHistoryByItemIDs(ctx, ids)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when fetching price history", err)
+	}
+
+	if len(result) != 2 || result[0].ItemID != ids[0] || result[1].ItemID != ids[1] {
+		t.Fatalf("Unexpected price history was given, '%+v'.", result)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_ItemRepository_AddScheduledPrice_ShouldReturnScheduledPrice(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	expectedID := uuid.New()
+	itemID := uuid.New()
+	effectiveAt := time.Now().Add(time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO scheduled_price \\(item_id, price, effective_at\\) VALUES \\(\\$1, \\$2, \\$3\\) RETURNING id").
+		WithArgs(itemID, Decimal(150), effectiveAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(expectedID))
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(audit.AnonymousActor, "", audit.Create, audit.ScheduledPriceEntityType, expectedID, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	sut := NewRepository(dbConn)
+	ctx := context.Background()
+
+	result, err := sut.AddScheduledPrice(ctx, itemID, 150, effectiveAt)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when scheduling a price", err)
+	}
+
+	if result.ID != expectedID || result.ItemID != itemID || result.Price != 150 || !result.EffectiveAt.Equal(effectiveAt) {
+		t.Fatalf("Unexpected scheduled price was given, '%+v'.", result)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_ItemRepository_ApplyScheduledPrices_ShouldUpdateItemsAndRecordHistory(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	now := time.Now()
+	scheduledPrice := ScheduledPrice{ID: uuid.New(), ItemID: uuid.New(), Price: 150, EffectiveAt: now.Add(-time.Minute)}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, item_id, price, effective_at FROM scheduled_price WHERE applied_at IS NULL AND effective_at <= \\$1 ORDER BY effective_at FOR UPDATE SKIP LOCKED").
+		WithArgs(now).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "item_id", "price", "effective_at"}).
+				AddRow(scheduledPrice.ID, scheduledPrice.ItemID, scheduledPrice.Price, scheduledPrice.EffectiveAt),
+		)
+	mock.ExpectQuery("SELECT name, price, manufacturer FROM item WHERE id = \\$1 FOR UPDATE").
+		WithArgs(scheduledPrice.ItemID).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "price", "manufacturer"}).AddRow("Lens", 120, "Canon"))
+	mock.ExpectQuery("UPDATE item SET price = \\$1 WHERE id = \\$2 RETURNING id, name, price, manufacturer").
+		WithArgs(scheduledPrice.Price, scheduledPrice.ItemID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "manufacturer"}).AddRow(scheduledPrice.ItemID, "Lens", scheduledPrice.Price, "Canon"))
+	mock.ExpectExec("INSERT INTO price_history \\(item_id, price, effective_at\\) VALUES \\(\\$1, \\$2, \\$3\\)").
+		WithArgs(scheduledPrice.ItemID, scheduledPrice.Price, scheduledPrice.EffectiveAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE scheduled_price SET applied_at = \\$1 WHERE id = \\$2").
+		WithArgs(now, scheduledPrice.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox \\(topic, key, payload\\) VALUES \\(\\$1, \\$2, \\$3\\)").
+		WithArgs(ItemUpdatedTopic, scheduledPrice.ItemID.String(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(PriceSchedulerActor, "", audit.Update, audit.ItemEntityType, scheduledPrice.ItemID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	sut := NewRepository(dbConn)
+	ctx := audit.WithActor(context.Background(), PriceSchedulerActor)
+
+	result, err := sut.ApplyScheduledPrices(ctx, now)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when applying scheduled prices", err)
+	}
+
+	if len(result) != 1 || result[0].ID != scheduledPrice.ID || result[0].AppliedAt == nil {
+		t.Fatalf("Unexpected applied prices were given, '%+v'.", result)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_ItemRepository_ApplyScheduledPrices_WhenErrorOccurs_ShouldRollback(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	now := time.Now()
+	scheduledPrice := ScheduledPrice{ID: uuid.New(), ItemID: uuid.New(), Price: 150, EffectiveAt: now.Add(-time.Minute)}
+	expectedError := createError()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, item_id, price, effective_at FROM scheduled_price").
+		WithArgs(now).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "item_id", "price", "effective_at"}).
+				AddRow(scheduledPrice.ID, scheduledPrice.ItemID, scheduledPrice.Price, scheduledPrice.EffectiveAt),
+		)
+	mock.ExpectQuery("SELECT name, price, manufacturer FROM item WHERE id = \\$1 FOR UPDATE").
+		WithArgs(scheduledPrice.ItemID).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "price", "manufacturer"}).AddRow("Lens", 120, "Canon"))
+	mock.ExpectQuery("UPDATE item SET price = \\$1 WHERE id = \\$2 RETURNING id, name, price, manufacturer").
+		WithArgs(scheduledPrice.Price, scheduledPrice.ItemID).
+		WillReturnError(expectedError)
+	mock.ExpectRollback()
+
+	sut := NewRepository(dbConn)
+	ctx := context.Background()
+
+	_, err = sut.ApplyScheduledPrices(ctx, now)
+	if !errors.Is(expectedError, err) {
+		t.Fatalf("Expected failure '%s', but received '%s' when simulating failure while applying scheduled prices", expectedError, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func convertObjectToCSV(item Item) string {
 	return fmt.Sprintf("%s,%s,%d,%s", item.ID.String(), item.Name, item.Price, item.Manufacturer)
 }
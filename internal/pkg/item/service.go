@@ -2,13 +2,18 @@ package item
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"github.com/google/uuid"
+	"time"
 )
 
 // Service ..
 type Service interface {
 	GetItems(ctx context.Context, page int64, pageSize int64) ([]Item, error)
 	GetItemByID(ctx context.Context, id uuid.UUID) (Item, error)
+	FindItems(ctx context.Context, filter Filter, page int64, pageSize int64) ([]Item, error)
+	GetItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]Item, error)
 	AddItem(
 		ctx context.Context,
 		item *ItemDTO,
@@ -18,6 +23,16 @@ type Service interface {
 		item *Item,
 	) (Item, ServiceError)
 	RemoveItem(ctx context.Context, id uuid.UUID) (uuid.UUID, ServiceError)
+	GetPriceHistory(ctx context.Context, id uuid.UUID, page int64, pageSize int64) ([]PriceChange, ServiceError)
+	GetPriceHistoryByItemIDs(ctx context.Context, ids []uuid.UUID) ([]PriceChange, error)
+	GetScheduledPrices(ctx context.Context, id uuid.UUID) ([]ScheduledPrice, ServiceError)
+	GetScheduledPricesByItemIDs(ctx context.Context, ids []uuid.UUID) ([]ScheduledPrice, error)
+	SchedulePrice(
+		ctx context.Context,
+		id uuid.UUID,
+		price *ScheduledPriceDTO,
+	) (ScheduledPrice, ServiceError)
+	ApplyScheduledPrices(ctx context.Context, now time.Time) ([]ScheduledPrice, error)
 }
 
 // NewService ..
@@ -41,6 +56,19 @@ func (s *service) GetItemByID(ctx context.Context, id uuid.UUID) (Item, error) {
 	return s.Repository.GetItemByID(ctx, id)
 }
 
+// FindItems ..
+func (s *service) FindItems(ctx context.Context, filter Filter, page int64, pageSize int64) ([]Item, error) {
+	return s.Repository.FindItems(ctx, filter, page, pageSize)
+}
+
+// GetItemsByIDs ..
+func (s *service) GetItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]Item, error) {
+	if len(ids) == 0 {
+		return []Item{}, nil
+	}
+	return s.Repository.GetItemsByIDs(ctx, ids)
+}
+
 // AddItem ..
 func (s *service) AddItem(ctx context.Context, item *ItemDTO) (Item, error) {
 	err := item.Validate()
@@ -59,7 +87,9 @@ func (s *service) UpdateItem(ctx context.Context, item *Item) (Item, ServiceErro
 	}
 
 	result, err := s.Repository.UpdateItem(ctx, item)
-	if err != nil {
+	if errors.Is(err, sql.ErrNoRows) {
+		return Item{}, CreateServiceError(err.Error(), ItemNotFound)
+	} else if err != nil {
 		return Item{}, CreateServiceError(err.Error(), UnknownException)
 	}
 
@@ -75,3 +105,87 @@ func (s *service) RemoveItem(ctx context.Context, id uuid.UUID) (uuid.UUID, Serv
 
 	return result, nil
 }
+
+// GetPriceHistory ..
+func (s *service) GetPriceHistory(ctx context.Context, id uuid.UUID, page int64, pageSize int64) ([]PriceChange, ServiceError) {
+	if serviceError := s.findItem(ctx, id); serviceError != nil {
+		return nil, serviceError
+	}
+
+	result, err := s.Repository.GetPriceHistory(ctx, id, page, pageSize)
+	if err != nil {
+		return nil, CreateServiceError(err.Error(), UnknownException)
+	}
+
+	return result, nil
+}
+
+// GetPriceHistoryByItemIDs ..
+func (s *service) GetPriceHistoryByItemIDs(ctx context.Context, ids []uuid.UUID) ([]PriceChange, error) {
+	if len(ids) == 0 {
+		return []PriceChange{}, nil
+	}
+	return s.Repository.GetPriceHistoryByItemIDs(ctx, ids)
+}
+
+// GetScheduledPrices ..
+func (s *service) GetScheduledPrices(ctx context.Context, id uuid.UUID) ([]ScheduledPrice, ServiceError) {
+	if serviceError := s.findItem(ctx, id); serviceError != nil {
+		return nil, serviceError
+	}
+
+	result, err := s.Repository.GetScheduledPrices(ctx, id)
+	if err != nil {
+		return nil, CreateServiceError(err.Error(), UnknownException)
+	}
+
+	return result, nil
+}
+
+// GetScheduledPricesByItemIDs ..
+func (s *service) GetScheduledPricesByItemIDs(ctx context.Context, ids []uuid.UUID) ([]ScheduledPrice, error) {
+	if len(ids) == 0 {
+		return []ScheduledPrice{}, nil
+	}
+	return s.Repository.GetScheduledPricesByItemIDs(ctx, ids)
+}
+
+// SchedulePrice ..
+func (s *service) SchedulePrice(ctx context.Context, id uuid.UUID, price *ScheduledPriceDTO) (ScheduledPrice, ServiceError) {
+	err := price.Validate()
+	if err != nil {
+		return ScheduledPrice{}, CreateServiceError(err.Error(), InvalidPrice)
+	}
+
+	if !price.EffectiveAt.After(time.Now()) {
+		return ScheduledPrice{}, CreateServiceError("effective_at: must be in the future.", InvalidPrice)
+	}
+
+	if serviceError := s.findItem(ctx, id); serviceError != nil {
+		return ScheduledPrice{}, serviceError
+	}
+
+	result, err := s.Repository.AddScheduledPrice(ctx, id, price.Price, price.EffectiveAt)
+	if err != nil {
+		return ScheduledPrice{}, CreateServiceError(err.Error(), UnknownException)
+	}
+
+	return result, nil
+}
+
+// ApplyScheduledPrices ..
+func (s *service) ApplyScheduledPrices(ctx context.Context, now time.Time) ([]ScheduledPrice, error) {
+	return s.Repository.ApplyScheduledPrices(ctx, now)
+}
+
+// findItem checks that the item with id exists, so that asking after the prices of an unknown
+// item is told so rather than given none.
+func (s *service) findItem(ctx context.Context, id uuid.UUID) ServiceError {
+	_, err := s.Repository.GetItemByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return CreateServiceError(err.Error(), ItemNotFound)
+	} else if err != nil {
+		return CreateServiceError(err.Error(), UnknownException)
+	}
+	return nil
+}
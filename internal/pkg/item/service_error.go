@@ -16,6 +16,12 @@ const (
 	// InvalidItem ..
 	InvalidItem ServiceStatusCode = "InvalidItem"
 
+	// InvalidPrice ..
+	InvalidPrice ServiceStatusCode = "InvalidPrice"
+
+	// Forbidden is returned when the caller isn't allowed to perform the operation.
+	Forbidden ServiceStatusCode = "Forbidden"
+
 	// UnknownException ..
 	UnknownException ServiceStatusCode = "UnknownException"
 )
@@ -2,9 +2,11 @@ package item
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"github.com/google/uuid"
 	"testing"
+	"time"
 
 	"github.com/icrowley/fake"
 )
@@ -268,6 +270,30 @@ func Test_ItemService_UpdateCartItem_WhenUnknownErrorOccurs_ShouldReturnServiceE
 	}
 }
 
+func Test_ItemService_UpdateCartItem_WhenItemWasRemoved_ShouldReturnItemNotFound(t *testing.T) {
+	removedItem := Item{
+		ID:           uuid.New(),
+		Name:         fake.ProductName(),
+		Price:        Decimal(99),
+		Manufacturer: fake.Brand(),
+	}
+
+	mockRepository := &RepositoryMock{
+		UpdateItemFunc: func(ctx context.Context, item *Item) (Item, error) {
+			return Item{}, sql.ErrNoRows
+		},
+	}
+
+	ctx := context.Background()
+	sut := NewService(mockRepository)
+
+	_, serviceError := sut.UpdateItem(ctx, &removedItem)
+
+	if serviceError == nil || serviceError.StatusCode() != ItemNotFound {
+		t.Errorf("Expected an %s service error. Got %+v", ItemNotFound, serviceError)
+	}
+}
+
 func Test_ItemService_RemoveCartItem_WhenItemExists_ShouldReturnItemID(t *testing.T) {
 	var idCalled uuid.UUID
 	deletedItem := Item{
@@ -331,3 +357,135 @@ func Test_ItemService_RemoveCartItem_WhenUnknownErrorOccurs_ShouldReturnServiceE
 		t.Errorf("Send was called %d times", callsToSend)
 	}
 }
+
+func Test_ItemService_SchedulePrice_WhenGivenValidPrice_ShouldReturnScheduledPrice(t *testing.T) {
+	itemID := uuid.New()
+	effectiveAt := time.Now().Add(24 * time.Hour)
+	expectedPrice := ScheduledPrice{ID: uuid.New(), ItemID: itemID, Price: 150, EffectiveAt: effectiveAt}
+
+	mockRepository := &RepositoryMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (Item, error) {
+			return Item{ID: id}, nil
+		},
+		AddScheduledPriceFunc: func(ctx context.Context, id uuid.UUID, price Decimal, effectiveAt time.Time) (ScheduledPrice, error) {
+			return expectedPrice, nil
+		},
+	}
+
+	ctx := context.Background()
+	sut := NewService(mockRepository)
+
+	result, serviceError := sut.SchedulePrice(ctx, itemID, &ScheduledPriceDTO{Price: 150, EffectiveAt: effectiveAt})
+	if serviceError != nil {
+		t.Fatalf("Should not have failed! %s", serviceError.Message())
+	}
+
+	if result != expectedPrice {
+		t.Errorf("Expected scheduled price: %+v. Got %+v", expectedPrice, result)
+	}
+
+	callsToSend := len(mockRepository.AddScheduledPriceCalls())
+	if callsToSend != 1 {
+		t.Errorf("Send was called %d times", callsToSend)
+	}
+}
+
+func Test_ItemService_SchedulePrice_WhenEffectiveAtIsInThePast_ShouldReturnServiceError(t *testing.T) {
+	mockRepository := &RepositoryMock{}
+
+	ctx := context.Background()
+	sut := NewService(mockRepository)
+
+	_, serviceError := sut.SchedulePrice(ctx, uuid.New(), &ScheduledPriceDTO{Price: 150, EffectiveAt: time.Now().Add(-time.Hour)})
+	if serviceError == nil || serviceError.StatusCode() != InvalidPrice {
+		t.Fatalf("Expected an %s service error. Got %+v", InvalidPrice, serviceError)
+	}
+
+	callsToSend := len(mockRepository.AddScheduledPriceCalls())
+	if callsToSend != 0 {
+		t.Errorf("Send was called %d times", callsToSend)
+	}
+}
+
+func Test_ItemService_SchedulePrice_WhenItemDoesNotExist_ShouldReturnServiceError(t *testing.T) {
+	mockRepository := &RepositoryMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (Item, error) {
+			return Item{}, sql.ErrNoRows
+		},
+	}
+
+	ctx := context.Background()
+	sut := NewService(mockRepository)
+
+	_, serviceError := sut.SchedulePrice(ctx, uuid.New(), &ScheduledPriceDTO{Price: 150, EffectiveAt: time.Now().Add(time.Hour)})
+	if serviceError == nil || serviceError.StatusCode() != ItemNotFound {
+		t.Fatalf("Expected an %s service error. Got %+v", ItemNotFound, serviceError)
+	}
+
+	callsToSend := len(mockRepository.AddScheduledPriceCalls())
+	if callsToSend != 0 {
+		t.Errorf("Send was called %d times", callsToSend)
+	}
+}
+
+func Test_ItemService_GetPriceHistory_WhenItemDoesNotExist_ShouldReturnServiceError(t *testing.T) {
+	mockRepository := &RepositoryMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (Item, error) {
+			return Item{}, sql.ErrNoRows
+		},
+	}
+
+	ctx := context.Background()
+	sut := NewService(mockRepository)
+
+	_, serviceError := sut.GetPriceHistory(ctx, uuid.New(), 0, 10)
+	if serviceError == nil || serviceError.StatusCode() != ItemNotFound {
+		t.Fatalf("Expected an %s service error. Got %+v", ItemNotFound, serviceError)
+	}
+
+	callsToSend := len(mockRepository.GetPriceHistoryCalls())
+	if callsToSend != 0 {
+		t.Errorf("Send was called %d times", callsToSend)
+	}
+}
+
+func Test_ItemService_GetScheduledPrices_WhenItemDoesNotExist_ShouldReturnServiceError(t *testing.T) {
+	mockRepository := &RepositoryMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (Item, error) {
+			return Item{}, sql.ErrNoRows
+		},
+	}
+
+	ctx := context.Background()
+	sut := NewService(mockRepository)
+
+	_, serviceError := sut.GetScheduledPrices(ctx, uuid.New())
+	if serviceError == nil || serviceError.StatusCode() != ItemNotFound {
+		t.Fatalf("Expected an %s service error. Got %+v", ItemNotFound, serviceError)
+	}
+
+	callsToSend := len(mockRepository.GetScheduledPricesCalls())
+	if callsToSend != 0 {
+		t.Errorf("Send was called %d times", callsToSend)
+	}
+}
+
+func Test_ItemService_GetItemsByIDs_WhenGivenNoIDs_ShouldNotCallRepository(t *testing.T) {
+	mockRepository := &RepositoryMock{}
+
+	ctx := context.Background()
+	sut := NewService(mockRepository)
+
+	results, err := sut.GetItemsByIDs(ctx, []uuid.UUID{})
+	if err != nil {
+		t.Fatalf("Should not have failed!")
+	}
+
+	if len(results) != 0 {
+		t.Errorf("Expected no items. Got %d", len(results))
+	}
+
+	if calls := len(mockRepository.GetItemsByIDsCalls()); calls != 0 {
+		t.Errorf("GetItemsByIDs was called %d times", calls)
+	}
+}
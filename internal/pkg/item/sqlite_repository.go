@@ -0,0 +1,332 @@
+package item
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewSQLiteRepository returns a Repository storing items in the SQLite database DBConn, whose
+// tables are created by driver.ConnectSQLite. As there's no outbox to relay them, changes aren't
+// published.
+func NewSQLiteRepository(DBConn *sql.DB) Repository {
+	return &sqliteRepository{DBConn: DBConn}
+}
+
+// sqliteRepository stores times as nanoseconds since the Unix epoch, so that they sort in order.
+type sqliteRepository struct {
+	DBConn *sql.DB
+}
+
+// GetItems ..
+func (r *sqliteRepository) GetItems(ctx context.Context, page int64, pageSize int64) ([]Item, error) {
+	return r.FindItems(ctx, Filter{}, page, pageSize)
+}
+
+// GetItemByID ..
+func (r *sqliteRepository) GetItemByID(ctx context.Context, id uuid.UUID) (Item, error) {
+	var item Item
+	row := r.DBConn.QueryRowContext(ctx, "SELECT id, name, price, manufacturer FROM item WHERE id = ?1", id)
+	err := row.Scan(&item.ID, &item.Name, &item.Price, &item.Manufacturer)
+	if err != nil {
+		return Item{}, err
+	}
+
+	return item, nil
+}
+
+// FindItems ..
+func (r *sqliteRepository) FindItems(ctx context.Context, filter Filter, page int64, pageSize int64) ([]Item, error) {
+	limit := pageSize
+	offset := page * pageSize
+
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT id, name, price, manufacturer FROM item WHERE (?1 = '' OR manufacturer = ?1) AND (?2 = '' OR name LIKE '%' || ?2 || '%') AND (?3 = 0 OR price >= ?3) AND (?4 = 0 OR price <= ?4) ORDER BY id LIMIT ?5 OFFSET ?6", filter.Manufacturer, filter.Name, filter.MinPrice, filter.MaxPrice, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanItems(rows)
+}
+
+// GetItemsByIDs returns the items matching ids in a single query. Unknown ids are skipped.
+func (r *sqliteRepository) GetItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]Item, error) {
+	if len(ids) == 0 {
+		return []Item{}, nil
+	}
+
+	placeholders, args := in(ids)
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT id, name, price, manufacturer FROM item WHERE id IN ("+placeholders+")", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanItems(rows)
+}
+
+// AddItem ..
+func (r *sqliteRepository) AddItem(ctx context.Context, name string, price Decimal, manufacturer string) (Item, error) {
+	tx, err := r.DBConn.BeginTx(ctx, nil)
+	if err != nil {
+		return Item{}, err
+	}
+
+	item := Item{
+		ID:           uuid.New(),
+		Name:         name,
+		Price:        price,
+		Manufacturer: manufacturer,
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO item (id, name, price, manufacturer) VALUES (?1, ?2, ?3, ?4)", item.ID, name, price, manufacturer)
+	if err != nil {
+		tx.Rollback()
+		return Item{}, err
+	}
+
+	err = recordSQLitePriceChange(ctx, tx, item.ID, price, time.Now())
+	if err != nil {
+		tx.Rollback()
+		return Item{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return Item{}, err
+	}
+
+	return item, nil
+}
+
+// UpdateItem ..
+func (r *sqliteRepository) UpdateItem(ctx context.Context, item *Item) (Item, error) {
+	tx, err := r.DBConn.BeginTx(ctx, nil)
+	if err != nil {
+		return Item{}, err
+	}
+
+	var previousPrice Decimal
+	err = tx.QueryRowContext(ctx, "SELECT price FROM item WHERE id = ?1", item.ID).Scan(&previousPrice)
+	if err != nil {
+		tx.Rollback()
+		return Item{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE item SET name = ?1, price = ?2, manufacturer = ?3 WHERE id = ?4", item.Name, item.Price, item.Manufacturer, item.ID)
+	if err != nil {
+		tx.Rollback()
+		return Item{}, err
+	}
+
+	if previousPrice != item.Price {
+		err = recordSQLitePriceChange(ctx, tx, item.ID, item.Price, time.Now())
+		if err != nil {
+			tx.Rollback()
+			return Item{}, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return Item{}, err
+	}
+
+	return *item, nil
+}
+
+// RemoveItem ..
+func (r *sqliteRepository) RemoveItem(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	_, err := r.DBConn.ExecContext(ctx, "DELETE FROM item WHERE id = ?1", id)
+	if err != nil {
+		return id, err
+	}
+
+	return id, nil
+}
+
+// GetPriceHistory ..
+func (r *sqliteRepository) GetPriceHistory(ctx context.Context, id uuid.UUID, page int64, pageSize int64) ([]PriceChange, error) {
+	limit := pageSize
+	offset := page * pageSize
+
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT item_id, price, effective_at FROM price_history WHERE item_id = ?1 ORDER BY effective_at DESC, id DESC LIMIT ?2 OFFSET ?3", id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSQLitePriceChanges(rows)
+}
+
+// GetPriceHistoryByItemIDs returns the full price history of every item in ids, newest first.
+func (r *sqliteRepository) GetPriceHistoryByItemIDs(ctx context.Context, ids []uuid.UUID) ([]PriceChange, error) {
+	if len(ids) == 0 {
+		return []PriceChange{}, nil
+	}
+
+	placeholders, args := in(ids)
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT item_id, price, effective_at FROM price_history WHERE item_id IN ("+placeholders+") ORDER BY effective_at DESC, id DESC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSQLitePriceChanges(rows)
+}
+
+// GetScheduledPrices ..
+func (r *sqliteRepository) GetScheduledPrices(ctx context.Context, id uuid.UUID) ([]ScheduledPrice, error) {
+	return r.GetScheduledPricesByItemIDs(ctx, []uuid.UUID{id})
+}
+
+// GetScheduledPricesByItemIDs returns the pending scheduled prices of every item in ids.
+func (r *sqliteRepository) GetScheduledPricesByItemIDs(ctx context.Context, ids []uuid.UUID) ([]ScheduledPrice, error) {
+	if len(ids) == 0 {
+		return []ScheduledPrice{}, nil
+	}
+
+	placeholders, args := in(ids)
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT id, item_id, price, effective_at, applied_at FROM scheduled_price WHERE item_id IN ("+placeholders+") AND applied_at IS NULL ORDER BY effective_at", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payload := make([]ScheduledPrice, 0)
+	for rows.Next() {
+		data := new(ScheduledPrice)
+		var effectiveAt int64
+		var appliedAt sql.NullInt64
+		err := rows.Scan(&data.ID, &data.ItemID, &data.Price, &effectiveAt, &appliedAt)
+		if err != nil {
+			return nil, err
+		}
+		data.EffectiveAt = time.Unix(0, effectiveAt)
+		if appliedAt.Valid {
+			applied := time.Unix(0, appliedAt.Int64)
+			data.AppliedAt = &applied
+		}
+		payload = append(payload, *data)
+	}
+
+	return payload, rows.Err()
+}
+
+// AddScheduledPrice ..
+func (r *sqliteRepository) AddScheduledPrice(ctx context.Context, id uuid.UUID, price Decimal, effectiveAt time.Time) (ScheduledPrice, error) {
+	scheduledPrice := ScheduledPrice{
+		ID:          uuid.New(),
+		ItemID:      id,
+		Price:       price,
+		EffectiveAt: effectiveAt,
+	}
+
+	_, err := r.DBConn.ExecContext(ctx, "INSERT INTO scheduled_price (id, item_id, price, effective_at) VALUES (?1, ?2, ?3, ?4)", scheduledPrice.ID, id, price, effectiveAt.UnixNano())
+	if err != nil {
+		return ScheduledPrice{}, err
+	}
+
+	return scheduledPrice, nil
+}
+
+// ApplyScheduledPrices applies every pending scheduled price that is effective at now. SQLite
+// allows one writer at a time, so concurrent calls never apply the same scheduled price twice.
+func (r *sqliteRepository) ApplyScheduledPrices(ctx context.Context, now time.Time) ([]ScheduledPrice, error) {
+	tx, err := r.DBConn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT id, item_id, price, effective_at FROM scheduled_price WHERE applied_at IS NULL AND effective_at <= ?1 ORDER BY effective_at", now.UnixNano())
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	payload := make([]ScheduledPrice, 0)
+	for rows.Next() {
+		data := new(ScheduledPrice)
+		var effectiveAt int64
+		err := rows.Scan(&data.ID, &data.ItemID, &data.Price, &effectiveAt)
+		if err != nil {
+			rows.Close()
+			tx.Rollback()
+			return nil, err
+		}
+		data.EffectiveAt = time.Unix(0, effectiveAt)
+		payload = append(payload, *data)
+	}
+	rows.Close()
+
+	for i := range payload {
+		scheduledPrice := &payload[i]
+
+		_, err = tx.ExecContext(ctx, "UPDATE item SET price = ?1 WHERE id = ?2", scheduledPrice.Price, scheduledPrice.ItemID)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		err = recordSQLitePriceChange(ctx, tx, scheduledPrice.ItemID, scheduledPrice.Price, scheduledPrice.EffectiveAt)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE scheduled_price SET applied_at = ?1 WHERE id = ?2", now.UnixNano(), scheduledPrice.ID)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		appliedAt := now
+		scheduledPrice.AppliedAt = &appliedAt
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+func recordSQLitePriceChange(ctx context.Context, tx *sql.Tx, id uuid.UUID, price Decimal, effectiveAt time.Time) error {
+	_, err := tx.ExecContext(ctx, "INSERT INTO price_history (item_id, price, effective_at) VALUES (?1, ?2, ?3)", id, price, effectiveAt.UnixNano())
+	return err
+}
+
+func scanSQLitePriceChanges(rows *sql.Rows) ([]PriceChange, error) {
+	payload := make([]PriceChange, 0)
+	for rows.Next() {
+		data := new(PriceChange)
+		var effectiveAt int64
+		err := rows.Scan(&data.ItemID, &data.Price, &effectiveAt)
+		if err != nil {
+			return nil, err
+		}
+		data.EffectiveAt = time.Unix(0, effectiveAt)
+		payload = append(payload, *data)
+	}
+
+	return payload, rows.Err()
+}
+
+// in returns the placeholders and arguments for matching ids in an IN list.
+func in(ids []uuid.UUID) (string, []any) {
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	return strings.Join(placeholders, ", "), args
+}
@@ -0,0 +1,18 @@
+package logging
+
+import "context"
+
+type contextKey string
+
+const requestIDKey contextKey = "logging.requestID"
+
+// WithRequestID ..
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext ..
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
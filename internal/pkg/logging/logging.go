@@ -0,0 +1,98 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Redacted replaces the value of sensitive attributes.
+const Redacted = "[REDACTED]"
+
+// SensitiveKeys are attribute keys, matched case-insensitively at any depth, whose values are
+// never logged. They include the headers credentials are sent in.
+var SensitiveKeys = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-API-Key",
+	"password",
+	"secret",
+	"token",
+}
+
+// New returns a logger writing to w at level ("debug", "info", "warn" or "error", defaulting to
+// info) in format ("json" or "text", defaulting to json). Records carry the request id and trace
+// context from their context and have SensitiveKeys redacted.
+func New(w io.Writer, level string, format string) (*slog.Logger, error) {
+	var minimum slog.Level
+	if level != "" {
+		if err := minimum.UnmarshalText([]byte(level)); err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", level, err)
+		}
+	}
+
+	options := &slog.HandlerOptions{Level: minimum, ReplaceAttr: redact}
+
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case "", "json":
+		handler = slog.NewJSONHandler(w, options)
+	case "text":
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("invalid log format %q, must be json or text", format)
+	}
+
+	return slog.New(&contextHandler{Handler: handler}), nil
+}
+
+// Headers is an attribute holding h, whose sensitive headers are redacted by loggers from New.
+func Headers(key string, h http.Header) slog.Attr {
+	attrs := make([]interface{}, 0, len(h))
+	for name, values := range h {
+		attrs = append(attrs, slog.String(name, strings.Join(values, ", ")))
+	}
+	return slog.Group(key, attrs...)
+}
+
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	for _, key := range SensitiveKeys {
+		if strings.EqualFold(attr.Key, key) {
+			return slog.String(attr.Key, Redacted)
+		}
+	}
+	return attr
+}
+
+// contextHandler adds the request id and the trace and span ids in a record's context to the record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_New_WhenLoggingWithRequestID_ShouldIncludeIt(t *testing.T) {
+	var output bytes.Buffer
+	sut, err := New(&output, "info", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sut.With("component", "test").InfoContext(WithRequestID(context.Background(), "abc-123"), "handled")
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(output.Bytes(), &record); err != nil {
+		t.Fatalf("expected a JSON line, got %q", output.String())
+	}
+	if record["request_id"] != "abc-123" || record["component"] != "test" || record["msg"] != "handled" {
+		t.Errorf("unexpected record %v", record)
+	}
+}
+
+func Test_New_WhenLoggingWithinSpan_ShouldIncludeTraceAndSpanIDs(t *testing.T) {
+	var output bytes.Buffer
+	sut, err := New(&output, "info", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	sut.InfoContext(trace.ContextWithSpanContext(context.Background(), spanContext), "handled")
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(output.Bytes(), &record); err != nil {
+		t.Fatalf("expected a JSON line, got %q", output.String())
+	}
+	if record["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" || record["span_id"] != "00f067aa0ba902b7" {
+		t.Errorf("unexpected record %v", record)
+	}
+}
+
+func Test_New_WhenLoggingHeaders_ShouldRedactSensitiveOnes(t *testing.T) {
+	var output bytes.Buffer
+	sut, _ := New(&output, "debug", "json")
+
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer eyJhbGciOi")
+	headers.Set("X-API-Key", "sck_secret")
+	headers.Set("Accept", "application/json")
+	sut.Debug("request", Headers("headers", headers), slog.String("password", "hunter2"))
+
+	if strings.Contains(output.String(), "eyJhbGciOi") || strings.Contains(output.String(), "sck_secret") || strings.Contains(output.String(), "hunter2") {
+		t.Fatalf("expected credentials to be redacted, got %s", output.String())
+	}
+	if !strings.Contains(output.String(), `"Accept":"application/json"`) {
+		t.Errorf("expected other headers to be logged, got %s", output.String())
+	}
+}
+
+func Test_New_WhenLevelIsHigher_ShouldDropLowerRecords(t *testing.T) {
+	var output bytes.Buffer
+	sut, _ := New(&output, "WARN", "text")
+
+	sut.Info("ignored")
+	sut.Warn("kept")
+
+	if strings.Contains(output.String(), "ignored") || !strings.Contains(output.String(), "msg=kept") {
+		t.Errorf("unexpected output %q", output.String())
+	}
+}
+
+func Test_New_WhenConfigurationIsInvalid_ShouldReturnError(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, "loud", "json"); err == nil {
+		t.Error("expected an error for an invalid level")
+	}
+	if _, err := New(&bytes.Buffer{}, "info", "xml"); err == nil {
+		t.Error("expected an error for an invalid format")
+	}
+}
@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+// ItemRepositoryName labels the item repository's query durations.
+const ItemRepositoryName = "item"
+
+// NewItemRepository wraps an item.Repository so that the duration of every call to it is
+// observed by RepositoryQueryDuration.
+func NewItemRepository(next item.Repository) item.Repository {
+	return &itemRepository{Repository: next}
+}
+
+type itemRepository struct {
+	Repository item.Repository
+}
+
+// GetItems ..
+func (r *itemRepository) GetItems(ctx context.Context, page int64, pageSize int64) ([]item.Item, error) {
+	done := observe(ItemRepositoryName, "GetItems")
+	result, err := r.Repository.GetItems(ctx, page, pageSize)
+	done(err)
+	return result, err
+}
+
+// GetItemByID ..
+func (r *itemRepository) GetItemByID(ctx context.Context, id uuid.UUID) (item.Item, error) {
+	done := observe(ItemRepositoryName, "GetItemByID")
+	result, err := r.Repository.GetItemByID(ctx, id)
+	done(err)
+	return result, err
+}
+
+// FindItems ..
+func (r *itemRepository) FindItems(ctx context.Context, filter item.Filter, page int64, pageSize int64) ([]item.Item, error) {
+	done := observe(ItemRepositoryName, "FindItems")
+	result, err := r.Repository.FindItems(ctx, filter, page, pageSize)
+	done(err)
+	return result, err
+}
+
+// GetItemsByIDs ..
+func (r *itemRepository) GetItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]item.Item, error) {
+	done := observe(ItemRepositoryName, "GetItemsByIDs")
+	result, err := r.Repository.GetItemsByIDs(ctx, ids)
+	done(err)
+	return result, err
+}
+
+// AddItem ..
+func (r *itemRepository) AddItem(ctx context.Context, name string, price item.Decimal, manufacturer string) (item.Item, error) {
+	done := observe(ItemRepositoryName, "AddItem")
+	result, err := r.Repository.AddItem(ctx, name, price, manufacturer)
+	done(err)
+	return result, err
+}
+
+// UpdateItem ..
+func (r *itemRepository) UpdateItem(ctx context.Context, updated *item.Item) (item.Item, error) {
+	done := observe(ItemRepositoryName, "UpdateItem")
+	result, err := r.Repository.UpdateItem(ctx, updated)
+	done(err)
+	return result, err
+}
+
+// RemoveItem ..
+func (r *itemRepository) RemoveItem(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	done := observe(ItemRepositoryName, "RemoveItem")
+	result, err := r.Repository.RemoveItem(ctx, id)
+	done(err)
+	return result, err
+}
+
+// GetPriceHistory ..
+func (r *itemRepository) GetPriceHistory(ctx context.Context, id uuid.UUID, page int64, pageSize int64) ([]item.PriceChange, error) {
+	done := observe(ItemRepositoryName, "GetPriceHistory")
+	result, err := r.Repository.GetPriceHistory(ctx, id, page, pageSize)
+	done(err)
+	return result, err
+}
+
+// GetPriceHistoryByItemIDs ..
+func (r *itemRepository) GetPriceHistoryByItemIDs(ctx context.Context, ids []uuid.UUID) ([]item.PriceChange, error) {
+	done := observe(ItemRepositoryName, "GetPriceHistoryByItemIDs")
+	result, err := r.Repository.GetPriceHistoryByItemIDs(ctx, ids)
+	done(err)
+	return result, err
+}
+
+// GetScheduledPrices ..
+func (r *itemRepository) GetScheduledPrices(ctx context.Context, id uuid.UUID) ([]item.ScheduledPrice, error) {
+	done := observe(ItemRepositoryName, "GetScheduledPrices")
+	result, err := r.Repository.GetScheduledPrices(ctx, id)
+	done(err)
+	return result, err
+}
+
+// GetScheduledPricesByItemIDs ..
+func (r *itemRepository) GetScheduledPricesByItemIDs(ctx context.Context, ids []uuid.UUID) ([]item.ScheduledPrice, error) {
+	done := observe(ItemRepositoryName, "GetScheduledPricesByItemIDs")
+	result, err := r.Repository.GetScheduledPricesByItemIDs(ctx, ids)
+	done(err)
+	return result, err
+}
+
+// AddScheduledPrice ..
+func (r *itemRepository) AddScheduledPrice(ctx context.Context, id uuid.UUID, price item.Decimal, effectiveAt time.Time) (item.ScheduledPrice, error) {
+	done := observe(ItemRepositoryName, "AddScheduledPrice")
+	result, err := r.Repository.AddScheduledPrice(ctx, id, price, effectiveAt)
+	done(err)
+	return result, err
+}
+
+// ApplyScheduledPrices ..
+func (r *itemRepository) ApplyScheduledPrices(ctx context.Context, now time.Time) ([]item.ScheduledPrice, error) {
+	done := observe(ItemRepositoryName, "ApplyScheduledPrices")
+	result, err := r.Repository.ApplyScheduledPrices(ctx, now)
+	done(err)
+	return result, err
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+func Test_MetricsItemRepository_ShouldObserveQueryDurationByMethodAndOutcome(t *testing.T) {
+	sut := NewItemRepository(&item.RepositoryMock{
+		GetItemByIDFunc: func(ctx context.Context, id uuid.UUID) (item.Item, error) {
+			return item.Item{}, sql.ErrNoRows
+		},
+	})
+
+	before := testutil.CollectAndCount(RepositoryQueryDuration)
+
+	_, err := sut.GetItemByID(context.Background(), uuid.New())
+	if err != sql.ErrNoRows {
+		t.Fatalf("expected the repository's error to be returned, got %v", err)
+	}
+
+	if got := testutil.CollectAndCount(RepositoryQueryDuration) - before; got != 1 {
+		t.Errorf("expected a failed GetItemByID to be observed, got %d", got)
+	}
+
+}
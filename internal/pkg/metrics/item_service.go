@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+// NewItemService wraps an item.Service so that every item it creates, updates, or deletes is
+// counted by ItemsCreated, ItemsUpdated, or ItemsDeleted. Reads are passed straight through.
+func NewItemService(next item.Service) item.Service {
+	return &itemService{Service: next}
+}
+
+type itemService struct {
+	item.Service
+}
+
+// AddItem ..
+func (s *itemService) AddItem(ctx context.Context, dto *item.ItemDTO) (item.Item, error) {
+	result, err := s.Service.AddItem(ctx, dto)
+	if err == nil {
+		ItemsCreated.Inc()
+	}
+	return result, err
+}
+
+// UpdateItem ..
+func (s *itemService) UpdateItem(ctx context.Context, updated *item.Item) (item.Item, item.ServiceError) {
+	result, serviceError := s.Service.UpdateItem(ctx, updated)
+	if serviceError == nil {
+		ItemsUpdated.Inc()
+	}
+	return result, serviceError
+}
+
+// RemoveItem ..
+func (s *itemService) RemoveItem(ctx context.Context, id uuid.UUID) (uuid.UUID, item.ServiceError) {
+	result, serviceError := s.Service.RemoveItem(ctx, id)
+	if serviceError == nil {
+		ItemsDeleted.Inc()
+	}
+	return result, serviceError
+}
+
+// ApplyScheduledPrices ..
+func (s *itemService) ApplyScheduledPrices(ctx context.Context, now time.Time) ([]item.ScheduledPrice, error) {
+	applied, err := s.Service.ApplyScheduledPrices(ctx, now)
+	ItemsUpdated.Add(float64(len(applied)))
+	return applied, err
+}
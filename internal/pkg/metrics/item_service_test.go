@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+func Test_MetricsItemService_AddItem_ShouldCountCreatedItems(t *testing.T) {
+	fail := false
+	sut := NewItemService(&item.ServiceMock{
+		AddItemFunc: func(ctx context.Context, dto *item.ItemDTO) (item.Item, error) {
+			if fail {
+				return item.Item{}, errors.New("unavailable")
+			}
+			return item.Item{ID: uuid.New()}, nil
+		},
+	})
+
+	before := testutil.ToFloat64(ItemsCreated)
+
+	_, _ = sut.AddItem(context.Background(), &item.ItemDTO{})
+	fail = true
+	_, _ = sut.AddItem(context.Background(), &item.ItemDTO{})
+
+	if got := testutil.ToFloat64(ItemsCreated) - before; got != 1 {
+		t.Errorf("expected only the successful create to be counted, got %v", got)
+	}
+}
+
+func Test_MetricsItemService_RemoveItem_WhenItFails_ShouldNotCountIt(t *testing.T) {
+	sut := NewItemService(&item.ServiceMock{
+		RemoveItemFunc: func(ctx context.Context, id uuid.UUID) (uuid.UUID, item.ServiceError) {
+			return uuid.Nil, item.CreateServiceError("not found", item.ItemNotFound)
+		},
+	})
+
+	before := testutil.ToFloat64(ItemsDeleted)
+
+	_, _ = sut.RemoveItem(context.Background(), uuid.New())
+
+	if got := testutil.ToFloat64(ItemsDeleted) - before; got != 0 {
+		t.Errorf("expected the failed delete not to be counted, got %v", got)
+	}
+}
+
+func Test_MetricsItemService_ApplyScheduledPrices_ShouldCountEachPriceApplied(t *testing.T) {
+	sut := NewItemService(&item.ServiceMock{
+		ApplyScheduledPricesFunc: func(ctx context.Context, now time.Time) ([]item.ScheduledPrice, error) {
+			return []item.ScheduledPrice{{ItemID: uuid.New()}, {ItemID: uuid.New()}}, nil
+		},
+	})
+
+	before := testutil.ToFloat64(ItemsUpdated)
+
+	_, _ = sut.ApplyScheduledPrices(context.Background(), time.Now())
+
+	if got := testutil.ToFloat64(ItemsUpdated) - before; got != 2 {
+		t.Errorf("expected both applied prices to be counted as updates, got %v", got)
+	}
+}
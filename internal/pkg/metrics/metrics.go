@@ -0,0 +1,168 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes the name of every metric the service exports.
+const Namespace = "shopping_cart"
+
+// Registry holds the service's metrics, along with the Go runtime and process collectors.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequests counts HTTP requests by method, route pattern and status code.
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route pattern and status code.",
+	}, []string{"method", "route", "code"})
+
+	// HTTPRequestDuration observes how long HTTP requests take by method and route pattern.
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method and route pattern.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// HTTPRequestsInFlight gauges the HTTP requests being served.
+	HTTPRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "HTTP requests being served.",
+	})
+
+	// RepositoryQueryDuration observes how long repository methods take.
+	RepositoryQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "repository",
+		Name:      "query_duration_seconds",
+		Help:      "Repository method latency by repository, method and whether it failed.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"repository", "method", "error"})
+
+	// ItemsCreated counts the items created.
+	ItemsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "items",
+		Name:      "created_total",
+		Help:      "Items created.",
+	})
+
+	// ItemsUpdated counts the item updates, including scheduled price changes.
+	ItemsUpdated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "items",
+		Name:      "updated_total",
+		Help:      "Item updates, including scheduled price changes.",
+	})
+
+	// ItemsDeleted counts the items deleted.
+	ItemsDeleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "items",
+		Name:      "deleted_total",
+		Help:      "Items deleted.",
+	})
+
+	// ItemCacheLookups counts item cache lookups by whether the item was cached.
+	ItemCacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "item_cache",
+		Name:      "lookups_total",
+		Help:      "Item cache lookups by result, hit or miss.",
+	}, []string{"result"})
+
+	// ItemCacheInvalidations counts the items dropped from the item cache by whether this replica
+	// changed them or another did.
+	ItemCacheInvalidations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "item_cache",
+		Name:      "invalidations_total",
+		Help:      "Items dropped from the item cache by source, local or remote.",
+	}, []string{"source"})
+
+	// OutboxDeadLetters counts the outbox messages set aside after failing to be published too many
+	// times, by topic.
+	OutboxDeadLetters = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "outbox",
+		Name:      "dead_letters_total",
+		Help:      "Outbox messages set aside after failing to be published too many times, by topic.",
+	}, []string{"topic"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		HTTPRequestsInFlight,
+		RepositoryQueryDuration,
+		ItemsCreated,
+		ItemsUpdated,
+		ItemsDeleted,
+		ItemCacheLookups,
+		ItemCacheInvalidations,
+		OutboxDeadLetters,
+	)
+}
+
+var (
+	dbCollectorsMu sync.Mutex
+	dbCollectors   = map[string]prometheus.Collector{}
+)
+
+// RegisterDB exports the connection pool statistics of db, labelled with name. Registering
+// another db under the same name replaces it.
+func RegisterDB(db *sql.DB, name string) error {
+	dbCollectorsMu.Lock()
+	defer dbCollectorsMu.Unlock()
+
+	if previous, ok := dbCollectors[name]; ok {
+		Registry.Unregister(previous)
+	}
+
+	collector := collectors.NewDBStatsCollector(db, name)
+	if err := Registry.Register(collector); err != nil {
+		return err
+	}
+	dbCollectors[name] = collector
+
+	return nil
+}
+
+// Handler serves Registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// observe starts timing a repository method. The returned func records its duration once
+// given the error, if any, that the method returned.
+func observe(repository string, method string) func(err error) {
+	start := time.Now()
+	return func(err error) {
+		RepositoryQueryDuration.
+			WithLabelValues(repository, method, boolLabel(err != nil)).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+func boolLabel(value bool) string {
+	if value {
+		return "true"
+	}
+	return "false"
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/outbox"
+)
+
+// NewOutboxRepository wraps an outbox.Repository so that every message a drain sets aside as a
+// dead letter is counted by OutboxDeadLetters.
+func NewOutboxRepository(next outbox.Repository) outbox.Repository {
+	return &outboxRepository{Repository: next}
+}
+
+type outboxRepository struct {
+	Repository outbox.Repository
+}
+
+// Drain ..
+func (r *outboxRepository) Drain(ctx context.Context, limit int64, publish func(outbox.Message) error) (int, error) {
+	return r.Repository.Drain(ctx, limit, func(message outbox.Message) error {
+		err := publish(message)
+		if outbox.IsDeadLetter(err) {
+			OutboxDeadLetters.WithLabelValues(message.Topic).Inc()
+		}
+		return err
+	})
+}
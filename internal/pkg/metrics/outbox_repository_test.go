@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/outbox"
+)
+
+func Test_MetricsOutboxRepository_ShouldCountDeadLettersByTopic(t *testing.T) {
+	sut := NewOutboxRepository(&outbox.RepositoryMock{
+		DrainFunc: func(ctx context.Context, limit int64, publish func(outbox.Message) error) (int, error) {
+			publish(outbox.Message{ID: 1, Topic: "item.created"})
+			publish(outbox.Message{ID: 2, Topic: "item.created"})
+			return 0, nil
+		},
+	})
+
+	before := testutil.ToFloat64(OutboxDeadLetters.WithLabelValues("item.created"))
+
+	_, err := sut.Drain(context.Background(), 10, func(message outbox.Message) error {
+		if message.ID == 1 {
+			return outbox.DeadLetter(errors.New("not keyed by an item id"))
+		}
+		return errors.New("unavailable")
+	})
+	if err != nil {
+		t.Fatalf("expected the repository's error to be returned, got %v", err)
+	}
+
+	if got := testutil.ToFloat64(OutboxDeadLetters.WithLabelValues("item.created")) - before; got != 1 {
+		t.Errorf("expected only the dead letter to be counted, got %v", got)
+	}
+}
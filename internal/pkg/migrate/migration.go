@@ -0,0 +1,91 @@
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	upMarker   = "-- migrate:up"
+	downMarker = "-- migrate:down"
+
+	// noTransaction is the dbmate option, given after a marker, for statements such as
+	// CREATE INDEX CONCURRENTLY that can't run in a transaction.
+	noTransaction = "transaction:false"
+)
+
+// Migration is a dbmate migration: a file named <version>_<description>.sql whose statements
+// after "-- migrate:up" apply it and after "-- migrate:down" roll it back.
+type Migration struct {
+	Version  string
+	Name     string
+	Up       string
+	Down     string
+	UpInTx   bool
+	DownInTx bool
+}
+
+// Load reads the migrations in fsys, oldest first.
+func Load(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	migrations := make([]Migration, 0, len(names))
+	seen := make(map[string]string)
+	for _, name := range names {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, err := parse(path.Base(name), string(content))
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := seen[migration.Version]; ok {
+			return nil, fmt.Errorf("%s: version %s is also used by %s", name, migration.Version, other)
+		}
+		seen[migration.Version] = name
+
+		migrations = append(migrations, migration)
+	}
+
+	return migrations, nil
+}
+
+func parse(name string, content string) (Migration, error) {
+	version, _, ok := strings.Cut(strings.TrimSuffix(name, ".sql"), "_")
+	if !ok || version == "" || strings.Trim(version, "0123456789") != "" {
+		return Migration{}, fmt.Errorf("%s: migrations must be named <version>_<description>.sql", name)
+	}
+
+	up := strings.Index(content, upMarker)
+	down := strings.Index(content, downMarker)
+	if up < 0 || down < 0 || down < up {
+		return Migration{}, fmt.Errorf("%s: migrations must have a %q section followed by a %q section", name, upMarker, downMarker)
+	}
+
+	upOptions, upSQL := splitOptions(content[up+len(upMarker) : down])
+	downOptions, downSQL := splitOptions(content[down+len(downMarker):])
+
+	return Migration{
+		Version:  version,
+		Name:     name,
+		Up:       upSQL,
+		Down:     downSQL,
+		UpInTx:   !strings.Contains(upOptions, noTransaction),
+		DownInTx: !strings.Contains(downOptions, noTransaction),
+	}, nil
+}
+
+// splitOptions splits a section into the options on its marker's line and its statements.
+func splitOptions(section string) (string, string) {
+	options, statements, _ := strings.Cut(section, "\n")
+	return strings.TrimSpace(options), strings.TrimSpace(statements)
+}
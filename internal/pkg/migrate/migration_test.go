@@ -0,0 +1,57 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_Load_ShouldParseMigrationsOldestFirst(t *testing.T) {
+	fsys := fstest.MapFS{
+		"20200101000000_create_b.sql": {Data: []byte("-- migrate:up transaction:false\nCREATE INDEX CONCURRENTLY b_idx ON b (id);\n\n-- migrate:down\nDROP INDEX b_idx;\n")},
+		"20190101000000_create_a.sql": {Data: []byte("-- migrate:up\nCREATE TABLE a (id int);\n\n-- migrate:down\nDROP TABLE a;\n")},
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(migrations) != 2 {
+		t.Fatalf("Expected 2 migrations. Got %d", len(migrations))
+	}
+
+	expected := Migration{Version: "20190101000000", Name: "20190101000000_create_a.sql", Up: "CREATE TABLE a (id int);", Down: "DROP TABLE a;", UpInTx: true, DownInTx: true}
+	if migrations[0] != expected {
+		t.Errorf("Expected %+v. Got %+v", expected, migrations[0])
+	}
+	if migrations[1].UpInTx || !migrations[1].DownInTx {
+		t.Errorf("Expected only the second migration's up section to opt out of a transaction. Got %+v", migrations[1])
+	}
+}
+
+func Test_Load_WhenMigrationIsMalformed_ShouldNameIt(t *testing.T) {
+	for name, content := range map[string]string{
+		"create_a.sql":                "-- migrate:up\nSELECT 1;\n-- migrate:down\nSELECT 1;\n",
+		"20190101000000_create_a.sql": "CREATE TABLE a (id int);\n",
+	} {
+		_, err := Load(fstest.MapFS{name: {Data: []byte(content)}})
+
+		if err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected an error naming %s. Got %v", name, err)
+		}
+	}
+}
+
+func Test_Load_WhenVersionIsReused_ShouldReturnError(t *testing.T) {
+	content := []byte("-- migrate:up\nSELECT 1;\n-- migrate:down\nSELECT 1;\n")
+
+	_, err := Load(fstest.MapFS{
+		"20190101000000_create_a.sql": {Data: content},
+		"20190101000000_create_b.sql": {Data: content},
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "20190101000000") {
+		t.Errorf("Expected the reused version to be reported. Got %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// LockKey identifies the Postgres advisory lock held while migrating, so that replicas starting
+// at once take turns instead of racing to apply the same migrations.
+const LockKey int64 = 20190626153002
+
+// ErrNoneApplied is returned when rolling back a database no migration has been applied to.
+var ErrNoneApplied = errors.New("no migrations have been applied")
+
+// Status ..
+type Status struct {
+	Migration
+	Applied bool
+}
+
+// NewMigrator ..
+func NewMigrator(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{DB: db, Migrations: migrations}
+}
+
+// Migrator applies and rolls back migrations, recording the versions applied in the
+// schema_migrations table just as dbmate does, so databases it migrated carry on where it left
+// off. Each migration runs in a transaction along with its record, unless it opts out.
+type Migrator struct {
+	DB         *sql.DB
+	Migrations []Migration
+}
+
+// Up applies the migrations not yet applied, oldest first, returning those it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied := make([]Migration, 0)
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.Migrations {
+			if versions[migration.Version] {
+				continue
+			}
+			if err := up(ctx, conn, migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down rolls back the most recently applied migration, returning it.
+func (m *Migrator) Down(ctx context.Context) (Migration, error) {
+	var rolledBack Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		migration, err := m.latest(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		rolledBack = migration
+		return down(ctx, conn, migration)
+	})
+
+	return rolledBack, err
+}
+
+// Redo rolls back the most recently applied migration and applies it again, returning it.
+func (m *Migrator) Redo(ctx context.Context) (Migration, error) {
+	var redone Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		migration, err := m.latest(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		redone = migration
+		if err := down(ctx, conn, migration); err != nil {
+			return err
+		}
+		return up(ctx, conn, migration)
+	})
+
+	return redone, err
+}
+
+// Status reports whether each migration has been applied, oldest first.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	versions, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.Migrations))
+	for _, migration := range m.Migrations {
+		statuses = append(statuses, Status{Migration: migration, Applied: versions[migration.Version]})
+	}
+
+	return statuses, nil
+}
+
+// withLock runs migrate on a connection holding the migrations' advisory lock, waiting for any
+// other replica holding it to finish. Statements on the connection aren't cut off by the
+// configured statement timeout in the meantime, as a lengthy migration shouldn't fail halfway.
+func (m *Migrator) withLock(ctx context.Context, migrate func(conn *sql.Conn) error) error {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SET statement_timeout = 0"); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), "RESET statement_timeout")
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", LockKey); err != nil {
+		return fmt.Errorf("unable to lock migrations: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", LockKey)
+
+	return migrate(conn)
+}
+
+// latest returns the most recently applied migration.
+func (m *Migrator) latest(ctx context.Context, conn *sql.Conn) (Migration, error) {
+	var version string
+	err := conn.QueryRowContext(ctx, "SELECT version FROM schema_migrations ORDER BY version DESC LIMIT 1").Scan(&version)
+	if err == sql.ErrNoRows {
+		return Migration{}, ErrNoneApplied
+	}
+	if err != nil {
+		return Migration{}, err
+	}
+
+	for _, migration := range m.Migrations {
+		if migration.Version == version {
+			return migration, nil
+		}
+	}
+
+	return Migration{}, fmt.Errorf("migration %s has been applied but can't be found", version)
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[string]bool, error) {
+	_, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version varchar(255) PRIMARY KEY)")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make(map[string]bool)
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		versions[version] = true
+	}
+
+	return versions, rows.Err()
+}
+
+func up(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	if err := run(ctx, conn, migration.Up, migration.UpInTx, "INSERT INTO schema_migrations (version) VALUES ($1)", migration.Version); err != nil {
+		return fmt.Errorf("%s: %w", migration.Name, err)
+	}
+	return nil
+}
+
+func down(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	if err := run(ctx, conn, migration.Down, migration.DownInTx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+		return fmt.Errorf("%s: %w", migration.Name, err)
+	}
+	return nil
+}
+
+// run runs statements and then record, with version, in a transaction when inTx is set.
+func run(ctx context.Context, conn *sql.Conn, statements string, inTx bool, record string, version string) error {
+	if !inTx {
+		if _, err := conn.ExecContext(ctx, statements); err != nil {
+			return err
+		}
+		_, err := conn.ExecContext(ctx, record, version)
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, statements); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, version); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,164 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var testMigrations = []Migration{
+	{Version: "1", Name: "1_create_a.sql", Up: "CREATE TABLE a (id int);", Down: "DROP TABLE a;", UpInTx: true, DownInTx: true},
+	{Version: "2", Name: "2_create_b.sql", Up: "CREATE TABLE b (id int);", Down: "DROP TABLE b;", UpInTx: true, DownInTx: true},
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec("SET statement_timeout = 0").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WithArgs(LockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WithArgs(LockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RESET statement_timeout").WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectApplied(mock sqlmock.Sqlmock, versions ...string) {
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version"})
+	for _, version := range versions {
+		rows.AddRow(version)
+	}
+	mock.ExpectQuery("SELECT version FROM schema_migrations").WillReturnRows(rows)
+}
+
+func Test_Migrator_Up_ShouldApplyPendingMigrationsWhileHoldingTheLock(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	expectLock(mock)
+	expectApplied(mock, "1")
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE b (id int);")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version) VALUES ($1)")).WithArgs("2").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	applied, err := NewMigrator(dbConn, testMigrations).Up(context.Background())
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when migrating", err)
+	}
+
+	if len(applied) != 1 || applied[0].Version != "2" {
+		t.Errorf("Expected only the pending migration to be applied. Got %+v", applied)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_Migrator_Up_WhenMigrationFails_ShouldRollItBackAndStop(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	expectLock(mock)
+	expectApplied(mock)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE a (id int);")).WillReturnError(errors.New("syntax error"))
+	mock.ExpectRollback()
+	expectUnlock(mock)
+
+	applied, err := NewMigrator(dbConn, testMigrations).Up(context.Background())
+
+	if err == nil || len(applied) != 0 {
+		t.Errorf("Expected the failure to stop migrating. Got %+v, %v", applied, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_Migrator_Redo_ShouldRollBackAndReapplyTheLatestMigration(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	expectLock(mock)
+	mock.ExpectQuery("SELECT version FROM schema_migrations ORDER BY version DESC LIMIT 1").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("2"))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE b;")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = $1")).WithArgs("2").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE b (id int);")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version) VALUES ($1)")).WithArgs("2").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	redone, err := NewMigrator(dbConn, testMigrations).Redo(context.Background())
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when redoing a migration", err)
+	}
+
+	if redone.Version != "2" {
+		t.Errorf("Expected the latest migration to be redone. Got %+v", redone)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_Migrator_Down_WhenNoneApplied_ShouldReturnErrNoneApplied(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	expectLock(mock)
+	mock.ExpectQuery("SELECT version FROM schema_migrations ORDER BY version DESC LIMIT 1").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	expectUnlock(mock)
+
+	_, err = NewMigrator(dbConn, testMigrations).Down(context.Background())
+
+	if !errors.Is(err, ErrNoneApplied) {
+		t.Errorf("Expected ErrNoneApplied. Got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_Migrator_Status_ShouldReportWhetherEachMigrationIsApplied(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	expectApplied(mock, "1")
+
+	statuses, err := NewMigrator(dbConn, testMigrations).Status(context.Background())
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when reading the status", err)
+	}
+
+	if len(statuses) != 2 || !statuses[0].Applied || statuses[1].Applied {
+		t.Errorf("Expected only the first migration to be applied. Got %+v", statuses)
+	}
+}
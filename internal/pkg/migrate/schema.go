@@ -0,0 +1,243 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// schemaHeader opens every dump, which is only ever written by the migrate dump command.
+const schemaHeader = "-- Generated from a migrated database by `shopping-cart-service migrate dump`, do not edit.\n"
+
+// The catalog queries Dump renders the public schema from, each ordered by name so that the same
+// schema is always dumped the same way. Constraints' indexes are left to the constraints, and
+// foreign keys come last, after the keys they reference.
+const (
+	sequencesQuery = `SELECT quote_ident(s.relname), format_type(q.seqtypid, NULL)
+FROM pg_sequence AS q
+JOIN pg_class AS s ON s.oid = q.seqrelid
+WHERE s.relnamespace = 'public'::regnamespace
+ORDER BY s.relname`
+
+	sequenceOwnersQuery = `SELECT quote_ident(s.relname), quote_ident(t.relname) || '.' || quote_ident(a.attname)
+FROM pg_class AS s
+JOIN pg_depend AS d ON d.classid = 'pg_class'::regclass AND d.objid = s.oid AND d.refclassid = 'pg_class'::regclass AND d.deptype = 'a'
+JOIN pg_class AS t ON t.oid = d.refobjid
+JOIN pg_attribute AS a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+WHERE s.relnamespace = 'public'::regnamespace AND s.relkind = 'S'
+ORDER BY s.relname`
+
+	columnsQuery = `SELECT quote_ident(c.relname), quote_ident(a.attname), format_type(a.atttypid, a.atttypmod), a.attnotnull, coalesce(pg_get_expr(d.adbin, d.adrelid), '')
+FROM pg_class AS c
+JOIN pg_attribute AS a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+LEFT JOIN pg_attrdef AS d ON d.adrelid = c.oid AND d.adnum = a.attnum
+WHERE c.relnamespace = 'public'::regnamespace AND c.relkind = 'r'
+ORDER BY c.relname, a.attnum`
+
+	constraintsQuery = `SELECT quote_ident(c.relname), quote_ident(k.conname), pg_get_constraintdef(k.oid)
+FROM pg_constraint AS k
+JOIN pg_class AS c ON c.oid = k.conrelid
+WHERE c.relnamespace = 'public'::regnamespace AND k.contype IN ('p', 'u', 'c', 'x', 'f')
+ORDER BY k.contype = 'f', c.relname, k.conname`
+
+	indexesQuery = `SELECT pg_get_indexdef(i.indexrelid)
+FROM pg_index AS i
+JOIN pg_class AS x ON x.oid = i.indexrelid
+WHERE x.relnamespace = 'public'::regnamespace
+AND NOT EXISTS (SELECT 1 FROM pg_constraint AS k WHERE k.conrelid = i.indrelid AND k.conindid = i.indexrelid)
+ORDER BY x.relname`
+
+	functionsQuery = `SELECT pg_get_functiondef(p.oid)
+FROM pg_proc AS p
+WHERE p.pronamespace = 'public'::regnamespace
+AND NOT EXISTS (SELECT 1 FROM pg_depend AS d WHERE d.classid = 'pg_proc'::regclass AND d.objid = p.oid AND d.deptype = 'e')
+ORDER BY p.proname, p.oid`
+
+	triggersQuery = `SELECT pg_get_triggerdef(t.oid)
+FROM pg_trigger AS t
+JOIN pg_class AS c ON c.oid = t.tgrelid
+WHERE c.relnamespace = 'public'::regnamespace AND NOT t.tgisinternal
+ORDER BY c.relname, t.tgname`
+)
+
+// Dump renders the public schema of a migrated database from its catalog, in the layout of
+// dbmate's schema.sql: its sequences, tables, the columns sequences belong to, constraints,
+// indexes, functions and triggers, followed by the versions recorded in schema_migrations. It describes the schema the migrations
+// result in, rather than the statements that got there.
+func Dump(ctx context.Context, db *sql.DB) (string, error) {
+	var b strings.Builder
+	b.WriteString(schemaHeader)
+
+	sections := []func(ctx context.Context, db *sql.DB, b *strings.Builder) error{
+		dumpSequences,
+		dumpTables,
+		dumpSequenceOwners,
+		dumpConstraints,
+		dumpDefinitions(indexesQuery),
+		dumpDefinitions(functionsQuery),
+		dumpDefinitions(triggersQuery),
+		dumpVersions,
+	}
+	for _, section := range sections {
+		if err := section(ctx, db, &b); err != nil {
+			return "", err
+		}
+	}
+
+	return b.String(), nil
+}
+
+func dumpSequences(ctx context.Context, db *sql.DB, b *strings.Builder) error {
+	rows, err := db.QueryContext(ctx, sequencesQuery)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, dataType string
+		if err := rows.Scan(&name, &dataType); err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "\nCREATE SEQUENCE %s AS %s;\n", name, dataType)
+	}
+
+	return rows.Err()
+}
+
+func dumpSequenceOwners(ctx context.Context, db *sql.DB, b *strings.Builder) error {
+	rows, err := db.QueryContext(ctx, sequenceOwnersQuery)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	b.WriteString("\n")
+	for rows.Next() {
+		var name, column string
+		if err := rows.Scan(&name, &column); err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "ALTER SEQUENCE %s OWNED BY %s;\n", name, column)
+	}
+
+	return rows.Err()
+}
+
+func dumpTables(ctx context.Context, db *sql.DB, b *strings.Builder) error {
+	rows, err := db.QueryContext(ctx, columnsQuery)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	table := ""
+	for rows.Next() {
+		var relation, column, dataType, columnDefault string
+		var notNull bool
+		if err := rows.Scan(&relation, &column, &dataType, &notNull, &columnDefault); err != nil {
+			return err
+		}
+
+		if relation != table {
+			if table != "" {
+				b.WriteString("\n);\n")
+			}
+			fmt.Fprintf(b, "\nCREATE TABLE %s (\n", relation)
+			table = relation
+		} else {
+			b.WriteString(",\n")
+		}
+
+		fmt.Fprintf(b, "  %s %s", column, dataType)
+		if columnDefault != "" {
+			fmt.Fprintf(b, " DEFAULT %s", columnDefault)
+		}
+		if notNull {
+			b.WriteString(" NOT NULL")
+		}
+	}
+	if table != "" {
+		b.WriteString("\n);\n")
+	}
+
+	return rows.Err()
+}
+
+func dumpConstraints(ctx context.Context, db *sql.DB, b *strings.Builder) error {
+	rows, err := db.QueryContext(ctx, constraintsQuery)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	b.WriteString("\n")
+	for rows.Next() {
+		var relation, name, definition string
+		if err := rows.Scan(&relation, &name, &definition); err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "ALTER TABLE ONLY %s ADD CONSTRAINT %s %s;\n", relation, name, definition)
+	}
+
+	return rows.Err()
+}
+
+// dumpDefinitions writes the definitions query returns as statements, each set apart by a blank
+// line.
+func dumpDefinitions(query string) func(ctx context.Context, db *sql.DB, b *strings.Builder) error {
+	return func(ctx context.Context, db *sql.DB, b *strings.Builder) error {
+		rows, err := db.QueryContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var definition string
+			if err := rows.Scan(&definition); err != nil {
+				return err
+			}
+			fmt.Fprintf(b, "\n%s;\n", strings.TrimRight(definition, "\n"))
+		}
+
+		return rows.Err()
+	}
+}
+
+func dumpVersions(ctx context.Context, db *sql.DB, b *strings.Builder) error {
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	versions := make([]string, 0)
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return err
+		}
+		versions = append(versions, version)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return nil
+	}
+
+	b.WriteString("\n-- Dbmate schema migrations\n")
+	b.WriteString("INSERT INTO schema_migrations (version) VALUES")
+	for i, version := range versions {
+		separator := ","
+		if i == len(versions)-1 {
+			separator = ";"
+		}
+		fmt.Fprintf(b, "\n  ('%s')%s", version, separator)
+	}
+	b.WriteString("\n")
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package migrate
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func Test_Dump_ShouldRenderTheCatalogAndRecordEachVersion(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(sequencesQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type"}).AddRow("a_id_seq", "bigint"))
+	mock.ExpectQuery(regexp.QuoteMeta(columnsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"table", "column", "type", "not_null", "default"}).
+			AddRow("a", "id", "bigint", true, "nextval('a_id_seq'::regclass)").
+			AddRow("a", "name", "text", false, "").
+			AddRow("b", "a_id", "bigint", true, ""))
+	mock.ExpectQuery(regexp.QuoteMeta(sequenceOwnersQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "column"}).AddRow("a_id_seq", "a.id"))
+	mock.ExpectQuery(regexp.QuoteMeta(constraintsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"table", "name", "definition"}).
+			AddRow("a", "a_pkey", "PRIMARY KEY (id)").
+			AddRow("b", "b_a_id_fkey", "FOREIGN KEY (a_id) REFERENCES a(id)"))
+	mock.ExpectQuery(regexp.QuoteMeta(indexesQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"definition"}).AddRow("CREATE INDEX a_name_idx ON public.a USING btree (name)"))
+	mock.ExpectQuery(regexp.QuoteMeta(functionsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"definition"}).AddRow("CREATE OR REPLACE FUNCTION public.f()\n RETURNS trigger\n LANGUAGE plpgsql\nAS $function$\nBEGIN\n  RETURN NULL;\nEND;\n$function$\n"))
+	mock.ExpectQuery(regexp.QuoteMeta(triggersQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"definition"}).AddRow("CREATE TRIGGER a_f AFTER INSERT ON public.a FOR EACH ROW EXECUTE FUNCTION f()"))
+	mock.ExpectQuery("SELECT version FROM schema_migrations ORDER BY version").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("1").AddRow("2"))
+
+	schema, err := Dump(context.Background(), dbConn)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when dumping the schema", err)
+	}
+
+	expected := schemaHeader + `
+CREATE SEQUENCE a_id_seq AS bigint;
+
+CREATE TABLE a (
+  id bigint DEFAULT nextval('a_id_seq'::regclass) NOT NULL,
+  name text
+);
+
+CREATE TABLE b (
+  a_id bigint NOT NULL
+);
+
+ALTER SEQUENCE a_id_seq OWNED BY a.id;
+
+ALTER TABLE ONLY a ADD CONSTRAINT a_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY b ADD CONSTRAINT b_a_id_fkey FOREIGN KEY (a_id) REFERENCES a(id);
+
+CREATE INDEX a_name_idx ON public.a USING btree (name);
+
+CREATE OR REPLACE FUNCTION public.f()
+ RETURNS trigger
+ LANGUAGE plpgsql
+AS $function$
+BEGIN
+  RETURN NULL;
+END;
+$function$;
+
+CREATE TRIGGER a_f AFTER INSERT ON public.a FOR EACH ROW EXECUTE FUNCTION f();
+
+-- Dbmate schema migrations
+INSERT INTO schema_migrations (version) VALUES
+  ('1'),
+  ('2');
+`
+	if schema != expected {
+		t.Errorf("Expected the schema:\n%s\nGot:\n%s", expected, schema)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
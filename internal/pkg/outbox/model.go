@@ -0,0 +1,16 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Message is a change waiting in the outbox. Attempts is how many times publishing it has failed.
+type Message struct {
+	ID        int64           `json:"id"`
+	Topic     string          `json:"topic"`
+	Key       string          `json:"key"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+	Attempts  int             `json:"-"`
+}
@@ -0,0 +1,24 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+// Execer is satisfied by *sql.Tx, so messages can be written in the same transaction as the
+// change they describe.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Insert records a message to be relayed once the surrounding transaction commits.
+func Insert(ctx context.Context, tx Execer, topic string, key string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO outbox (topic, key, payload) VALUES ($1, $2, $3)", topic, key, string(body))
+	return err
+}
@@ -0,0 +1,69 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Publisher hands relayed messages to a broker. Implementations must be safe to call again with a
+// message they have already seen, since the relay guarantees at-least-once delivery.
+type Publisher interface {
+	Publish(ctx context.Context, message Message) error
+}
+
+// Publishers publishes each message to every one of its publishers in turn, stopping at the first
+// that fails, so that the message is relayed again to all of them.
+type Publishers []Publisher
+
+// Publish ..
+func (p Publishers) Publish(ctx context.Context, message Message) error {
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewMemoryPublisher ..
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// MemoryPublisher keeps published messages in memory, for tests and demos.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// Publish ..
+func (p *MemoryPublisher) Publish(ctx context.Context, message Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, message)
+	return nil
+}
+
+// Messages returns a copy of everything published so far.
+func (p *MemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Message(nil), p.messages...)
+}
+
+// NewLogPublisher ..
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+// LogPublisher writes every message to the standard logger.
+type LogPublisher struct{}
+
+// Publish ..
+func (p *LogPublisher) Publish(ctx context.Context, message Message) error {
+	slog.InfoContext(ctx, "published outbox message", "message_id", message.ID, "topic", message.Topic, "key", message.Key, "payload", string(message.Payload))
+	return nil
+}
@@ -0,0 +1,74 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	defaultBatchSize   = 100
+	defaultMaxAttempts = 10
+)
+
+// NewRelay ..
+func NewRelay(repository Repository, publisher Publisher, interval time.Duration) *Relay {
+	return &Relay{Repository: repository, Publisher: publisher, Interval: interval, BatchSize: defaultBatchSize, MaxAttempts: defaultMaxAttempts}
+}
+
+// Relay drains the outbox to a Publisher. A message that fails to be published MaxAttempts times
+// is set aside as a dead letter, so that it doesn't hold up the messages behind it.
+type Relay struct {
+	Repository  Repository
+	Publisher   Publisher
+	Interval    time.Duration
+	BatchSize   int64
+	MaxAttempts int
+}
+
+// Run blocks, draining the outbox every interval until the context is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Flush(ctx); err != nil {
+			slog.ErrorContext(ctx, "unable to relay outbox messages", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush drains batches until the outbox is empty or publishing fails, returning how many
+// messages were published.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		published, err := r.Repository.Drain(ctx, r.BatchSize, func(message Message) error {
+			return r.publish(ctx, message)
+		})
+		total += published
+		if err != nil {
+			return total, err
+		}
+
+		if int64(published) < r.BatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (r *Relay) publish(ctx context.Context, message Message) error {
+	err := r.Publisher.Publish(ctx, message)
+	if err == nil || message.Attempts+1 < r.MaxAttempts {
+		return err
+	}
+
+	slog.ErrorContext(ctx, "dead-lettering outbox message", "message_id", message.ID, "topic", message.Topic, "attempts", message.Attempts+1, "error", err)
+	return DeadLetter(err)
+}
@@ -0,0 +1,98 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_Relay_Flush_ShouldDrainUntilOutboxIsEmpty(t *testing.T) {
+	batches := [][]Message{
+		{{ID: 1, Topic: "item.created"}, {ID: 2, Topic: "item.updated"}},
+		{{ID: 3, Topic: "item.deleted"}},
+	}
+
+	mockRepository := &RepositoryMock{
+		DrainFunc: func(ctx context.Context, limit int64, publish func(Message) error) (int, error) {
+			if len(batches) == 0 {
+				return 0, nil
+			}
+			batch := batches[0]
+			batches = batches[1:]
+			for _, message := range batch {
+				if err := publish(message); err != nil {
+					return 0, err
+				}
+			}
+			return len(batch), nil
+		},
+	}
+	publisher := NewMemoryPublisher()
+
+	sut := NewRelay(mockRepository, publisher, time.Second)
+	sut.BatchSize = 2
+
+	published, err := sut.Flush(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if published != 3 {
+		t.Errorf("Expected 3 messages to be published. Got %d", published)
+	}
+
+	messages := publisher.Messages()
+	if len(messages) != 3 || messages[2].Topic != "item.deleted" {
+		t.Errorf("Unexpected messages were published: %+v", messages)
+	}
+
+	if calls := len(mockRepository.DrainCalls()); calls != 2 {
+		t.Errorf("Drain was called %d times", calls)
+	}
+}
+
+func Test_Relay_Flush_WhenMessageKeepsFailing_ShouldDeadLetterItOnTheLastAttempt(t *testing.T) {
+	var errs []error
+	mockRepository := &RepositoryMock{
+		DrainFunc: func(ctx context.Context, limit int64, publish func(Message) error) (int, error) {
+			errs = append(errs,
+				publish(Message{ID: 1, Topic: "item.created", Attempts: defaultMaxAttempts - 2}),
+				publish(Message{ID: 2, Topic: "item.created", Attempts: defaultMaxAttempts - 1}),
+			)
+			return 0, nil
+		},
+	}
+
+	sut := NewRelay(mockRepository, failingPublisher{}, time.Second)
+
+	if _, err := sut.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(errs) != 2 || errs[0] == nil || IsDeadLetter(errs[0]) {
+		t.Errorf("Expected a message with attempts left to be retried. Got %v", errs)
+	}
+	if len(errs) != 2 || !IsDeadLetter(errs[1]) {
+		t.Errorf("Expected a message on its last attempt to be dead-lettered. Got %v", errs)
+	}
+}
+
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(ctx context.Context, message Message) error {
+	return errors.New("unavailable")
+}
+
+func Test_Publishers_Publish_WhenOneFails_ShouldStopAndReturnItsError(t *testing.T) {
+	first, last := NewMemoryPublisher(), NewMemoryPublisher()
+	sut := Publishers{first, failingPublisher{}, last}
+
+	if err := sut.Publish(context.Background(), Message{ID: 1, Topic: "item.created"}); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if len(first.Messages()) != 1 || len(last.Messages()) != 0 {
+		t.Errorf("Expected publishing to stop at the failure. Got %d and %d messages", len(first.Messages()), len(last.Messages()))
+	}
+}
@@ -0,0 +1,121 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// Repository ..
+type Repository interface {
+	Drain(ctx context.Context, limit int64, publish func(Message) error) (int, error)
+}
+
+// DeadLetter marks err, returned by publish, as final: Drain sets the message aside as a dead
+// letter and carries on with the rest, instead of stopping to retry it.
+func DeadLetter(err error) error {
+	return deadLetterError{err}
+}
+
+// IsDeadLetter reports whether err was marked by DeadLetter.
+func IsDeadLetter(err error) bool {
+	var deadLetter deadLetterError
+	return errors.As(err, &deadLetter)
+}
+
+type deadLetterError struct {
+	error
+}
+
+func (e deadLetterError) Unwrap() error {
+	return e.error
+}
+
+// NewRepository ..
+func NewRepository(DBConn *sql.DB) Repository {
+	return &repository{DBConn: DBConn}
+}
+
+// repository ..
+type repository struct {
+	DBConn *sql.DB
+}
+
+// Drain locks up to limit of the oldest messages that aren't dead letters, hands each to publish in
+// order, and deletes the ones that were published. A failure is counted against its message, and
+// draining stops there, leaving that message and the rest for the next drain, unless publish
+// returned a DeadLetter error, in which case the message is kept as a dead letter and draining
+// carries on. A message is only deleted after it was published, so delivery is at-least-once.
+func (r *repository) Drain(ctx context.Context, limit int64, publish func(Message) error) (int, error) {
+	tx, err := r.DBConn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT id, topic, key, payload, created_at, attempts FROM outbox WHERE dead_at IS NULL ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED", limit)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	messages := make([]Message, 0)
+	for rows.Next() {
+		var payload []byte
+		message := new(Message)
+		err := rows.Scan(&message.ID, &message.Topic, &message.Key, &payload, &message.CreatedAt, &message.Attempts)
+		if err != nil {
+			rows.Close()
+			tx.Rollback()
+			return 0, err
+		}
+		message.Payload = payload
+		messages = append(messages, *message)
+	}
+	rows.Close()
+
+	published := make([]int64, 0, len(messages))
+	var publishErr error
+	for _, message := range messages {
+		publishErr = publish(message)
+		if publishErr == nil {
+			published = append(published, message.ID)
+			continue
+		}
+
+		dead := IsDeadLetter(publishErr)
+
+		failStm := "UPDATE outbox SET attempts = attempts + 1, last_error = $2 WHERE id = $1"
+		if dead {
+			failStm = "UPDATE outbox SET attempts = attempts + 1, last_error = $2, dead_at = now() WHERE id = $1"
+		}
+
+		_, err = tx.ExecContext(ctx, failStm, message.ID, publishErr.Error())
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		if !dead {
+			break
+		}
+		publishErr = nil
+	}
+
+	if len(published) > 0 {
+		_, err = tx.ExecContext(ctx, "DELETE FROM outbox WHERE id = ANY ($1)", pq.Array(published))
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return len(published), publishErr
+}
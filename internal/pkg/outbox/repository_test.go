@@ -0,0 +1,144 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+var messageColumns = []string{"id", "topic", "key", "payload", "created_at", "attempts"}
+
+func Test_OutboxRepository_Drain_ShouldDeletePublishedMessages(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, topic, key, payload, created_at, attempts FROM outbox WHERE dead_at IS NULL ORDER BY id LIMIT \\$1 FOR UPDATE SKIP LOCKED").
+		WithArgs(10).
+		WillReturnRows(
+			sqlmock.NewRows(messageColumns).
+				AddRow(1, "item.created", "a", []byte(`{}`), time.Now(), 0).
+				AddRow(2, "item.deleted", "b", []byte(`{}`), time.Now(), 0),
+		)
+	mock.ExpectExec("DELETE FROM outbox WHERE id = ANY \\(\\$1\\)").
+		WithArgs(pq.Array([]int64{1, 2})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	sut := NewRepository(dbConn)
+
+	var topics []string
+	published, err := sut.Drain(context.Background(), 10, func(message Message) error {
+		topics = append(topics, message.Topic)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when draining the outbox", err)
+	}
+
+	if published != 2 || len(topics) != 2 || topics[0] != "item.created" {
+		t.Errorf("Expected both messages to be published in order. Got %d, %v", published, topics)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_OutboxRepository_Drain_WhenPublishFails_ShouldCountTheAttemptAndKeepUnpublishedMessages(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	publishError := errors.New("broker unavailable")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, topic, key, payload, created_at, attempts FROM outbox WHERE dead_at IS NULL").
+		WithArgs(10).
+		WillReturnRows(
+			sqlmock.NewRows(messageColumns).
+				AddRow(1, "item.created", "a", []byte(`{}`), time.Now(), 0).
+				AddRow(2, "item.deleted", "b", []byte(`{}`), time.Now(), 0).
+				AddRow(3, "item.created", "c", []byte(`{}`), time.Now(), 0),
+		)
+	mock.ExpectExec("UPDATE outbox SET attempts = attempts \\+ 1, last_error = \\$2 WHERE id = \\$1").
+		WithArgs(2, publishError.Error()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM outbox WHERE id = ANY \\(\\$1\\)").
+		WithArgs(pq.Array([]int64{1})).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	sut := NewRepository(dbConn)
+
+	published, err := sut.Drain(context.Background(), 10, func(message Message) error {
+		if message.ID == 2 {
+			return publishError
+		}
+		return nil
+	})
+	if !errors.Is(err, publishError) {
+		t.Fatalf("Expected failure '%s', but received '%v'", publishError, err)
+	}
+
+	if published != 1 {
+		t.Errorf("Expected only the first message to be published. Got %d", published)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_OutboxRepository_Drain_WhenMessageIsDeadLettered_ShouldSetItAsideAndCarryOn(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, topic, key, payload, created_at, attempts FROM outbox WHERE dead_at IS NULL").
+		WithArgs(10).
+		WillReturnRows(
+			sqlmock.NewRows(messageColumns).
+				AddRow(1, "item.created", "not-an-item-id", []byte(`{}`), time.Now(), 9).
+				AddRow(2, "item.deleted", "b", []byte(`{}`), time.Now(), 0),
+		)
+	mock.ExpectExec("UPDATE outbox SET attempts = attempts \\+ 1, last_error = \\$2, dead_at = now\\(\\) WHERE id = \\$1").
+		WithArgs(1, "not keyed by an item id").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM outbox WHERE id = ANY \\(\\$1\\)").
+		WithArgs(pq.Array([]int64{2})).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	sut := NewRepository(dbConn)
+
+	published, err := sut.Drain(context.Background(), 10, func(message Message) error {
+		if message.ID == 1 {
+			return DeadLetter(errors.New("not keyed by an item id"))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when draining the outbox", err)
+	}
+
+	if published != 1 {
+		t.Errorf("Expected the message behind the dead letter to be published. Got %d", published)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
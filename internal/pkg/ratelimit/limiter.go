@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Store keeps each bucket's state as a theoretical arrival time (TAT): the time at which the
+// bucket will be full again. This is the generic cell rate algorithm, a token bucket that needs a
+// single value per bucket, so it can be updated atomically wherever it is stored.
+type Store interface {
+	// Take counts a request against key at now if limit allows it, returning the bucket's TAT
+	// afterwards and whether the request was allowed.
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (time.Time, bool, error)
+}
+
+// Result ..
+type Result struct {
+	Limit      Limit
+	Allowed    bool
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// NewLimiter ..
+func NewLimiter(store Store, limits *Limits) *Limiter {
+	return &Limiter{Store: store, Limits: limits, Now: time.Now}
+}
+
+// Limiter ..
+type Limiter struct {
+	Store  Store
+	Limits *Limits
+	Now    func() time.Time
+}
+
+// Allow counts a request by client to the route with pattern against the route's limit.
+func (l *Limiter) Allow(ctx context.Context, client string, method string, pattern string) (Result, error) {
+	route, limit := l.Limits.For(method, pattern)
+	now := l.Now()
+
+	tat, allowed, err := l.Store.Take(ctx, client+" "+route, limit, now)
+	if err != nil {
+		return Result{Limit: limit, Allowed: true}, err
+	}
+
+	return result(limit, now, tat, allowed), nil
+}
+
+// AllowIP counts a request from ip against the per-IP limit, which is shared by every route.
+func (l *Limiter) AllowIP(ctx context.Context, ip string) (Result, error) {
+	limit := l.Limits.PerIP
+	if limit.Requests == 0 {
+		limit = l.Limits.Default
+	}
+	now := l.Now()
+
+	tat, allowed, err := l.Store.Take(ctx, "ip:"+ip+" per-ip", limit, now)
+	if err != nil {
+		return Result{Limit: limit, Allowed: true}, err
+	}
+
+	return result(limit, now, tat, allowed), nil
+}
+
+func result(limit Limit, now time.Time, tat time.Time, allowed bool) Result {
+	interval := limit.interval()
+	reset := tat.Sub(now)
+	if reset < 0 {
+		reset = 0
+	}
+
+	if !allowed {
+		return Result{
+			Limit:      limit,
+			Reset:      reset,
+			RetryAfter: reset + interval - limit.Window,
+		}
+	}
+
+	return Result{
+		Limit:     limit,
+		Allowed:   true,
+		Remaining: int((limit.Window - reset) / interval),
+		Reset:     reset,
+	}
+}
+
+// next is the TAT after a request arriving at now, which is allowed when it doesn't put the
+// bucket more than a window behind.
+func next(tat time.Time, limit Limit, now time.Time) (time.Time, bool) {
+	if tat.Before(now) {
+		tat = now
+	}
+
+	advanced := tat.Add(limit.interval())
+	return advanced, advanced.Sub(now) <= limit.Window
+}
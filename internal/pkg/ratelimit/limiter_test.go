@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestLimiter(now *time.Time) *Limiter {
+	sut := NewLimiter(NewMemoryStore(), &Limits{
+		Default: Limit{Requests: 100, Window: time.Minute},
+		Routes:  map[string]Limit{"GET /items": {Requests: 2, Window: time.Minute}},
+	})
+	sut.Now = func() time.Time { return *now }
+	return sut
+}
+
+func Test_Limiter_Allow_WhenBurstIsUsedUp_ShouldDenyUntilRefilled(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	sut := newTestLimiter(&now)
+
+	first, _ := sut.Allow(context.Background(), "ip:192.0.2.1", "GET", "/v1/items/")
+	second, _ := sut.Allow(context.Background(), "ip:192.0.2.1", "GET", "/items/")
+	if !first.Allowed || first.Remaining != 1 || !second.Allowed || second.Remaining != 0 {
+		t.Fatalf("expected the burst to be allowed, got %+v and %+v", first, second)
+	}
+
+	denied, _ := sut.Allow(context.Background(), "ip:192.0.2.1", "GET", "/v1/items/")
+	if denied.Allowed || denied.RetryAfter != 30*time.Second || denied.Reset != time.Minute {
+		t.Fatalf("expected to be told to retry in 30s, got %+v", denied)
+	}
+
+	now = now.Add(30 * time.Second)
+	refilled, _ := sut.Allow(context.Background(), "ip:192.0.2.1", "GET", "/v1/items/")
+	if !refilled.Allowed || refilled.Remaining != 0 {
+		t.Errorf("expected one request to be refilled, got %+v", refilled)
+	}
+}
+
+func Test_Limiter_Allow_ShouldCountClientsAndRoutesSeparately(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	sut := newTestLimiter(&now)
+
+	for i := 0; i < 2; i++ {
+		sut.Allow(context.Background(), "api-key:erp", "GET", "/v1/items/")
+	}
+
+	other, _ := sut.Allow(context.Background(), "ip:192.0.2.1", "GET", "/v1/items/")
+	unlisted, _ := sut.Allow(context.Background(), "api-key:erp", "GET", "/v1/items/{id}")
+	if !other.Allowed || !unlisted.Allowed || unlisted.Limit.Requests != 100 {
+		t.Errorf("expected other clients and routes to have their own buckets, got %+v and %+v", other, unlisted)
+	}
+}
+
+func Test_Limiter_AllowIP_ShouldCountEveryRouteFromTheIPTogether(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	sut := NewLimiter(NewMemoryStore(), &Limits{
+		Default: Limit{Requests: 100, Window: time.Minute},
+		PerIP:   Limit{Requests: 2, Window: time.Minute},
+	})
+	sut.Now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		sut.AllowIP(context.Background(), "192.0.2.1")
+	}
+
+	denied, _ := sut.AllowIP(context.Background(), "192.0.2.1")
+	other, _ := sut.AllowIP(context.Background(), "192.0.2.2")
+	route, _ := sut.Allow(context.Background(), "ip:192.0.2.1", "GET", "/v1/items/")
+	if denied.Allowed || !other.Allowed || !route.Allowed {
+		t.Errorf("expected only the IP over its limit to be denied, got %+v, %+v and %+v", denied, other, route)
+	}
+}
@@ -0,0 +1,112 @@
+package ratelimit
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Limit lets a client make Requests requests in a burst, refilling at Requests per Window.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// UnmarshalJSON reads {"requests": 60, "window": "1m"}.
+func (l *Limit) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Requests int    `json:"requests"`
+		Window   string `json:"window"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	window, err := time.ParseDuration(raw.Window)
+	if err != nil {
+		return err
+	}
+	if raw.Requests <= 0 || window <= 0 {
+		return fmt.Errorf("requests and window must be positive, got %d per %s", raw.Requests, raw.Window)
+	}
+
+	l.Requests, l.Window = raw.Requests, window
+	return nil
+}
+
+// interval is how long it takes to refill one request.
+func (l Limit) interval() time.Duration {
+	return l.Window / time.Duration(l.Requests)
+}
+
+// DefaultLimits applies to every route not named in its routes.
+//
+//go:embed limits.json
+var DefaultLimits []byte
+
+// Limits maps routes, as "METHOD /pattern" with any /v1 prefix removed so versioned routes and
+// their aliases share a limit, onto their Limit. Default applies to the remaining routes. PerIP
+// limits each IP address across every route, before its requests are authenticated, and is the
+// Default when unset.
+type Limits struct {
+	Default Limit            `json:"default"`
+	PerIP   Limit            `json:"per_ip"`
+	Routes  map[string]Limit `json:"routes"`
+}
+
+// LoadLimits reads a limits file in the format of DefaultLimits. An empty path loads
+// DefaultLimits.
+func LoadLimits(path string) (*Limits, error) {
+	if path == "" {
+		return ParseLimits(DefaultLimits)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseLimits(data)
+}
+
+// ParseLimits ..
+func ParseLimits(data []byte) (*Limits, error) {
+	var limits Limits
+	if err := json.Unmarshal(data, &limits); err != nil {
+		return nil, err
+	}
+	if limits.Default.Requests == 0 {
+		return nil, fmt.Errorf("a default limit is required")
+	}
+
+	return &limits, nil
+}
+
+// For returns the route's Limit and the name of the bucket it is counted in. GraphQL serves the same
+// queries whichever method they're sent with, so GET /graphql is counted as POST /graphql.
+func (l *Limits) For(method string, pattern string) (string, Limit) {
+	pattern = normalize(pattern)
+	if method == "GET" && pattern == "/graphql" {
+		method = "POST"
+	}
+
+	route := method + " " + pattern
+	if limit, ok := l.Routes[route]; ok {
+		return route, limit
+	}
+	return "*", l.Default
+}
+
+func normalize(pattern string) string {
+	pattern = strings.TrimPrefix(pattern, "/v1")
+	if len(pattern) > 1 {
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	if pattern == "" {
+		return "/"
+	}
+	return pattern
+}
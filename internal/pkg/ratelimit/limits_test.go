@@ -0,0 +1,44 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_LoadLimits_WhenPathIsEmpty_ShouldLoadDefaultLimits(t *testing.T) {
+	sut, err := LoadLimits("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	route, limit := sut.For("GET", "/v1/items/")
+	if route != "GET /items" || limit.Requests != 120 || limit.Window != time.Minute {
+		t.Errorf("unexpected limit %s %+v", route, limit)
+	}
+
+	route, limit = sut.For("GET", "/graphql")
+	if route != "POST /graphql" || limit != sut.Routes["POST /graphql"] {
+		t.Errorf("expected GET /graphql to share the POST /graphql limit, got %s %+v", route, limit)
+	}
+
+	route, limit = sut.For("GET", "/health")
+	if route != "*" || limit != sut.Default {
+		t.Errorf("expected the default limit, got %s %+v", route, limit)
+	}
+
+	if sut.PerIP.Requests != 1200 || sut.PerIP.Window != time.Minute {
+		t.Errorf("unexpected per-IP limit %+v", sut.PerIP)
+	}
+}
+
+func Test_ParseLimits_WhenLimitIsInvalid_ShouldReturnError(t *testing.T) {
+	for _, data := range []string{
+		`{"routes": {"GET /items": {"requests": 1, "window": "1m"}}}`,
+		`{"default": {"requests": 0, "window": "1m"}}`,
+		`{"default": {"requests": 10, "window": "a minute"}}`,
+	} {
+		if _, err := ParseLimits([]byte(data)); err == nil {
+			t.Errorf("expected an error for %s", data)
+		}
+	}
+}
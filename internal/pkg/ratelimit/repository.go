@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// NewPostgresStore keeps buckets in the rate_limit_bucket table, so limits hold across replicas.
+func NewPostgresStore(DBConn *sql.DB) Store {
+	return &postgresStore{DBConn: DBConn}
+}
+
+type postgresStore struct {
+	DBConn    *sql.DB
+	mutex     sync.Mutex
+	lastSweep time.Time
+}
+
+// Take advances the bucket's TAT in a single statement, which only updates the row when the
+// request is allowed, so concurrent requests from every replica are counted exactly once.
+func (s *postgresStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (time.Time, bool, error) {
+	s.sweep(now)
+
+	takeStm := `INSERT INTO rate_limit_bucket AS b (key, tat) VALUES ($1, $2::timestamptz + $3 * interval '1 microsecond')
+ON CONFLICT (key) DO UPDATE SET tat = GREATEST(b.tat, $2) + $3 * interval '1 microsecond'
+WHERE GREATEST(b.tat, $2) + $3 * interval '1 microsecond' <= $2::timestamptz + $4 * interval '1 microsecond'
+RETURNING tat`
+
+	var tat time.Time
+	err := s.DBConn.QueryRowContext(ctx, takeStm, key, now, limit.interval().Microseconds(), limit.Window.Microseconds()).Scan(&tat)
+	if err == nil {
+		return tat, true, nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, false, err
+	}
+
+	err = s.DBConn.QueryRowContext(ctx, "SELECT tat FROM rate_limit_bucket WHERE key = $1", key).Scan(&tat)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	return tat, false, nil
+}
+
+// sweep deletes full buckets, which are the same as ones never used, about once a minute and in
+// the background, so no request waits on it.
+func (s *postgresStore) sweep(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	go func() {
+		if _, err := s.DBConn.ExecContext(context.Background(), "DELETE FROM rate_limit_bucket WHERE tat < $1", now); err != nil {
+			slog.Error("failed to sweep rate limit buckets", "error", err)
+		}
+	}()
+}
@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func Test_PostgresStore_Take_WhenBucketIsEmpty_ShouldReturnCurrentTAT(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	limit := Limit{Requests: 2, Window: time.Minute}
+
+	mock.ExpectQuery("INSERT INTO rate_limit_bucket AS b \\(key, tat\\) (.+) ON CONFLICT \\(key\\) DO UPDATE SET (.+) WHERE (.+) RETURNING tat").
+		WithArgs("ip:192.0.2.1 GET /items", now, int64(30_000_000), int64(60_000_000)).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT tat FROM rate_limit_bucket WHERE key = \\$1").
+		WithArgs("ip:192.0.2.1 GET /items").
+		WillReturnRows(sqlmock.NewRows([]string{"tat"}).AddRow(now.Add(time.Minute)))
+
+	sut := &postgresStore{DBConn: dbConn, lastSweep: now}
+
+	tat, allowed, err := sut.Take(context.Background(), "ip:192.0.2.1 GET /items", limit, now)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when taking from a bucket", err)
+	}
+
+	if allowed || !tat.Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected the request to be denied at %s, got %t at %s", now.Add(time.Minute), allowed, tat)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// NewMemoryStore keeps buckets in memory, so each replica enforces limits separately.
+func NewMemoryStore() Store {
+	return &memoryStore{buckets: make(map[string]time.Time)}
+}
+
+type memoryStore struct {
+	mutex     sync.Mutex
+	buckets   map[string]time.Time
+	lastSweep time.Time
+}
+
+func (s *memoryStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (time.Time, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sweep(now)
+
+	tat, allowed := next(s.buckets[key], limit, now)
+	if !allowed {
+		return s.buckets[key], false, nil
+	}
+
+	s.buckets[key] = tat
+	return tat, true, nil
+}
+
+// sweep forgets full buckets, which are the same as ones never used, about once a minute.
+func (s *memoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+
+	for key, tat := range s.buckets {
+		if !tat.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
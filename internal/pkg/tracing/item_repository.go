@@ -0,0 +1,123 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+// NewItemRepository wraps an item.Repository so that every call to it is traced by a span named
+// after the method, such as item.Repository/GetItems.
+func NewItemRepository(next item.Repository) item.Repository {
+	return &itemRepository{Repository: next}
+}
+
+type itemRepository struct {
+	Repository item.Repository
+}
+
+// GetItems ..
+func (r *itemRepository) GetItems(ctx context.Context, page int64, pageSize int64) ([]item.Item, error) {
+	ctx, span := start(ctx, "item.Repository/GetItems")
+	result, err := r.Repository.GetItems(ctx, page, pageSize)
+	end(span, err)
+	return result, err
+}
+
+// GetItemByID ..
+func (r *itemRepository) GetItemByID(ctx context.Context, id uuid.UUID) (item.Item, error) {
+	ctx, span := start(ctx, "item.Repository/GetItemByID")
+	result, err := r.Repository.GetItemByID(ctx, id)
+	end(span, err)
+	return result, err
+}
+
+// FindItems ..
+func (r *itemRepository) FindItems(ctx context.Context, filter item.Filter, page int64, pageSize int64) ([]item.Item, error) {
+	ctx, span := start(ctx, "item.Repository/FindItems")
+	result, err := r.Repository.FindItems(ctx, filter, page, pageSize)
+	end(span, err)
+	return result, err
+}
+
+// GetItemsByIDs ..
+func (r *itemRepository) GetItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]item.Item, error) {
+	ctx, span := start(ctx, "item.Repository/GetItemsByIDs")
+	result, err := r.Repository.GetItemsByIDs(ctx, ids)
+	end(span, err)
+	return result, err
+}
+
+// AddItem ..
+func (r *itemRepository) AddItem(ctx context.Context, name string, price item.Decimal, manufacturer string) (item.Item, error) {
+	ctx, span := start(ctx, "item.Repository/AddItem")
+	result, err := r.Repository.AddItem(ctx, name, price, manufacturer)
+	end(span, err)
+	return result, err
+}
+
+// UpdateItem ..
+func (r *itemRepository) UpdateItem(ctx context.Context, updated *item.Item) (item.Item, error) {
+	ctx, span := start(ctx, "item.Repository/UpdateItem")
+	result, err := r.Repository.UpdateItem(ctx, updated)
+	end(span, err)
+	return result, err
+}
+
+// RemoveItem ..
+func (r *itemRepository) RemoveItem(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	ctx, span := start(ctx, "item.Repository/RemoveItem")
+	result, err := r.Repository.RemoveItem(ctx, id)
+	end(span, err)
+	return result, err
+}
+
+// GetPriceHistory ..
+func (r *itemRepository) GetPriceHistory(ctx context.Context, id uuid.UUID, page int64, pageSize int64) ([]item.PriceChange, error) {
+	ctx, span := start(ctx, "item.Repository/GetPriceHistory")
+	result, err := r.Repository.GetPriceHistory(ctx, id, page, pageSize)
+	end(span, err)
+	return result, err
+}
+
+// GetPriceHistoryByItemIDs ..
+func (r *itemRepository) GetPriceHistoryByItemIDs(ctx context.Context, ids []uuid.UUID) ([]item.PriceChange, error) {
+	ctx, span := start(ctx, "item.Repository/GetPriceHistoryByItemIDs")
+	result, err := r.Repository.GetPriceHistoryByItemIDs(ctx, ids)
+	end(span, err)
+	return result, err
+}
+
+// GetScheduledPrices ..
+func (r *itemRepository) GetScheduledPrices(ctx context.Context, id uuid.UUID) ([]item.ScheduledPrice, error) {
+	ctx, span := start(ctx, "item.Repository/GetScheduledPrices")
+	result, err := r.Repository.GetScheduledPrices(ctx, id)
+	end(span, err)
+	return result, err
+}
+
+// GetScheduledPricesByItemIDs ..
+func (r *itemRepository) GetScheduledPricesByItemIDs(ctx context.Context, ids []uuid.UUID) ([]item.ScheduledPrice, error) {
+	ctx, span := start(ctx, "item.Repository/GetScheduledPricesByItemIDs")
+	result, err := r.Repository.GetScheduledPricesByItemIDs(ctx, ids)
+	end(span, err)
+	return result, err
+}
+
+// AddScheduledPrice ..
+func (r *itemRepository) AddScheduledPrice(ctx context.Context, id uuid.UUID, price item.Decimal, effectiveAt time.Time) (item.ScheduledPrice, error) {
+	ctx, span := start(ctx, "item.Repository/AddScheduledPrice")
+	result, err := r.Repository.AddScheduledPrice(ctx, id, price, effectiveAt)
+	end(span, err)
+	return result, err
+}
+
+// ApplyScheduledPrices ..
+func (r *itemRepository) ApplyScheduledPrices(ctx context.Context, now time.Time) ([]item.ScheduledPrice, error) {
+	ctx, span := start(ctx, "item.Repository/ApplyScheduledPrices")
+	result, err := r.Repository.ApplyScheduledPrices(ctx, now)
+	end(span, err)
+	return result, err
+}
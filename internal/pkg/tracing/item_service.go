@@ -0,0 +1,137 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+// NewItemService wraps an item.Service so that every call to it is traced by a span named after
+// the method, such as item.Service/GetItems.
+func NewItemService(next item.Service) item.Service {
+	return &itemService{Service: next}
+}
+
+type itemService struct {
+	Service item.Service
+}
+
+// GetItems ..
+func (s *itemService) GetItems(ctx context.Context, page int64, pageSize int64) ([]item.Item, error) {
+	ctx, span := start(ctx, "item.Service/GetItems")
+	result, err := s.Service.GetItems(ctx, page, pageSize)
+	end(span, err)
+	return result, err
+}
+
+// GetItemByID ..
+func (s *itemService) GetItemByID(ctx context.Context, id uuid.UUID) (item.Item, error) {
+	ctx, span := start(ctx, "item.Service/GetItemByID")
+	result, err := s.Service.GetItemByID(ctx, id)
+	end(span, err)
+	return result, err
+}
+
+// FindItems ..
+func (s *itemService) FindItems(ctx context.Context, filter item.Filter, page int64, pageSize int64) ([]item.Item, error) {
+	ctx, span := start(ctx, "item.Service/FindItems")
+	result, err := s.Service.FindItems(ctx, filter, page, pageSize)
+	end(span, err)
+	return result, err
+}
+
+// GetItemsByIDs ..
+func (s *itemService) GetItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]item.Item, error) {
+	ctx, span := start(ctx, "item.Service/GetItemsByIDs")
+	result, err := s.Service.GetItemsByIDs(ctx, ids)
+	end(span, err)
+	return result, err
+}
+
+// AddItem ..
+func (s *itemService) AddItem(ctx context.Context, dto *item.ItemDTO) (item.Item, error) {
+	ctx, span := start(ctx, "item.Service/AddItem")
+	result, err := s.Service.AddItem(ctx, dto)
+	end(span, err)
+	return result, err
+}
+
+// UpdateItem ..
+func (s *itemService) UpdateItem(ctx context.Context, updated *item.Item) (item.Item, item.ServiceError) {
+	ctx, span := start(ctx, "item.Service/UpdateItem")
+	result, serviceError := s.Service.UpdateItem(ctx, updated)
+	endWithServiceError(span, serviceError)
+	return result, serviceError
+}
+
+// RemoveItem ..
+func (s *itemService) RemoveItem(ctx context.Context, id uuid.UUID) (uuid.UUID, item.ServiceError) {
+	ctx, span := start(ctx, "item.Service/RemoveItem")
+	result, serviceError := s.Service.RemoveItem(ctx, id)
+	endWithServiceError(span, serviceError)
+	return result, serviceError
+}
+
+// GetPriceHistory ..
+func (s *itemService) GetPriceHistory(ctx context.Context, id uuid.UUID, page int64, pageSize int64) ([]item.PriceChange, item.ServiceError) {
+	ctx, span := start(ctx, "item.Service/GetPriceHistory")
+	result, serviceError := s.Service.GetPriceHistory(ctx, id, page, pageSize)
+	endWithServiceError(span, serviceError)
+	return result, serviceError
+}
+
+// GetPriceHistoryByItemIDs ..
+func (s *itemService) GetPriceHistoryByItemIDs(ctx context.Context, ids []uuid.UUID) ([]item.PriceChange, error) {
+	ctx, span := start(ctx, "item.Service/GetPriceHistoryByItemIDs")
+	result, err := s.Service.GetPriceHistoryByItemIDs(ctx, ids)
+	end(span, err)
+	return result, err
+}
+
+// GetScheduledPrices ..
+func (s *itemService) GetScheduledPrices(ctx context.Context, id uuid.UUID) ([]item.ScheduledPrice, item.ServiceError) {
+	ctx, span := start(ctx, "item.Service/GetScheduledPrices")
+	result, serviceError := s.Service.GetScheduledPrices(ctx, id)
+	endWithServiceError(span, serviceError)
+	return result, serviceError
+}
+
+// GetScheduledPricesByItemIDs ..
+func (s *itemService) GetScheduledPricesByItemIDs(ctx context.Context, ids []uuid.UUID) ([]item.ScheduledPrice, error) {
+	ctx, span := start(ctx, "item.Service/GetScheduledPricesByItemIDs")
+	result, err := s.Service.GetScheduledPricesByItemIDs(ctx, ids)
+	end(span, err)
+	return result, err
+}
+
+// SchedulePrice ..
+func (s *itemService) SchedulePrice(ctx context.Context, id uuid.UUID, price *item.ScheduledPriceDTO) (item.ScheduledPrice, item.ServiceError) {
+	ctx, span := start(ctx, "item.Service/SchedulePrice")
+	result, serviceError := s.Service.SchedulePrice(ctx, id, price)
+	endWithServiceError(span, serviceError)
+	return result, serviceError
+}
+
+// ApplyScheduledPrices ..
+func (s *itemService) ApplyScheduledPrices(ctx context.Context, now time.Time) ([]item.ScheduledPrice, error) {
+	ctx, span := start(ctx, "item.Service/ApplyScheduledPrices")
+	result, err := s.Service.ApplyScheduledPrices(ctx, now)
+	end(span, err)
+	return result, err
+}
+
+// endWithServiceError ends span, marking it as failed with serviceError and its status code when
+// it's set.
+func endWithServiceError(span trace.Span, serviceError item.ServiceError) {
+	if serviceError != nil {
+		span.SetAttributes(attribute.String("item.service_error", string(serviceError.StatusCode())))
+		span.SetStatus(codes.Error, serviceError.Message())
+	}
+	span.End()
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/item"
+)
+
+func useTestTracer(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return exporter
+}
+
+func Test_TracingItemService_GetItems_ShouldTraceRepositoryWithinService(t *testing.T) {
+	exporter := useTestTracer(t)
+
+	repository := NewItemRepository(&item.RepositoryMock{
+		GetItemsFunc: func(ctx context.Context, page int64, pageSize int64) ([]item.Item, error) {
+			return []item.Item{{ID: uuid.New()}}, nil
+		},
+	})
+	sut := NewItemService(item.NewService(repository))
+
+	if _, err := sut.GetItems(context.Background(), 0, 10); err != nil {
+		t.Fatalf("Should not have failed!")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	repositorySpan, serviceSpan := spans[0], spans[1]
+	if repositorySpan.Name != "item.Repository/GetItems" || serviceSpan.Name != "item.Service/GetItems" {
+		t.Fatalf("unexpected spans %q and %q", repositorySpan.Name, serviceSpan.Name)
+	}
+	if repositorySpan.Parent.SpanID() != serviceSpan.SpanContext.SpanID() {
+		t.Errorf("expected the repository span to be a child of the service span")
+	}
+}
+
+func Test_TracingItemService_RemoveItem_WhenItFails_ShouldMarkSpanAsError(t *testing.T) {
+	exporter := useTestTracer(t)
+
+	repository := NewItemRepository(&item.RepositoryMock{
+		RemoveItemFunc: func(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+			return id, sql.ErrNoRows
+		},
+	})
+	sut := NewItemService(item.NewService(repository))
+
+	if _, serviceError := sut.RemoveItem(context.Background(), uuid.New()); serviceError == nil {
+		t.Fatalf("Should have failed!")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	for _, span := range spans {
+		if span.Status.Code != codes.Error {
+			t.Errorf("expected span %q to be marked as an error, got %v", span.Name, span.Status)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// InstrumentationName is the instrumentation scope of the spans the service starts itself.
+	InstrumentationName = "github.com/tjmaynes/shopping-cart-service-go"
+
+	// ServiceName names the service in the spans it exports, unless OTEL_SERVICE_NAME is set.
+	ServiceName = "shopping-cart-service"
+)
+
+// Setup installs the W3C trace context propagator and a tracer provider exporting spans to
+// exporter: "otlp" over OTLP/HTTP, configured by the standard OTEL_EXPORTER_OTLP_* variables,
+// "stdout", or "none" (the default) to not record spans at all. The returned func flushes any
+// spans not yet exported and stops the provider.
+func Setup(ctx context.Context, exporter string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch strings.ToLower(exporter) {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		spanExporter, err = otlptracehttp.New(ctx)
+	case "stdout":
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("invalid trace exporter %q, must be otlp, stdout or none", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	serviceResource, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(ServiceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(serviceResource),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// start starts a span named name, as a child of any span in ctx, from the global tracer provider.
+func start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name)
+}
+
+// end ends span, marking it as failed with err when it's set.
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
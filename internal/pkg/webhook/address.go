@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+)
+
+// ErrInternalAddress is returned when a webhook would be delivered to the service's own network
+// rather than the public internet.
+var ErrInternalAddress = errors.New("webhook address is not publicly routable")
+
+var internalHostSuffixes = []string{".localhost", ".local", ".internal", ".cluster.local", ".svc"}
+
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isInternalHost reports whether host, a name or an IP literal, is one webhooks may not be sent
+// to. Names are only checked against well-known internal suffixes; what they resolve to is
+// checked again when the delivery is dialed.
+func isInternalHost(host string) bool {
+	if ip := net.ParseIP(host); ip != nil {
+		return isInternalIP(ip)
+	}
+
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || !strings.Contains(host, ".") {
+		return true
+	}
+	for _, suffix := range internalHostSuffixes {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() ||
+		sharedAddressSpace.Contains(ip)
+}
+
+// checkDialAddress refuses connections to internal addresses. It runs on the address actually
+// being dialed, after DNS resolution, so a name that is rebound to an internal address between
+// validation and delivery, or a redirect to one, is still refused.
+func checkDialAddress(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || isInternalIP(ip) {
+		return ErrInternalAddress
+	}
+	return nil
+}
+
+// newDeliveryClient returns a client that only connects to publicly routable addresses. Proxies
+// aren't used, so the address checked is always the receiver's.
+func newDeliveryClient() *http.Client {
+	dialer := &net.Dialer{Timeout: deliveryTimeout, Control: checkDialAddress}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: deliveryTimeout, Transport: transport}
+}
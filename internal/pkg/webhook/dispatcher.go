@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultBatchSize   = 20
+	defaultMaxAttempts = 8
+	deliveryTimeout    = 10 * time.Second
+	baseBackoff        = 10 * time.Second
+	maxBackoff         = time.Hour
+
+	// leaseMargin is how much longer a batch is leased for than sending each of its deliveries can
+	// take, to leave time for recording their outcomes.
+	leaseMargin = 30 * time.Second
+)
+
+// NewDispatcher ..
+func NewDispatcher(repository Repository, interval time.Duration) *Dispatcher {
+	return &Dispatcher{
+		Repository:  repository,
+		Client:      newDeliveryClient(),
+		Interval:    interval,
+		BatchSize:   defaultBatchSize,
+		MaxAttempts: defaultMaxAttempts,
+		Now:         time.Now,
+	}
+}
+
+// Dispatcher sends pending deliveries, retrying failures with exponential backoff until they
+// either succeed or exhaust MaxAttempts and are dead-lettered.
+type Dispatcher struct {
+	Repository  Repository
+	Client      *http.Client
+	Interval    time.Duration
+	BatchSize   int64
+	MaxAttempts int
+	Now         func() time.Time
+}
+
+// Run blocks, dispatching due deliveries every interval until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.Dispatch(ctx); err != nil {
+			slog.ErrorContext(ctx, "unable to dispatch webhook deliveries", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch sends a single batch of due deliveries and returns how many were attempted. The batch
+// is leased for long enough to send every delivery one after another, even if each takes until it
+// times out, and a delivery is only sent while there's still time for it within the lease; any
+// left over are sent once the lease has expired, so no other dispatcher sends them meanwhile.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	now := d.Now()
+	leaseUntil := now.Add(time.Duration(d.BatchSize)*deliveryTimeout + leaseMargin)
+
+	deliveries, err := d.Repository.ClaimDeliveries(ctx, now, leaseUntil, d.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for attempted, delivery := range deliveries {
+		if d.Now().Add(deliveryTimeout + leaseMargin).After(leaseUntil) {
+			slog.WarnContext(ctx, "leaving webhook deliveries until their lease expires", "remaining", len(deliveries)-attempted)
+			return attempted, nil
+		}
+
+		if err := d.deliver(ctx, delivery); err != nil {
+			slog.ErrorContext(ctx, "unable to record outcome of webhook delivery", "delivery_id", delivery.ID, "error", err)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// Backoff returns how long to wait before retrying a delivery that has failed attempts times.
+func Backoff(attempts int) time.Duration {
+	backoff := baseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return backoff
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery PendingDelivery) error {
+	now := d.Now()
+
+	request, err := http.NewRequestWithContext(ctx, "POST", delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return d.fail(ctx, delivery, now, err.Error(), nil)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, delivery.EventType)
+	request.Header.Set(DeliveryHeader, delivery.ID.String())
+	request.Header.Set(SignatureHeader, Sign(delivery.Secret, now, delivery.Payload))
+
+	response, err := d.Client.Do(request)
+	if err != nil {
+		return d.fail(ctx, delivery, now, err.Error(), nil)
+	}
+	io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+	response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		code := response.StatusCode
+		return d.fail(ctx, delivery, now, fmt.Sprintf("unexpected response status %d", code), &code)
+	}
+
+	return d.Repository.MarkDelivered(ctx, delivery.ID, response.StatusCode, now)
+}
+
+func (d *Dispatcher) fail(ctx context.Context, delivery PendingDelivery, now time.Time, reason string, responseCode *int) error {
+	attempts := delivery.Attempts + 1
+	if attempts >= d.MaxAttempts {
+		return d.Repository.MarkFailed(ctx, delivery.ID, Dead, now, reason, responseCode)
+	}
+
+	return d.Repository.MarkFailed(ctx, delivery.ID, Pending, now.Add(Backoff(attempts)), reason, responseCode)
+}
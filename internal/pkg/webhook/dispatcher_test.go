@@ -0,0 +1,226 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func Test_Dispatcher_Dispatch_WhenReceiverAccepts_ShouldSendSignedPayloadAndMarkDelivered(t *testing.T) {
+	secret := "a-very-secret-value"
+	payload := []byte(`{"id":1,"type":"item.created"}`)
+
+	var receivedBody []byte
+	var receivedHeader http.Header
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedBody, _ = io.ReadAll(r.Body)
+		receivedHeader = r.Header
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	delivery := PendingDelivery{ID: uuid.New(), EventID: 1, EventType: "item.created", Payload: payload, URL: receiver.URL, Secret: secret}
+	mockRepository := &RepositoryMock{
+		ClaimDeliveriesFunc: func(ctx context.Context, now time.Time, leaseUntil time.Time, limit int64) ([]PendingDelivery, error) {
+			return []PendingDelivery{delivery}, nil
+		},
+		MarkDeliveredFunc: func(ctx context.Context, id uuid.UUID, responseCode int, deliveredAt time.Time) error {
+			return nil
+		},
+	}
+
+	sut := NewDispatcher(mockRepository, time.Minute)
+	sut.Client = receiver.Client()
+
+	attempted, err := sut.Dispatch(context.Background())
+	if err != nil || attempted != 1 {
+		t.Fatalf("Expected a single delivery to be attempted. Got %d, %v", attempted, err)
+	}
+
+	if string(receivedBody) != string(payload) {
+		t.Errorf("Expected payload %s. Got %s", payload, receivedBody)
+	}
+
+	if !Verify(secret, receivedHeader.Get(SignatureHeader), receivedBody) {
+		t.Errorf("Expected a valid signature. Got %s", receivedHeader.Get(SignatureHeader))
+	}
+
+	if receivedHeader.Get(EventHeader) != "item.created" || receivedHeader.Get(DeliveryHeader) != delivery.ID.String() {
+		t.Errorf("Unexpected delivery headers: %+v", receivedHeader)
+	}
+
+	calls := mockRepository.MarkDeliveredCalls()
+	if len(calls) != 1 || calls[0].ID != delivery.ID || calls[0].ResponseCode != http.StatusNoContent {
+		t.Errorf("Expected delivery to be marked delivered. Got %+v", calls)
+	}
+}
+
+func Test_Dispatcher_Dispatch_WhenReceiverFails_ShouldScheduleRetryWithBackoff(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	delivery := PendingDelivery{ID: uuid.New(), Attempts: 2, Payload: []byte(`{}`), URL: receiver.URL, Secret: "a-very-secret-value"}
+	mockRepository := &RepositoryMock{
+		ClaimDeliveriesFunc: func(ctx context.Context, now time.Time, leaseUntil time.Time, limit int64) ([]PendingDelivery, error) {
+			return []PendingDelivery{delivery}, nil
+		},
+		MarkFailedFunc: func(ctx context.Context, id uuid.UUID, status DeliveryStatus, nextAttemptAt time.Time, lastError string, responseCode *int) error {
+			return nil
+		},
+	}
+
+	sut := NewDispatcher(mockRepository, time.Minute)
+	sut.Client = receiver.Client()
+	sut.Now = func() time.Time { return now }
+
+	if _, err := sut.Dispatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := mockRepository.MarkFailedCalls()
+	if len(calls) != 1 {
+		t.Fatalf("MarkFailed was called %d times", len(calls))
+	}
+
+	if calls[0].Status != Pending || !calls[0].NextAttemptAt.Equal(now.Add(Backoff(3))) {
+		t.Errorf("Expected a retry at %s. Got %s at %s", now.Add(Backoff(3)), calls[0].Status, calls[0].NextAttemptAt)
+	}
+
+	if calls[0].ResponseCode == nil || *calls[0].ResponseCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected the response code to be recorded. Got %v", calls[0].ResponseCode)
+	}
+}
+
+func Test_Dispatcher_Dispatch_WhenAttemptsAreExhausted_ShouldDeadLetterDelivery(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	delivery := PendingDelivery{ID: uuid.New(), Attempts: defaultMaxAttempts - 1, Payload: []byte(`{}`), URL: receiver.URL, Secret: "a-very-secret-value"}
+	mockRepository := &RepositoryMock{
+		ClaimDeliveriesFunc: func(ctx context.Context, now time.Time, leaseUntil time.Time, limit int64) ([]PendingDelivery, error) {
+			return []PendingDelivery{delivery}, nil
+		},
+		MarkFailedFunc: func(ctx context.Context, id uuid.UUID, status DeliveryStatus, nextAttemptAt time.Time, lastError string, responseCode *int) error {
+			return nil
+		},
+	}
+
+	sut := NewDispatcher(mockRepository, time.Minute)
+	sut.Client = receiver.Client()
+
+	if _, err := sut.Dispatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := mockRepository.MarkFailedCalls()
+	if len(calls) != 1 || calls[0].Status != Dead {
+		t.Errorf("Expected delivery to be dead-lettered. Got %+v", calls)
+	}
+}
+
+func Test_Dispatcher_Dispatch_WhenLeaseIsRunningOut_ShouldLeaveTheRestOfTheBatch(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	startedAt := now
+
+	var leaseUntilCalled time.Time
+	mockRepository := &RepositoryMock{
+		ClaimDeliveriesFunc: func(ctx context.Context, now time.Time, leaseUntil time.Time, limit int64) ([]PendingDelivery, error) {
+			leaseUntilCalled = leaseUntil
+			return []PendingDelivery{
+				{ID: uuid.New(), Payload: []byte(`{}`), URL: receiver.URL, Secret: "a-very-secret-value"},
+				{ID: uuid.New(), Payload: []byte(`{}`), URL: receiver.URL, Secret: "a-very-secret-value"},
+			}, nil
+		},
+		MarkDeliveredFunc: func(ctx context.Context, id uuid.UUID, responseCode int, deliveredAt time.Time) error {
+			// Recording the outcome is slow enough that the next delivery might outlast the lease.
+			now = now.Add(15 * time.Second)
+			return nil
+		},
+	}
+
+	sut := NewDispatcher(mockRepository, time.Minute)
+	sut.Client = receiver.Client()
+	sut.BatchSize = 2
+	sut.Now = func() time.Time { return now }
+
+	attempted, err := sut.Dispatch(context.Background())
+	if err != nil || attempted != 1 {
+		t.Fatalf("Expected a single delivery to be attempted. Got %d, %v", attempted, err)
+	}
+
+	if expected := startedAt.Add(2*deliveryTimeout + leaseMargin); !leaseUntilCalled.Equal(expected) {
+		t.Errorf("Expected the batch to be leased until %s. Got %s", expected, leaseUntilCalled)
+	}
+
+	if calls := mockRepository.MarkDeliveredCalls(); len(calls) != 1 {
+		t.Errorf("Expected only the first delivery to be sent. Got %+v", calls)
+	}
+}
+
+func Test_Dispatcher_Dispatch_WhenReceiverIsInternal_ShouldRefuseToConnect(t *testing.T) {
+	var received bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	delivery := PendingDelivery{ID: uuid.New(), Payload: []byte(`{}`), URL: receiver.URL, Secret: "a-very-secret-value"}
+	mockRepository := &RepositoryMock{
+		ClaimDeliveriesFunc: func(ctx context.Context, now time.Time, leaseUntil time.Time, limit int64) ([]PendingDelivery, error) {
+			return []PendingDelivery{delivery}, nil
+		},
+		MarkFailedFunc: func(ctx context.Context, id uuid.UUID, status DeliveryStatus, nextAttemptAt time.Time, lastError string, responseCode *int) error {
+			return nil
+		},
+	}
+
+	sut := NewDispatcher(mockRepository, time.Minute)
+
+	if _, err := sut.Dispatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if received {
+		t.Errorf("Expected the loopback receiver not to be called")
+	}
+
+	calls := mockRepository.MarkFailedCalls()
+	if len(calls) != 1 || !strings.Contains(calls[0].LastError, ErrInternalAddress.Error()) {
+		t.Errorf("Expected delivery to fail with %s. Got %+v", ErrInternalAddress, calls)
+	}
+}
+
+func Test_Backoff_ShouldDoubleUntilCapped(t *testing.T) {
+	var tests = []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{20, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if result := Backoff(tt.attempts); result != tt.expected {
+			t.Errorf("Expected backoff of %s after %d attempts. Got %s", tt.expected, tt.attempts, result)
+		}
+	}
+}
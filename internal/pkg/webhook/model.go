@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/google/uuid"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/event"
+)
+
+// DeliveryStatus ..
+type DeliveryStatus string
+
+const (
+	// Pending ..
+	Pending DeliveryStatus = "pending"
+
+	// Delivered ..
+	Delivered DeliveryStatus = "delivered"
+
+	// Dead deliveries exhausted their retries and will not be attempted again.
+	Dead DeliveryStatus = "dead"
+)
+
+// EventTypes lists the event types a subscription may ask for.
+var EventTypes = []interface{}{
+	string(event.ItemCreated),
+	string(event.ItemUpdated),
+	string(event.ItemDeleted),
+}
+
+// Subscription ..
+type Subscription struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Secret     string    `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SubscriptionDTO ..
+type SubscriptionDTO struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+}
+
+// Validate ..
+func (subscription SubscriptionDTO) Validate() error {
+	return validation.ValidateStruct(&subscription,
+		// URL must be an absolute http(s) URL
+		validation.Field(&subscription.URL, validation.Required, validation.By(isWebhookURL)),
+		// EventTypes must name at least one known event type
+		validation.Field(&subscription.EventTypes, validation.Required, validation.Each(validation.In(EventTypes...))),
+		// Secret must be long enough to sign payloads with
+		validation.Field(&subscription.Secret, validation.Required, validation.Length(16, 255)),
+	)
+}
+
+// Delivery ..
+type Delivery struct {
+	ID               uuid.UUID       `json:"id"`
+	SubscriptionID   uuid.UUID       `json:"subscription_id"`
+	EventID          int64           `json:"event_id"`
+	EventType        string          `json:"event_type"`
+	Payload          json.RawMessage `json:"payload"`
+	Status           DeliveryStatus  `json:"status"`
+	Attempts         int             `json:"attempts"`
+	NextAttemptAt    time.Time       `json:"next_attempt_at"`
+	LastError        string          `json:"last_error"`
+	LastResponseCode *int            `json:"last_response_code"`
+	CreatedAt        time.Time       `json:"created_at"`
+	DeliveredAt      *time.Time      `json:"delivered_at"`
+}
+
+// PendingDelivery is a claimed delivery along with where and how to send it.
+type PendingDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        int64
+	EventType      string
+	Payload        json.RawMessage
+	Attempts       int
+	URL            string
+	Secret         string
+}
+
+func isWebhookURL(value interface{}) error {
+	rawURL, _ := value.(string)
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("must be an absolute http or https URL")
+	}
+
+	if isInternalHost(parsed.Hostname()) {
+		return errors.New("must not be a loopback, private or internal address")
+	}
+
+	return nil
+}
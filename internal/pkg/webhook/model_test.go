@@ -0,0 +1,52 @@
+package webhook
+
+import "testing"
+
+func Test_SubscriptionDTO_Validate_WhenGivenValidSubscription_ShouldReturnNoErrors(t *testing.T) {
+	subscription := SubscriptionDTO{
+		URL:        "https://search.example.com/hooks/items",
+		EventTypes: []string{"item.created", "item.deleted"},
+		Secret:     "a-very-secret-value",
+	}
+
+	if err := subscription.Validate(); err != nil {
+		t.Errorf("Expected no errors. Got %s", err)
+	}
+}
+
+func Test_SubscriptionDTO_Validate_WhenGivenBadSubscription_ShouldReturnErrors(t *testing.T) {
+	subscription := SubscriptionDTO{
+		URL:        "ftp://example.com",
+		EventTypes: []string{"order.created"},
+		Secret:     "short",
+	}
+
+	err := subscription.Validate()
+	expectedErrors := "event_types: (0: must be a valid value.); secret: the length must be between 16 and 255; url: must be an absolute http or https URL."
+	if err == nil || err.Error() != expectedErrors {
+		t.Errorf("Expected %s, Received %v", expectedErrors, err)
+	}
+}
+
+func Test_SubscriptionDTO_Validate_WhenURLIsInternal_ShouldReturnError(t *testing.T) {
+	var tests = []string{
+		"http://localhost:8080/hooks",
+		"http://127.0.0.1/hooks",
+		"http://169.254.169.254/latest/meta-data",
+		"http://10.0.0.12/hooks",
+		"http://[::1]/hooks",
+		"http://[fd00::1]/hooks",
+		"http://redis:6379",
+		"http://search.default.svc.cluster.local/hooks",
+		"http://metadata.google.internal/computeMetadata/v1",
+	}
+
+	for _, rawURL := range tests {
+		subscription := SubscriptionDTO{URL: rawURL, EventTypes: []string{"item.created"}, Secret: "a-very-secret-value"}
+
+		expectedErrors := "url: must not be a loopback, private or internal address."
+		if err := subscription.Validate(); err == nil || err.Error() != expectedErrors {
+			t.Errorf("Expected %s for %s, Received %v", expectedErrors, rawURL, err)
+		}
+	}
+}
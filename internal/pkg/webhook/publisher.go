@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/event"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/outbox"
+)
+
+// NewOutboxPublisher ..
+func NewOutboxPublisher(service Service) *OutboxPublisher {
+	return &OutboxPublisher{Service: service}
+}
+
+// OutboxPublisher enqueues webhook deliveries for each item change relayed from the outbox, so
+// that every committed change is delivered even if no replica was running when it was made.
+// Deliveries are keyed by the event's id, so relaying a message again doesn't enqueue it twice.
+type OutboxPublisher struct {
+	Service Service
+}
+
+// Publish ..
+func (p *OutboxPublisher) Publish(ctx context.Context, message outbox.Message) error {
+	e, err := event.FromMessage(message)
+	if err != nil {
+		return err
+	}
+
+	return p.Service.Enqueue(ctx, e)
+}
@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/event"
+)
+
+// Repository ..
+type Repository interface {
+	GetSubscriptions(ctx context.Context, page int64, pageSize int64) ([]Subscription, error)
+	GetSubscriptionByID(ctx context.Context, id uuid.UUID) (Subscription, error)
+	AddSubscription(ctx context.Context, url string, eventTypes []string, secret string) (Subscription, error)
+	RemoveSubscription(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, page int64, pageSize int64) ([]Delivery, error)
+	EnqueueDeliveries(ctx context.Context, e event.Event, payload []byte) (int64, error)
+	ClaimDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int64) ([]PendingDelivery, error)
+	MarkDelivered(ctx context.Context, id uuid.UUID, responseCode int, deliveredAt time.Time) error
+	MarkFailed(ctx context.Context, id uuid.UUID, status DeliveryStatus, nextAttemptAt time.Time, lastError string, responseCode *int) error
+}
+
+// NewRepository ..
+func NewRepository(DBConn *sql.DB) Repository {
+	return &repository{DBConn: DBConn}
+}
+
+// repository ..
+type repository struct {
+	DBConn *sql.DB
+}
+
+// GetSubscriptions ..
+func (r *repository) GetSubscriptions(ctx context.Context, page int64, pageSize int64) ([]Subscription, error) {
+	limit := pageSize
+	offset := page * pageSize
+
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT id, url, event_types, secret, created_at FROM webhook_subscription ORDER BY created_at LIMIT $1 OFFSET $2", limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payload := make([]Subscription, 0)
+	for rows.Next() {
+		data := new(Subscription)
+		err := rows.Scan(&data.ID, &data.URL, (*pq.StringArray)(&data.EventTypes), &data.Secret, &data.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, *data)
+	}
+
+	return payload, nil
+}
+
+// GetSubscriptionByID ..
+func (r *repository) GetSubscriptionByID(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	var subscription Subscription
+	row := r.DBConn.QueryRowContext(ctx, "SELECT id, url, event_types, secret, created_at FROM webhook_subscription WHERE id = $1", id)
+	err := row.Scan(&subscription.ID, &subscription.URL, (*pq.StringArray)(&subscription.EventTypes), &subscription.Secret, &subscription.CreatedAt)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	return subscription, nil
+}
+
+// AddSubscription ..
+func (r *repository) AddSubscription(ctx context.Context, url string, eventTypes []string, secret string) (Subscription, error) {
+	subscription := Subscription{URL: url, EventTypes: eventTypes, Secret: secret}
+	insertStm := "INSERT INTO webhook_subscription (url, event_types, secret) VALUES ($1, $2, $3) RETURNING id, created_at"
+	err := r.DBConn.QueryRowContext(ctx, insertStm, url, pq.StringArray(eventTypes), secret).Scan(&subscription.ID, &subscription.CreatedAt)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	return subscription, nil
+}
+
+// RemoveSubscription ..
+func (r *repository) RemoveSubscription(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	_, err := r.DBConn.ExecContext(ctx, "DELETE FROM webhook_subscription WHERE id = $1", id)
+	if err != nil {
+		return id, err
+	}
+
+	return id, nil
+}
+
+// GetDeliveries ..
+func (r *repository) GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, page int64, pageSize int64) ([]Delivery, error) {
+	limit := pageSize
+	offset := page * pageSize
+
+	rows, err := r.DBConn.QueryContext(ctx, "SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, last_response_code, created_at, delivered_at FROM webhook_delivery WHERE subscription_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3", subscriptionID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payload := make([]Delivery, 0)
+	for rows.Next() {
+		var body []byte
+		var responseCode sql.NullInt64
+		data := new(Delivery)
+		err := rows.Scan(&data.ID, &data.SubscriptionID, &data.EventID, &data.EventType, &body, &data.Status, &data.Attempts, &data.NextAttemptAt, &data.LastError, &responseCode, &data.CreatedAt, &data.DeliveredAt)
+		if err != nil {
+			return nil, err
+		}
+		data.Payload = body
+		if responseCode.Valid {
+			code := int(responseCode.Int64)
+			data.LastResponseCode = &code
+		}
+		payload = append(payload, *data)
+	}
+
+	return payload, nil
+}
+
+// EnqueueDeliveries creates a pending delivery of the event for every subscription interested in
+// its type. Enqueueing the same event twice is a no-op, so every replica may enqueue every event.
+func (r *repository) EnqueueDeliveries(ctx context.Context, e event.Event, payload []byte) (int64, error) {
+	insertStm := "INSERT INTO webhook_delivery (subscription_id, event_id, event_type, payload) SELECT id, $1, $2, $3 FROM webhook_subscription WHERE $2 = ANY (event_types) ON CONFLICT (subscription_id, event_id) DO NOTHING"
+	result, err := r.DBConn.ExecContext(ctx, insertStm, e.ID, string(e.Type), string(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// ClaimDeliveries leases up to limit due deliveries by pushing their next attempt out to
+// leaseUntil, so no other dispatcher picks them up while they are being sent.
+func (r *repository) ClaimDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int64) ([]PendingDelivery, error) {
+	claimStm := `UPDATE webhook_delivery AS d SET next_attempt_at = $2
+FROM webhook_subscription AS s
+WHERE s.id = d.subscription_id AND d.id IN (
+  SELECT id FROM webhook_delivery WHERE status = 'pending' AND next_attempt_at <= $1 ORDER BY next_attempt_at LIMIT $3 FOR UPDATE SKIP LOCKED
+)
+RETURNING d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.attempts, s.url, s.secret`
+
+	rows, err := r.DBConn.QueryContext(ctx, claimStm, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payload := make([]PendingDelivery, 0)
+	for rows.Next() {
+		var body []byte
+		data := new(PendingDelivery)
+		err := rows.Scan(&data.ID, &data.SubscriptionID, &data.EventID, &data.EventType, &body, &data.Attempts, &data.URL, &data.Secret)
+		if err != nil {
+			return nil, err
+		}
+		data.Payload = body
+		payload = append(payload, *data)
+	}
+
+	return payload, nil
+}
+
+// MarkDelivered ..
+func (r *repository) MarkDelivered(ctx context.Context, id uuid.UUID, responseCode int, deliveredAt time.Time) error {
+	_, err := r.DBConn.ExecContext(ctx, "UPDATE webhook_delivery SET status = $1, attempts = attempts + 1, last_error = '', last_response_code = $2, delivered_at = $3 WHERE id = $4", Delivered, responseCode, deliveredAt, id)
+	return err
+}
+
+// MarkFailed ..
+func (r *repository) MarkFailed(ctx context.Context, id uuid.UUID, status DeliveryStatus, nextAttemptAt time.Time, lastError string, responseCode *int) error {
+	_, err := r.DBConn.ExecContext(ctx, "UPDATE webhook_delivery SET status = $1, attempts = attempts + 1, next_attempt_at = $2, last_error = $3, last_response_code = $4 WHERE id = $5", status, nextAttemptAt, lastError, responseCode, id)
+	return err
+}
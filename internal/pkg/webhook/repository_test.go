@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/event"
+)
+
+func Test_WebhookRepository_EnqueueDeliveries_ShouldIgnoreDuplicateEvents(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	published := event.Event{ID: 9, Type: event.ItemUpdated}
+
+	mock.ExpectExec("INSERT INTO webhook_delivery \\(subscription_id, event_id, event_type, payload\\) SELECT id, \\$1, \\$2, \\$3 FROM webhook_subscription WHERE \\$2 = ANY \\(event_types\\) ON CONFLICT \\(subscription_id, event_id\\) DO NOTHING").
+		WithArgs(published.ID, "item.updated", `{}`).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	sut := NewRepository(dbConn)
+
+	enqueued, err := sut.EnqueueDeliveries(context.Background(), published, []byte(`{}`))
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when enqueueing deliveries", err)
+	}
+
+	if enqueued != 2 {
+		t.Errorf("Expected 2 deliveries to be enqueued. Got %d", enqueued)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_WebhookRepository_ClaimDeliveries_ShouldLeaseDueDeliveries(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbConn.Close()
+
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+	deliveryID := uuid.New()
+	subscriptionID := uuid.New()
+
+	mock.ExpectQuery("UPDATE webhook_delivery AS d SET next_attempt_at = \\$2 (.+) FOR UPDATE SKIP LOCKED").
+		WithArgs(now, leaseUntil, 20).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "subscription_id", "event_id", "event_type", "payload", "attempts", "url", "secret"}).
+				AddRow(deliveryID, subscriptionID, 9, "item.updated", []byte(`{}`), 1, "https://example.com/hook", "a-very-secret-value"),
+		).
+		RowsWillBeClosed()
+
+	sut := NewRepository(dbConn)
+
+	result, err := sut.ClaimDeliveries(context.Background(), now, leaseUntil, 20)
+	if err != nil {
+		t.Fatalf("Error '%s' was not expected when claiming deliveries", err)
+	}
+
+	if len(result) != 1 || result[0].ID != deliveryID || result[0].Attempts != 1 || result[0].URL != "https://example.com/hook" {
+		t.Fatalf("Unexpected deliveries were given, '%+v'.", result)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/event"
+)
+
+// Service ..
+type Service interface {
+	GetSubscriptions(ctx context.Context, page int64, pageSize int64) ([]Subscription, error)
+	GetSubscriptionByID(ctx context.Context, id uuid.UUID) (Subscription, ServiceError)
+	AddSubscription(
+		ctx context.Context,
+		subscription *SubscriptionDTO,
+	) (Subscription, ServiceError)
+	RemoveSubscription(ctx context.Context, id uuid.UUID) (uuid.UUID, ServiceError)
+	GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, page int64, pageSize int64) ([]Delivery, ServiceError)
+	Enqueue(ctx context.Context, e event.Event) error
+}
+
+// NewService ..
+func NewService(repository Repository) Service {
+	return &service{
+		Repository: repository,
+	}
+}
+
+type service struct {
+	Repository Repository
+}
+
+// GetSubscriptions ..
+func (s *service) GetSubscriptions(ctx context.Context, page int64, pageSize int64) ([]Subscription, error) {
+	return s.Repository.GetSubscriptions(ctx, page, pageSize)
+}
+
+// GetSubscriptionByID ..
+func (s *service) GetSubscriptionByID(ctx context.Context, id uuid.UUID) (Subscription, ServiceError) {
+	result, err := s.Repository.GetSubscriptionByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Subscription{}, CreateServiceError(err.Error(), SubscriptionNotFound)
+	} else if err != nil {
+		return Subscription{}, CreateServiceError(err.Error(), UnknownException)
+	}
+
+	return result, nil
+}
+
+// AddSubscription ..
+func (s *service) AddSubscription(ctx context.Context, subscription *SubscriptionDTO) (Subscription, ServiceError) {
+	err := subscription.Validate()
+	if err != nil {
+		return Subscription{}, CreateServiceError(err.Error(), InvalidSubscription)
+	}
+
+	result, err := s.Repository.AddSubscription(ctx, subscription.URL, subscription.EventTypes, subscription.Secret)
+	if err != nil {
+		return Subscription{}, CreateServiceError(err.Error(), UnknownException)
+	}
+
+	return result, nil
+}
+
+// RemoveSubscription ..
+func (s *service) RemoveSubscription(ctx context.Context, id uuid.UUID) (uuid.UUID, ServiceError) {
+	result, err := s.Repository.RemoveSubscription(ctx, id)
+	if err != nil {
+		return id, CreateServiceError(err.Error(), UnknownException)
+	}
+
+	return result, nil
+}
+
+// GetDeliveries ..
+func (s *service) GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, page int64, pageSize int64) ([]Delivery, ServiceError) {
+	_, serviceError := s.GetSubscriptionByID(ctx, subscriptionID)
+	if serviceError != nil {
+		return nil, serviceError
+	}
+
+	result, err := s.Repository.GetDeliveries(ctx, subscriptionID, page, pageSize)
+	if err != nil {
+		return nil, CreateServiceError(err.Error(), UnknownException)
+	}
+
+	return result, nil
+}
+
+// Enqueue ..
+func (s *service) Enqueue(ctx context.Context, e event.Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Repository.EnqueueDeliveries(ctx, e, payload)
+	return err
+}
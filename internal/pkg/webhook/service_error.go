@@ -0,0 +1,39 @@
+package webhook
+
+// ServiceStatusCode ..
+type ServiceStatusCode string
+
+// ServiceError ..
+type ServiceError interface {
+	Message() string
+	StatusCode() ServiceStatusCode
+}
+
+const (
+	// SubscriptionNotFound ..
+	SubscriptionNotFound ServiceStatusCode = "SubscriptionNotFound"
+
+	// InvalidSubscription ..
+	InvalidSubscription ServiceStatusCode = "InvalidSubscription"
+
+	// UnknownException ..
+	UnknownException ServiceStatusCode = "UnknownException"
+)
+
+// CreateServiceError ..
+func CreateServiceError(message string, statusCode ServiceStatusCode) ServiceError {
+	return &serviceError{message: message, statusCode: statusCode}
+}
+
+type serviceError struct {
+	message    string
+	statusCode ServiceStatusCode
+}
+
+func (s *serviceError) Message() string {
+	return s.message
+}
+
+func (s *serviceError) StatusCode() ServiceStatusCode {
+	return s.statusCode
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/event"
+	"github.com/tjmaynes/shopping-cart-service-go/internal/pkg/outbox"
+)
+
+func Test_WebhookService_AddSubscription_WhenGivenInvalidSubscription_ShouldReturnServiceError(t *testing.T) {
+	mockRepository := &RepositoryMock{}
+	sut := NewService(mockRepository)
+
+	_, serviceError := sut.AddSubscription(context.Background(), &SubscriptionDTO{URL: "not a url"})
+	if serviceError == nil || serviceError.StatusCode() != InvalidSubscription {
+		t.Fatalf("Expected an %s service error. Got %+v", InvalidSubscription, serviceError)
+	}
+
+	if calls := len(mockRepository.AddSubscriptionCalls()); calls != 0 {
+		t.Errorf("AddSubscription was called %d times", calls)
+	}
+}
+
+func Test_WebhookService_GetDeliveries_WhenSubscriptionDoesNotExist_ShouldReturnServiceError(t *testing.T) {
+	mockRepository := &RepositoryMock{
+		GetSubscriptionByIDFunc: func(ctx context.Context, id uuid.UUID) (Subscription, error) {
+			return Subscription{}, sql.ErrNoRows
+		},
+	}
+	sut := NewService(mockRepository)
+
+	_, serviceError := sut.GetDeliveries(context.Background(), uuid.New(), 0, 10)
+	if serviceError == nil || serviceError.StatusCode() != SubscriptionNotFound {
+		t.Fatalf("Expected an %s service error. Got %+v", SubscriptionNotFound, serviceError)
+	}
+}
+
+func Test_WebhookService_Enqueue_ShouldEnqueueEventAsPayload(t *testing.T) {
+	mockRepository := &RepositoryMock{
+		EnqueueDeliveriesFunc: func(ctx context.Context, e event.Event, payload []byte) (int64, error) {
+			return 1, nil
+		},
+	}
+	sut := NewService(mockRepository)
+
+	published := event.Event{ID: 3, Type: event.ItemDeleted, ItemID: uuid.New()}
+	if err := sut.Enqueue(context.Background(), published); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := mockRepository.EnqueueDeliveriesCalls()
+	if len(calls) != 1 {
+		t.Fatalf("EnqueueDeliveries was called %d times", len(calls))
+	}
+
+	var payload event.Event
+	if err := json.Unmarshal(calls[0].Payload, &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if payload.ID != published.ID || payload.Type != published.Type {
+		t.Errorf("Expected payload for event %d. Got %+v", published.ID, payload)
+	}
+}
+
+func Test_OutboxPublisher_Publish_ShouldEnqueueDeliveriesForTheMessagesEvent(t *testing.T) {
+	itemID := uuid.New()
+	mockRepository := &RepositoryMock{
+		EnqueueDeliveriesFunc: func(ctx context.Context, e event.Event, payload []byte) (int64, error) {
+			return 1, nil
+		},
+	}
+	sut := NewOutboxPublisher(NewService(mockRepository))
+
+	err := sut.Publish(context.Background(), outbox.Message{ID: 42, Topic: "item.created", Key: itemID.String(), Payload: []byte(`{}`)})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	calls := mockRepository.EnqueueDeliveriesCalls()
+	if len(calls) != 1 || calls[0].E.ID != 42 || calls[0].E.Type != event.ItemCreated || calls[0].E.ItemID != itemID {
+		t.Errorf("Expected deliveries to be enqueued for event 42. Got %+v", calls)
+	}
+}
@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the timestamp and HMAC-SHA256 signature of a delivery.
+	SignatureHeader = "X-Webhook-Signature"
+
+	// EventHeader ..
+	EventHeader = "X-Webhook-Event"
+
+	// DeliveryHeader ..
+	DeliveryHeader = "X-Webhook-Delivery"
+)
+
+// Sign returns the value of the signature header for a payload sent at the given time. The
+// signature covers "<unix timestamp>.<payload>" so receivers can reject replayed deliveries.
+func Sign(secret string, timestamp time.Time, payload []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", unix, computeSignature(secret, unix, payload))
+}
+
+// Verify checks a signature header produced by Sign.
+func Verify(secret string, header string, payload []byte) bool {
+	var unix, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			signature = value
+		}
+	}
+
+	if unix == "" || signature == "" {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(computeSignature(secret, unix, payload)))
+}
+
+func computeSignature(secret string, unix string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
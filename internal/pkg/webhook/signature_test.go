@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_Sign_ShouldProduceSignatureThatVerifies(t *testing.T) {
+	payload := []byte(`{"id":1,"type":"item.created"}`)
+	header := Sign("a-very-secret-value", time.Unix(1700000000, 0), payload)
+
+	if header[:13] != "t=1700000000," {
+		t.Errorf("Expected the signature to carry its timestamp. Got %s", header)
+	}
+
+	if !Verify("a-very-secret-value", header, payload) {
+		t.Errorf("Expected signature %s to verify", header)
+	}
+}
+
+func Test_Verify_WhenPayloadOrSecretDiffers_ShouldFail(t *testing.T) {
+	payload := []byte(`{"id":1,"type":"item.created"}`)
+	header := Sign("a-very-secret-value", time.Now(), payload)
+
+	if Verify("another-secret-value", header, payload) {
+		t.Errorf("Expected signature to be rejected for a different secret")
+	}
+
+	if Verify("a-very-secret-value", header, []byte(`{"id":2}`)) {
+		t.Errorf("Expected signature to be rejected for a different payload")
+	}
+
+	if Verify("a-very-secret-value", "garbage", payload) {
+		t.Errorf("Expected a malformed header to be rejected")
+	}
+}
@@ -27,6 +27,7 @@ function main() {
   docker run --rm \
     --env DATABASE_URL=$DATABASE_URL \
     --env PORT=$PORT \
+    --env AUTH_DISABLED=true \
     --publish $PORT:$PORT \
     $REGISTRY_USERNAME/$IMAGE_NAME:$TAG
 }
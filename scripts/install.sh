@@ -3,7 +3,6 @@
 set -e
 
 PACK_VERSION=v0.33.2
-DBMATE_VERSION=v2.16.0
 
 function check_requirements() {
   if [[ -z "$(command -v curl)" ]]; then
@@ -12,24 +11,6 @@ function check_requirements() {
   fi
 }
 
-function download_and_install_dbmate() {
-  if [[ "$OSTYPE" == "darwin"* ]]; then
-    if [[ `uname -m` == 'arm64' ]]; then
-      DBMATE_SOURCE_URL="https://github.com/amacneil/dbmate/releases/download/$DBMATE_VERSION/dbmate-macos-arm64"
-    else
-      DBMATE_SOURCE_URL="https://github.com/amacneil/dbmate/releases/download/$DBMATE_VERSION/dbmate-macos-amd64"
-    fi
-  elif [[ "$OSTYPE" == "linux-gnu"* ]]; then
-    DBMATE_SOURCE_URL="https://github.com/amacneil/dbmate/releases/download/$DBMATE_VERSION/dbmate-linux-amd64"
-  else
-    echo "Please install 'dbmate' using a supported operating system (macos or linux)"
-    exit 1
-  fi
-
-  (mkdir -p bin || true) && curl -sSL "$DBMATE_SOURCE_URL" > bin/dbmate
-  chmod +x ./bin/dbmate
-}
-
 function download_and_install_pack() {
   if [[ "$OSTYPE" == "darwin"* ]]; then
     if [[ `uname -m` == 'arm64' ]]; then
@@ -59,15 +40,14 @@ function main() {
     download_and_install_pack
   fi
 
-  if [[ -z "$(command -v bin/dbmate)" ]]; then
-    download_and_install_dbmate
-  fi
-
   if [[ -n "$(command -v asdf)" ]]; then
     asdf install
   fi
 
   go install github.com/matryer/moq@latest
+  go install github.com/bufbuild/buf/cmd/buf@latest
+  go install google.golang.org/protobuf/cmd/protoc-gen-go@latest
+  go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest
   go install github.com/jstemmer/go-junit-report/v2@latest
   go install github.com/axw/gocov/gocov@latest
   go install github.com/AlekSi/gocov-xml@latest